@@ -31,6 +31,21 @@ type BrokerAppSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ServiceSelector"
 	ServiceSelector *metav1.LabelSelector `json:"selector,omitempty"`
 
+	// ServiceSelectorExpression is a CEL expression that states which BrokerServices the app
+	// can be placed on, on top of the label selector.
+	//
+	// The expression has access to the same variables and functions as the appSelectorExpression
+	// of a BrokerService: app, service, appNamespace, serviceNamespace and load.
+	//
+	// Empty or nil (default): every service selected by the label selector.
+	//
+	// The expression must evaluate to a boolean. A service for which the expression does not
+	// evaluate is not selected. The app is rebound when its service no longer matches.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Selector Expression"
+	ServiceSelectorExpression string `json:"serviceSelectorExpression,omitempty"`
+
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Addresses"
 	// Addresses with a lifecycle tied to this app, independent from addressRefs.
 	// These are private addresses that cannot be referenced by other apps.
@@ -45,8 +60,58 @@ type BrokerAppSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Messaging Capabilities"
 	Capabilities []AppCapabilityType `json:"capabilities,omitempty"`
 
+	// Routes divert the messages sent to an address to another address, both addresses are owned by the app
+	// or shared with it
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Routes"
+	Routes []RouteType `json:"routes,omitempty"`
+
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources"
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ClientCertSubject is the subject of the client cert that authenticates as this app, the
+	// distinguished name must match exactly. Defaults to CN=<name>,OU=<namespace>.
+	// The client cert issued by the operator has this subject.
+	// Apps on a service must have distinct subjects
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Cert Subject"
+	ClientCertSubject *ClientCertSubjectType `json:"clientCertSubject,omitempty"`
+
+	// MaxConnections is the number of connections the app can open to its acceptor, enforced by the broker.
+	// Counts against the connection capacity of the service, required when the service bounds connections
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Connections",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// PlacementStrategy chooses the service and peer of the app among those with capacity.
+	// Without it the service is chosen with spread, the least utilized across all bounded capacity
+	// dimensions rather than the most free memory of earlier releases, and the peer with the
+	// placementStrategy of that service
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Placement Strategy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PlacementStrategy PlacementStrategy `json:"placementStrategy,omitempty"`
+
+	// StatisticsInterval is how often the statistics of the queues of the app are collected into status.addresses,
+	// defaults to 1m and is bounded to between 15s and 15m
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Statistics Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	StatisticsInterval *metav1.Duration `json:"statisticsInterval,omitempty"`
+}
+
+// ClientCertSubjectType describes the subject distinguished name of a client cert
+type ClientCertSubjectType struct {
+	// CommonName (CN) of the subject
+	//+kubebuilder:validation:MinLength=1
+	CommonName string `json:"commonName"`
+
+	// OrganizationalUnits (OU) of the subject
+	//+optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+
+	// Organizations (O) of the subject
+	//+optional
+	Organizations []string `json:"organizations,omitempty"`
 }
 
 // AddressType defines a messaging address
@@ -63,14 +128,113 @@ type AddressType struct {
 	// Typical values will be of the form <client id>.<subscription nname>
 	// +optional
 	Subscriptions []string `json:"subscriptions,omitempty"`
+
+	// SubscriptionSettings declares the queue attributes of subscriptions
+	// +optional
+	SubscriptionSettings []SubscriptionSettingsType `json:"subscriptionSettings,omitempty"`
+
+	// Settings declares the delivery policy of the address
+	// +optional
+	Settings *AddressPolicyType `json:"settings,omitempty"`
+
+	// Access restricts the apps that reference the address with producerOf or consumerOf,
+	// only for sharedAddresses. Any app on the service can reference the address when unset
+	// +optional
+	Access *AddressAccessPolicyType `json:"access,omitempty"`
+}
+
+// AddressAccessPolicyType grants the produce and consume permissions of a shared address
+// to the apps that reference it
+type AddressAccessPolicyType struct {
+	// Produce grants the send permission to the matching apps, no app is granted when unset
+	// +optional
+	Produce *AddressAccessRuleType `json:"produce,omitempty"`
+
+	// Consume grants the consume permission to the matching apps, no app is granted when unset
+	// +optional
+	Consume *AddressAccessRuleType `json:"consume,omitempty"`
+}
+
+// AddressAccessRuleType matches the apps that reference a shared address, an app matches
+// when it matches each of the criteria that are set. An empty rule matches any app
+type AddressAccessRuleType struct {
+	// Namespaces of the matching apps
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Apps are the names of the matching apps
+	// +optional
+	Apps []string `json:"apps,omitempty"`
+
+	// Selector matches the labels of the apps
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Expression is a CEL expression with the variables app, the referencing BrokerApp, and owner,
+	// the BrokerApp sharing the address, and the functions of appSelectorExpression.
+	// An app for which the expression does not evaluate does not match
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+// AddressPolicyType defines the delivery policy of an address owned by an app.
+// Dead letter and expiry addresses are declared as anycast addresses owned by the app,
+// with a queue of the same name that the app can consume from.
+type AddressPolicyType struct {
+	// DeadLetterAddress is the address that receives messages that exceed MaxDeliveryAttempts
+	// +optional
+	DeadLetterAddress *string `json:"deadLetterAddress,omitempty"`
+
+	// MaxDeliveryAttempts is the number of delivery attempts before a message is sent to the DeadLetterAddress, -1 means no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxDeliveryAttempts *int32 `json:"maxDeliveryAttempts,omitempty"`
+
+	// RedeliveryDelay is the time in milliseconds to wait before redelivering a cancelled message
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RedeliveryDelay *int64 `json:"redeliveryDelay,omitempty"`
+
+	// RedeliveryMultiplier is the factor applied to the redelivery delay on each attempt, like 2 or 1.5
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	RedeliveryMultiplier *string `json:"redeliveryMultiplier,omitempty"`
+
+	// MaxRedeliveryDelay is the maximum time in milliseconds the redelivery delay backs off to
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRedeliveryDelay *int64 `json:"maxRedeliveryDelay,omitempty"`
+
+	// ExpiryAddress is the address that receives expired messages
+	// +optional
+	ExpiryAddress *string `json:"expiryAddress,omitempty"`
+
+	// ExpiryDelay is the expiration time in milliseconds applied to messages sent without one, -1 disables it
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	ExpiryDelay *int64 `json:"expiryDelay,omitempty"`
+
+	// MaxSizeBytes is the maximum size of the address, -1 means no limit. Supports byte notation like K, Mb, GB, etc.
+	// +kubebuilder:validation:Pattern=`^(-1|[0-9]+([KkMmGg]([Ii]?[Bb])?)?)$`
+	// +optional
+	MaxSizeBytes *string `json:"maxSizeBytes,omitempty"`
+
+	// AddressFullPolicy is what happens when the address reaches MaxSizeBytes
+	// +kubebuilder:validation:Enum=PAGE;BLOCK;FAIL;DROP
+	// +optional
+	AddressFullPolicy *string `json:"addressFullPolicy,omitempty"`
 }
 
 // AddressRef references an address for use in capabilities
 type AddressRef struct {
 	// Address is the address identifier (required)
+	// In consumerOf, with subscriptions, words of the address may be the wildcards '*' (one word) and '#' (any words),
+	// words are separated by '.'. A wildcard may only match addresses of the app or shared addresses it may consume
 	Address string `json:"address"`
 
 	// AppNamespace of owning app - for cross-app references  (optional)
+	// Only consumerOf references are linked to an owner on another service, the other
+	// capabilities and routes place the app on the service of the owner
 	AppNamespace string `json:"appNamespace,omitempty"`
 
 	// AppName of owning app - for cross-app references  (optional)
@@ -85,12 +249,127 @@ type AddressRef struct {
 	// Typical values will be of the form <client id>.<subscription nname>
 	// +optional
 	Subscriptions []string `json:"subscriptions,omitempty"`
+
+	// SubscriptionSettings declares the queue attributes of subscriptions, used with ConsumerOf
+	// +optional
+	SubscriptionSettings []SubscriptionSettingsType `json:"subscriptionSettings,omitempty"`
+}
+
+// SubscriptionSettingsType declares the queue attributes of a subscription. An attribute that is unset
+// after it was set is reset to the broker default
+type SubscriptionSettingsType struct {
+	// Subscription is the name of the subscription, one of the subscriptions of the address
+	// +kubebuilder:validation:MinLength=1
+	Subscription string `json:"subscription"`
+
+	// FilterString selects the messages the subscription receives
+	// +optional
+	FilterString *string `json:"filterString,omitempty"`
+
+	// Exclusive dispatches the messages to a single consumer at a time
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+
+	// LastValue retains only the last message of each value of LastValueKey, only applied when the queue is created
+	// +optional
+	LastValue *bool `json:"lastValue,omitempty"`
+
+	// LastValueKey is the message property that identifies last values, only applied when the queue is created
+	// +optional
+	LastValueKey *string `json:"lastValueKey,omitempty"`
+
+	// NonDestructive retains the messages that are consumed
+	// +optional
+	NonDestructive *bool `json:"nonDestructive,omitempty"`
+
+	// RingSize is the number of messages the subscription retains, -1 means no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	RingSize *int64 `json:"ringSize,omitempty"`
+
+	// MaxConsumers is the maximum number of consumers of the subscription, -1 means no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	MaxConsumers *int32 `json:"maxConsumers,omitempty"`
+
+	// ConsumersBeforeDispatch is the number of consumers required before messages are dispatched
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ConsumersBeforeDispatch *int32 `json:"consumersBeforeDispatch,omitempty"`
+
+	// DelayBeforeDispatch is the time in milliseconds to wait for ConsumersBeforeDispatch before dispatching anyway, -1 waits forever
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	DelayBeforeDispatch *int64 `json:"delayBeforeDispatch,omitempty"`
+
+	// GroupRebalance rebalances the message groups when a consumer is added
+	// +optional
+	GroupRebalance *bool `json:"groupRebalance,omitempty"`
+
+	// GroupRebalancePauseDispatch pauses dispatch while the message groups are rebalanced
+	// +optional
+	GroupRebalancePauseDispatch *bool `json:"groupRebalancePauseDispatch,omitempty"`
+
+	// GroupBuckets is the number of message group buckets, -1 means no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	GroupBuckets *int32 `json:"groupBuckets,omitempty"`
+
+	// GroupFirstKey is the message property set on the first message of a group
+	// +optional
+	GroupFirstKey *string `json:"groupFirstKey,omitempty"`
+}
+
+// RouteType diverts the messages sent to an address to another address.
+// A shared address of another app must grant consume to divert from it and produce to divert to it
+type RouteType struct {
+	// Name of the route, unique within the app
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	Name string `json:"name"`
+
+	// From is the address the messages are diverted from
+	From AddressRef `json:"from"`
+
+	// To is the address the messages are diverted to
+	To AddressRef `json:"to"`
+
+	// Exclusive diverts the messages instead of a copy, only from an address owned by the app
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
+
+	// FilterString selects the messages that are diverted
+	// +optional
+	FilterString *string `json:"filterString,omitempty"`
+
+	// TransformerClassName is a transformer on the broker classpath applied to the diverted messages
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_$][a-zA-Z0-9_$]*(\.[a-zA-Z_$][a-zA-Z0-9_$]*)*$`
+	// +optional
+	TransformerClassName *string `json:"transformerClassName,omitempty"`
 }
 
 type AppCapabilityType struct {
+	// ProducerOf grants send on owned or shared addresses. A shared address must grant produce to the app.
+	// Sends are not linked across services, the app is placed on the service of the app that shares the address
+	// +optional
 	ProducerOf []AddressRef `json:"producerOf,omitempty"`
 
 	ConsumerOf []AddressRef `json:"consumerOf,omitempty"`
+
+	// BrowserOf grants browse on owned or shared addresses, to look at messages without consuming them.
+	// A shared address must grant consume to the app
+	// +optional
+	BrowserOf []AddressRef `json:"browserOf,omitempty"`
+
+	// RequestReplyOn grants send of requests on owned or shared addresses, with replies on temporary queues.
+	// The temporary queues are named with the temporary-queue-prefix of the binding secret, the consumers of
+	// the addresses may send the replies to them. A shared address must grant produce to the app
+	// +optional
+	RequestReplyOn []AddressRef `json:"requestReplyOn,omitempty"`
+
+	// ManagerOf grants manage and deleteDurableQueue on owned or shared addresses.
+	// A shared address must grant produce and consume to the app
+	// +optional
+	ManagerOf []AddressRef `json:"managerOf,omitempty"`
 }
 
 // BrokerServiceBindingStatus captures the binding details between a BrokerApp and its provisioned BrokerService
@@ -106,6 +385,10 @@ type BrokerServiceBindingStatus struct {
 
 	// AssignedPort is the port allocated from the matched service
 	AssignedPort int32 `json:"assignedPort"`
+
+	// Peer is the index of the service peer broker this app is placed on
+	//+optional
+	Peer int32 `json:"peer,omitempty"`
 }
 
 // Key returns the field indexer key for this service binding (namespace:name format)
@@ -131,12 +414,189 @@ type BrokerAppStatus struct {
 	// Service references the BrokerService this app is bound to and its binding secret
 	//+optional
 	Service *BrokerServiceBindingStatus `json:"service,omitempty"`
+
+	// Placement explains the last decision of the service the app is bound to
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Placement"
+	Placement *BrokerAppPlacementStatus `json:"placement,omitempty"`
+
+	// ClientCert describes the client certificate issued by the operator into the binding secret
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Client Certificate"
+	ClientCert *BrokerAppClientCertStatus `json:"clientCert,omitempty"`
+
+	// Migration tracks the messages moved from the previous binding after the app is rebound to another peer
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Migration"
+	Migration *BrokerAppMigrationStatus `json:"migration,omitempty"`
+
+	// Links are the bridges that carry the messages of shared addresses between the app and the apps it shares
+	// addresses with that are bound to other services
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Links"
+	Links []BrokerAppLinkStatus `json:"links,omitempty"`
+
+	// Addresses holds the statistics of the queues the app owns or consumes, when last collected from the broker
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Addresses"
+	Addresses []BrokerAppAddressStatus `json:"addresses,omitempty"`
+
+	// StatisticsTime is when the statistics of the addresses were last collected
+	//+optional
+	StatisticsTime *metav1.Time `json:"statisticsTime,omitempty"`
+}
+
+// BrokerAppAddressStatus holds the statistics of a queue of the app
+type BrokerAppAddressStatus struct {
+	// Address of the queue
+	Address string `json:"address"`
+
+	// Queue name, the address for anycast queues
+	Queue string `json:"queue"`
+
+	// RoutingType of the queue, ANYCAST or MULTICAST
+	RoutingType string `json:"routingType"`
+
+	// MessageCount is the number of messages on the queue, including the messages being delivered
+	MessageCount int64 `json:"messageCount"`
+
+	// ConsumerCount is the number of consumers of the queue
+	ConsumerCount int64 `json:"consumerCount"`
+
+	// DeliveringCount is the number of messages delivered to consumers and not yet acknowledged
+	DeliveringCount int64 `json:"deliveringCount"`
+
+	// PersistentSize is the size in bytes of the durable messages on the queue
+	PersistentSize int64 `json:"persistentSize"`
+}
+
+// +kubebuilder:validation:Enum=Inbound;Outbound
+type LinkDirection string
+
+var LinkDirections = struct {
+	Inbound  LinkDirection
+	Outbound LinkDirection
+}{
+	Inbound:  "Inbound",
+	Outbound: "Outbound",
+}
+
+// BrokerAppLinkStatus is a core bridge from the broker of the app that shares an address to the broker of
+// an app that consumes it from another service
+type BrokerAppLinkStatus struct {
+	// Address carried by the link, address::queue for a subscription
+	Address string `json:"address"`
+
+	// Direction of the messages, Outbound from the broker of the app that shares the address,
+	// Inbound to the broker of the app that consumes it
+	Direction LinkDirection `json:"direction"`
+
+	// App at the other end of the link, as namespace/name
+	App string `json:"app"`
+
+	// Service the app at the other end is bound to, as namespace/name
+	Service string `json:"service"`
+
+	// Peer of the service the app at the other end is placed on
+	Peer int32 `json:"peer"`
+
+	// Port assigned to the app at the other end
+	Port int32 `json:"port"`
+
+	// Bridge is the name of the core bridge on the broker of the app that shares the address
+	Bridge string `json:"bridge"`
+}
+
+// BrokerAppMigrationStatus describes the move of pending messages from a previous binding.
+// The acceptor and queues of the previous binding are retained until its queues are drained
+type BrokerAppMigrationStatus struct {
+	// From is the previous binding the messages are moved from
+	From BrokerServiceBindingStatus `json:"from"`
+
+	// Reason the app was rebound
+	Reason string `json:"reason"`
+
+	// StartTime is when the app was rebound
+	StartTime metav1.Time `json:"startTime"`
+
+	// PendingMessages is the number of messages remaining on the queues of the previous binding, when last observed
+	//+optional
+	PendingMessages *int64 `json:"pendingMessages,omitempty"`
+}
+
+// BrokerAppPlacementStatus explains a placement decision, each candidate service selected
+// by the app with why it could or could not take the app, and the service chosen
+type BrokerAppPlacementStatus struct {
+	// Candidates are the services selected by the app when the decision was made
+	//+optional
+	Candidates []BrokerAppPlacementCandidate `json:"candidates,omitempty"`
+
+	// Chosen is the service the app was placed on, unset when no candidate could take the app
+	//+optional
+	Chosen *BrokerAppPlacementChoice `json:"chosen,omitempty"`
+
+	// DecisionTime is when the decision was made, it does not change while the same decision is made again
+	DecisionTime metav1.Time `json:"decisionTime"`
+}
+
+// BrokerAppPlacementCandidate is a service considered for the placement of an app
+type BrokerAppPlacementCandidate struct {
+	// Name of the service
+	Name string `json:"name"`
+
+	// Namespace of the service
+	Namespace string `json:"namespace"`
+
+	// Category of the rejection of the service, one of notDeployed, selector, selectorError, addressRef,
+	// addressClash, identityClash, capacity, portPool, cordoned or other. Empty when the service could take the app
+	//+optional
+	Category string `json:"category,omitempty"`
+
+	// Message explains the rejection, or the peer the service offered
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// BrokerAppPlacementChoice is the service, peer and port an app was placed on
+type BrokerAppPlacementChoice struct {
+	// Name of the service
+	Name string `json:"name"`
+
+	// Namespace of the service
+	Namespace string `json:"namespace"`
+
+	// Peer of the service
+	Peer int32 `json:"peer"`
+
+	// Port assigned to the app
+	Port int32 `json:"port"`
+
+	// Strategy that chose the service and peer among the candidates with capacity
+	Strategy PlacementStrategy `json:"strategy"`
+}
+
+// BrokerAppClientCertStatus describes an operator issued client certificate
+type BrokerAppClientCertStatus struct {
+	// Subject distinguished name of the certificate, the app identity on the service
+	Subject string `json:"subject"`
+
+	// NotAfter is the expiry time of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// RenewalTime is when the certificate is rotated, ahead of expiry
+	RenewalTime metav1.Time `json:"renewalTime"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=brokerapps,shortName=bapp
+//+kubebuilder:printcolumn:name="Service",type="string",JSONPath=".status.service.name",description="The BrokerService the app is bound to"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="The state of the resource"
+//+kubebuilder:printcolumn:name="Queues",type="string",JSONPath=".status.addresses[*].queue",priority=1,description="The queues of the app"
+//+kubebuilder:printcolumn:name="Messages",type="string",JSONPath=".status.addresses[*].messageCount",priority=1,description="The messages on each queue"
+//+kubebuilder:printcolumn:name="Consumers",type="string",JSONPath=".status.addresses[*].consumerCount",priority=1,description="The consumers of each queue"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the resource"
 //+operator-sdk:csv:customresourcedefinitions:resources={{"Secret", "v1"}}
 
 // Describes the messaging requirements of an application
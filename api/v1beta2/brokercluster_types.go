@@ -828,9 +828,11 @@ const (
 	ValidConditionInvalidResourceName    = "InvalidResourceName"
 	ValidConditionAddressTypeError       = "AddressTypeError"
 	ValidConditionSpecSelectorError      = "SpecSelectorError"
+	ValidConditionIdentityClashReason    = "IdentityClash"
 
 	ValidConditionPDBNonNilSelectorReason            = "PodDisruptionBudgetNonNilSelector"
 	ValidConditionFailedReservedLabelReason          = "ReservedLabelReference"
+	ValidConditionFailedReservedPortReason           = "ReservedPortInPortRange"
 	ValidConditionFailedExtraMountReason             = "InvalidExtraMount"
 	ValidConditionFailedDuplicateAcceptorPort        = "DuplicateAcceptorPort"
 	ValidConditionFailedInvalidExposeMode            = "InvalidExposeMode"
@@ -862,6 +864,21 @@ const (
 	ScaleDownPendingConditionPendingEmptyReason         = "PendingEmpty" // no messages
 	ScaleDownPendingConditionPendingDeleteReason        = "PendingDelete"
 
+	MigratingConditionType                  = "Migrating"
+	MigratingConditionMessagesPendingReason = "MessagesPending"
+	MigratingConditionUnknownReason         = "UnableToRetrievePending"
+	MigratingConditionRebindDeferredReason  = "RebindDeferred"
+	MigratingConditionNoIssuerReason        = "NoMigrationIssuer"
+
+	CordonedConditionType           = "Cordoned"
+	CordonedConditionCordonedReason = "Cordoned"
+	CordonedConditionDrainingReason = "Draining"
+	CordonedConditionDrainedReason  = "Drained" // no apps remain
+
+	ExposedConditionType              = "Exposed"
+	ExposedConditionCertValidReason   = "CertValidForHosts"
+	ExposedConditionCertInvalidReason = "CertNotValidForHosts"
+
 	ReconcileBlockedType   = "ReconcileBlocked"
 	ReconcileBlockedReason = "AnnotationPresent"
 )
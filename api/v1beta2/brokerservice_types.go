@@ -43,6 +43,9 @@ type BrokerServiceSpec struct {
 	// - service: The BrokerService object (map with metadata, spec, etc.)
 	// - appNamespace: The Namespace object where the app resides (map with metadata, etc.)
 	// - serviceNamespace: The Namespace object where the service resides (map with metadata, etc.)
+	// - load: The load of the service without the app (apps, memory, memoryLimit, memoryUtilization)
+	//
+	// and to the functions label, annotation, glob, quantity, compareQuantities, compareSemver and semverInRange.
 	//
 	// Empty or nil (default): Uses "app.metadata.namespace == service.metadata.namespace" (same namespace only).
 	//
@@ -53,6 +56,177 @@ type BrokerServiceSpec struct {
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="App Selector Expression"
 	AppSelectorExpression string `json:"appSelectorExpression,omitempty"`
+
+	// Persistence configures a persistent journal for the broker that backs this service.
+	// When not set, the journal is ephemeral and messages do not survive a broker restart.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Persistence"
+	Persistence *BrokerServicePersistenceType `json:"persistence,omitempty"`
+
+	// Peers is the number of brokers that back this service, defaults to 1.
+	// Each BrokerApp is placed on a single peer and reached via the peer Service,
+	// <name> for the first peer and <name>-peer-<index> for the others.
+	// The service cert must be valid for each peer Service.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Peers"
+	Peers *int32 `json:"peers,omitempty"`
+
+	// PortRange is the pool of acceptor ports assigned to BrokerApps, defaults to 61616-65535.
+	// Apps bound to a port outside the pool are reassigned a port from the pool.
+	// The pool must not include the broker console acceptor, jolokia and metrics ports 8161, 8778 and 8888.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port Range"
+	PortRange *BrokerServicePortRangeType `json:"portRange,omitempty"`
+
+	// Expose makes the acceptor of each provisioned BrokerApp reachable from outside the cluster.
+	// The external host, port and uri are added to the binding secret of each app.
+	// The service cert must be valid for the external hosts.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Expose"
+	Expose *BrokerServiceExposeType `json:"expose,omitempty"`
+
+	// Capacity bounds the connections, addresses and queues of the BrokerApps placed on each peer.
+	// The memory and cpu limits of resources and the persistence storage size also bound
+	// the memory, cpu and storage requests of the apps placed on each peer.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capacity"
+	Capacity *BrokerServiceCapacityType `json:"capacity,omitempty"`
+
+	// PlacementStrategy chooses the peer of a BrokerApp among the peers with capacity, defaults to spread.
+	// Spread prefers the least utilized peer across all bounded capacity dimensions, earlier releases
+	// preferred the peer with the most free memory. The placementStrategy of an app takes precedence.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Placement Strategy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PlacementStrategy PlacementStrategy `json:"placementStrategy,omitempty"`
+
+	// SchedulingPolicy takes the service out of rotation, defaults to Schedulable.
+	// Cordoned binds no new BrokerApps, the apps already bound stay.
+	// Draining also rebinds the bound apps to other services with capacity, moving their pending messages.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scheduling Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	SchedulingPolicy SchedulingPolicy `json:"schedulingPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=binpack;spread;leastApps
+type PlacementStrategy string
+
+var PlacementStrategies = struct {
+	Binpack   PlacementStrategy
+	Spread    PlacementStrategy
+	LeastApps PlacementStrategy
+}{
+	Binpack:   "binpack",
+	Spread:    "spread",
+	LeastApps: "leastApps",
+}
+
+// +kubebuilder:validation:Enum=Schedulable;Cordoned;Draining
+type SchedulingPolicy string
+
+var SchedulingPolicies = struct {
+	Schedulable SchedulingPolicy
+	Cordoned    SchedulingPolicy
+	Draining    SchedulingPolicy
+}{
+	Schedulable: "Schedulable",
+	Cordoned:    "Cordoned",
+	Draining:    "Draining",
+}
+
+// BrokerServiceCapacityType bounds the apps placed on each peer of a BrokerService
+type BrokerServiceCapacityType struct {
+	// Maximum number of connections of the apps placed on a peer, apps must declare spec.maxConnections
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Connections",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// Maximum number of addresses owned by the apps placed on a peer
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Addresses",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxAddresses *int32 `json:"maxAddresses,omitempty"`
+
+	// Maximum number of queues declared by the apps placed on a peer
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Queues",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxQueues *int32 `json:"maxQueues,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ingress;route;loadBalancer
+type BrokerServiceExposeMode string
+
+var BrokerServiceExposeModes = struct {
+	Ingress      BrokerServiceExposeMode
+	Route        BrokerServiceExposeMode
+	LoadBalancer BrokerServiceExposeMode
+}{
+	Ingress:      "ingress",
+	Route:        "route",
+	LoadBalancer: "loadBalancer",
+}
+
+// BrokerServiceExposeType configures the external access to the BrokerApp acceptors
+type BrokerServiceExposeType struct {
+	// Mode to expose the app acceptors.
+	//
+	// * `ingress` creates an Ingress per app with TLS passthrough, clients connect to <app namespace>-<app name>.<ingressDomain>:443 using SNI.
+	// * `route` creates an OpenShift Route per app with TLS passthrough, clients connect to <app namespace>-<app name>.<ingressDomain>:443 using SNI.
+	// * `loadBalancer` creates a LoadBalancer Service per peer with the assigned port of each app once an app is provisioned on the peer, the Service keeps its last ports when the peer has no apps so its external address is retained.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Mode BrokerServiceExposeMode `json:"mode"`
+
+	// The domain of the app hosts, required for the ingress and route modes. The service cert must cover the app hosts, for example with a *.<ingressDomain> DNS name, the Exposed condition is False when it doesn't
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Domain",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	IngressDomain string `json:"ingressDomain,omitempty"`
+}
+
+// BrokerServicePortRangeType is an inclusive range of ports with optional exclusions
+type BrokerServicePortRangeType struct {
+	// First port of the range
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Start",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Start int32 `json:"start"`
+
+	// Last port of the range, must not be less than start
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="End",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	End int32 `json:"end"`
+
+	// Ports in the range that are never assigned
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclusions"
+	Exclusions []int32 `json:"exclusions,omitempty"`
+}
+
+// BrokerServicePersistenceType configures the journal storage of a BrokerService
+type BrokerServicePersistenceType struct {
+	// Storage for the journal, the size defaults to 2Gi.
+	// With ha, a single ReadWriteMany claim is shared by the peers, the storage class
+	// must support ReadWriteMany access and advisory file locks
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage"
+	Storage StorageType `json:"storage,omitempty"`
+
+	// HA provisions a live/backup peer pair that share the persistent journal. The peers race
+	// for the journal lock, the winner is live and the other waits as a backup that takes over
+	// when the live peer fails.
+	// The backup peer resolves its operand cert from <name>-backup-broker-cert or broker-cert
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="High Availability",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	HA bool `json:"ha,omitempty"`
 }
 
 // RejectedApp represents a BrokerApp that was rejected during provisioning validation
@@ -85,6 +259,79 @@ type BrokerServiceStatus struct {
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Rejected Applications"
 	RejectedApps []RejectedApp `json:"rejectedApps,omitempty"`
+
+	// External address of the load balancer of each peer, when exposed with the loadBalancer mode
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Load Balancers"
+	LoadBalancers []BrokerServiceLoadBalancerStatus `json:"loadBalancers,omitempty"`
+
+	// Number of BrokerApps bound to the service or with messages still moving off it
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Remaining Applications"
+	RemainingApps int32 `json:"remainingApps,omitempty"`
+
+	// Capacity of each peer and the amounts allocated to the apps placed on it
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Capacity"
+	Capacity []BrokerServicePeerCapacityStatus `json:"capacity,omitempty"`
+
+	// Use of the port pool
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Ports"
+	Ports *BrokerServicePortsStatus `json:"ports,omitempty"`
+
+	// Allocations of the apps placed on the service
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Allocations"
+	Allocations []BrokerServiceAppAllocationStatus `json:"allocations,omitempty"`
+}
+
+// BrokerServicePeerCapacityStatus is the capacity of a peer and the amounts allocated to the apps placed on it.
+// Memory and storage are in bytes, cpu in cores, connections, addresses and queues are counts
+type BrokerServicePeerCapacityStatus struct {
+	// Index of the peer
+	Peer int32 `json:"peer"`
+	// Number of apps placed on the peer
+	Apps int32 `json:"apps"`
+	// Limit of each bounded dimension
+	//+optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Amount of each dimension allocated to the apps
+	//+optional
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+}
+
+// BrokerServicePortsStatus is the use of the port pool of a service
+type BrokerServicePortsStatus struct {
+	// Number of ports in the pool
+	Total int32 `json:"total"`
+	// Number of ports of the pool assigned to apps
+	Used int32 `json:"used"`
+	// Number of ports of the pool available to apps
+	Free int32 `json:"free"`
+}
+
+// BrokerServiceAppAllocationStatus is the allocation of an app placed on a service
+type BrokerServiceAppAllocationStatus struct {
+	// Name of the app
+	Name string `json:"name"`
+	// Namespace of the app
+	Namespace string `json:"namespace"`
+	// Peer the app is placed on
+	Peer int32 `json:"peer"`
+	// Port assigned to the app
+	Port int32 `json:"port"`
+	// Amount of each dimension allocated to the app
+	//+optional
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+}
+
+// BrokerServiceLoadBalancerStatus is the external address of the load balancer of a peer
+type BrokerServiceLoadBalancerStatus struct {
+	// Index of the peer
+	Peer int32 `json:"peer"`
+	// Hostname or IP of the load balancer
+	Host string `json:"host"`
 }
 
 //+kubebuilder:object:root=true
@@ -94,6 +341,9 @@ type BrokerServiceStatus struct {
 //+operator-sdk:csv:customresourcedefinitions:resources={{"Secret", "v1"}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{"Service", "v1"}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{"Broker", "v1beta2"}}
+//+operator-sdk:csv:customresourcedefinitions:resources={{"PersistentVolumeClaim", "v1"}}
+//+operator-sdk:csv:customresourcedefinitions:resources={{"Ingress", "v1"}}
+//+operator-sdk:csv:customresourcedefinitions:resources={{"Route", "v1"}}
 
 // Provides a broker service
 // +operator-sdk:csv:customresourcedefinitions:displayName="Broker Service"
@@ -67,6 +67,121 @@ func (in *AcceptorType) DeepCopy() *AcceptorType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressAccessPolicyType) DeepCopyInto(out *AddressAccessPolicyType) {
+	*out = *in
+	if in.Produce != nil {
+		in, out := &in.Produce, &out.Produce
+		*out = new(AddressAccessRuleType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Consume != nil {
+		in, out := &in.Consume, &out.Consume
+		*out = new(AddressAccessRuleType)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressAccessPolicyType.
+func (in *AddressAccessPolicyType) DeepCopy() *AddressAccessPolicyType {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressAccessPolicyType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressAccessRuleType) DeepCopyInto(out *AddressAccessRuleType) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressAccessRuleType.
+func (in *AddressAccessRuleType) DeepCopy() *AddressAccessRuleType {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressAccessRuleType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressPolicyType) DeepCopyInto(out *AddressPolicyType) {
+	*out = *in
+	if in.DeadLetterAddress != nil {
+		in, out := &in.DeadLetterAddress, &out.DeadLetterAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDeliveryAttempts != nil {
+		in, out := &in.MaxDeliveryAttempts, &out.MaxDeliveryAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RedeliveryDelay != nil {
+		in, out := &in.RedeliveryDelay, &out.RedeliveryDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RedeliveryMultiplier != nil {
+		in, out := &in.RedeliveryMultiplier, &out.RedeliveryMultiplier
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxRedeliveryDelay != nil {
+		in, out := &in.MaxRedeliveryDelay, &out.MaxRedeliveryDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExpiryAddress != nil {
+		in, out := &in.ExpiryAddress, &out.ExpiryAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpiryDelay != nil {
+		in, out := &in.ExpiryDelay, &out.ExpiryDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(string)
+		**out = **in
+	}
+	if in.AddressFullPolicy != nil {
+		in, out := &in.AddressFullPolicy, &out.AddressFullPolicy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressPolicyType.
+func (in *AddressPolicyType) DeepCopy() *AddressPolicyType {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressPolicyType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddressRef) DeepCopyInto(out *AddressRef) {
 	*out = *in
@@ -80,6 +195,13 @@ func (in *AddressRef) DeepCopyInto(out *AddressRef) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubscriptionSettings != nil {
+		in, out := &in.SubscriptionSettings, &out.SubscriptionSettings
+		*out = make([]SubscriptionSettingsType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressRef.
@@ -467,6 +589,23 @@ func (in *AddressType) DeepCopyInto(out *AddressType) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubscriptionSettings != nil {
+		in, out := &in.SubscriptionSettings, &out.SubscriptionSettings
+		*out = make([]SubscriptionSettingsType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(AddressPolicyType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(AddressAccessPolicyType)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressType.
@@ -526,6 +665,27 @@ func (in *AppCapabilityType) DeepCopyInto(out *AppCapabilityType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BrowserOf != nil {
+		in, out := &in.BrowserOf, &out.BrowserOf
+		*out = make([]AddressRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequestReplyOn != nil {
+		in, out := &in.RequestReplyOn, &out.RequestReplyOn
+		*out = make([]AddressRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagerOf != nil {
+		in, out := &in.ManagerOf, &out.ManagerOf
+		*out = make([]AddressRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppCapabilityType.
@@ -592,6 +752,53 @@ func (in *BrokerApp) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppAddressStatus) DeepCopyInto(out *BrokerAppAddressStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppAddressStatus.
+func (in *BrokerAppAddressStatus) DeepCopy() *BrokerAppAddressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppAddressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppClientCertStatus) DeepCopyInto(out *BrokerAppClientCertStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	in.RenewalTime.DeepCopyInto(&out.RenewalTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppClientCertStatus.
+func (in *BrokerAppClientCertStatus) DeepCopy() *BrokerAppClientCertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppClientCertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppLinkStatus) DeepCopyInto(out *BrokerAppLinkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppLinkStatus.
+func (in *BrokerAppLinkStatus) DeepCopy() *BrokerAppLinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppLinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerAppList) DeepCopyInto(out *BrokerAppList) {
 	*out = *in
@@ -624,6 +831,84 @@ func (in *BrokerAppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppMigrationStatus) DeepCopyInto(out *BrokerAppMigrationStatus) {
+	*out = *in
+	out.From = in.From
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.PendingMessages != nil {
+		in, out := &in.PendingMessages, &out.PendingMessages
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppMigrationStatus.
+func (in *BrokerAppMigrationStatus) DeepCopy() *BrokerAppMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppPlacementCandidate) DeepCopyInto(out *BrokerAppPlacementCandidate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppPlacementCandidate.
+func (in *BrokerAppPlacementCandidate) DeepCopy() *BrokerAppPlacementCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppPlacementCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppPlacementChoice) DeepCopyInto(out *BrokerAppPlacementChoice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppPlacementChoice.
+func (in *BrokerAppPlacementChoice) DeepCopy() *BrokerAppPlacementChoice {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppPlacementChoice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerAppPlacementStatus) DeepCopyInto(out *BrokerAppPlacementStatus) {
+	*out = *in
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]BrokerAppPlacementCandidate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Chosen != nil {
+		in, out := &in.Chosen, &out.Chosen
+		*out = new(BrokerAppPlacementChoice)
+		**out = **in
+	}
+	in.DecisionTime.DeepCopyInto(&out.DecisionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppPlacementStatus.
+func (in *BrokerAppPlacementStatus) DeepCopy() *BrokerAppPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerAppPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerAppSpec) DeepCopyInto(out *BrokerAppSpec) {
 	*out = *in
@@ -653,7 +938,29 @@ func (in *BrokerAppSpec) DeepCopyInto(out *BrokerAppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]RouteType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ClientCertSubject != nil {
+		in, out := &in.ClientCertSubject, &out.ClientCertSubject
+		*out = new(ClientCertSubjectType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StatisticsInterval != nil {
+		in, out := &in.StatisticsInterval, &out.StatisticsInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppSpec.
@@ -681,6 +988,35 @@ func (in *BrokerAppStatus) DeepCopyInto(out *BrokerAppStatus) {
 		*out = new(BrokerServiceBindingStatus)
 		**out = **in
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(BrokerAppPlacementStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(BrokerAppClientCertStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(BrokerAppMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]BrokerAppLinkStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]BrokerAppAddressStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatisticsTime != nil {
+		in, out := &in.StatisticsTime, &out.StatisticsTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerAppStatus.
@@ -898,6 +1234,28 @@ func (in *BrokerService) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServiceAppAllocationStatus) DeepCopyInto(out *BrokerServiceAppAllocationStatus) {
+	*out = *in
+	if in.Allocated != nil {
+		in, out := &in.Allocated, &out.Allocated
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceAppAllocationStatus.
+func (in *BrokerServiceAppAllocationStatus) DeepCopy() *BrokerServiceAppAllocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServiceAppAllocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerServiceBindingStatus) DeepCopyInto(out *BrokerServiceBindingStatus) {
 	*out = *in
@@ -913,6 +1271,51 @@ func (in *BrokerServiceBindingStatus) DeepCopy() *BrokerServiceBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServiceCapacityType) DeepCopyInto(out *BrokerServiceCapacityType) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAddresses != nil {
+		in, out := &in.MaxAddresses, &out.MaxAddresses
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxQueues != nil {
+		in, out := &in.MaxQueues, &out.MaxQueues
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceCapacityType.
+func (in *BrokerServiceCapacityType) DeepCopy() *BrokerServiceCapacityType {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServiceCapacityType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServiceExposeType) DeepCopyInto(out *BrokerServiceExposeType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceExposeType.
+func (in *BrokerServiceExposeType) DeepCopy() *BrokerServiceExposeType {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServiceExposeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerServiceList) DeepCopyInto(out *BrokerServiceList) {
 	*out = *in
@@ -945,6 +1348,101 @@ func (in *BrokerServiceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServiceLoadBalancerStatus) DeepCopyInto(out *BrokerServiceLoadBalancerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceLoadBalancerStatus.
+func (in *BrokerServiceLoadBalancerStatus) DeepCopy() *BrokerServiceLoadBalancerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServiceLoadBalancerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServicePeerCapacityStatus) DeepCopyInto(out *BrokerServicePeerCapacityStatus) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Allocated != nil {
+		in, out := &in.Allocated, &out.Allocated
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServicePeerCapacityStatus.
+func (in *BrokerServicePeerCapacityStatus) DeepCopy() *BrokerServicePeerCapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServicePeerCapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServicePersistenceType) DeepCopyInto(out *BrokerServicePersistenceType) {
+	*out = *in
+	out.Storage = in.Storage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServicePersistenceType.
+func (in *BrokerServicePersistenceType) DeepCopy() *BrokerServicePersistenceType {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServicePersistenceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServicePortRangeType) DeepCopyInto(out *BrokerServicePortRangeType) {
+	*out = *in
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServicePortRangeType.
+func (in *BrokerServicePortRangeType) DeepCopy() *BrokerServicePortRangeType {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServicePortRangeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerServicePortsStatus) DeepCopyInto(out *BrokerServicePortsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServicePortsStatus.
+func (in *BrokerServicePortsStatus) DeepCopy() *BrokerServicePortsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerServicePortsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerServiceSpec) DeepCopyInto(out *BrokerServiceSpec) {
 	*out = *in
@@ -961,6 +1459,31 @@ func (in *BrokerServiceSpec) DeepCopyInto(out *BrokerServiceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(BrokerServicePersistenceType)
+		**out = **in
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PortRange != nil {
+		in, out := &in.PortRange, &out.PortRange
+		*out = new(BrokerServicePortRangeType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(BrokerServiceExposeType)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(BrokerServiceCapacityType)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceSpec.
@@ -993,6 +1516,30 @@ func (in *BrokerServiceStatus) DeepCopyInto(out *BrokerServiceStatus) {
 		*out = make([]RejectedApp, len(*in))
 		copy(*out, *in)
 	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		*out = make([]BrokerServiceLoadBalancerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make([]BrokerServicePeerCapacityStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(BrokerServicePortsStatus)
+		**out = **in
+	}
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]BrokerServiceAppAllocationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerServiceStatus.
@@ -1190,6 +1737,31 @@ func (in *BrokerUpgrades) DeepCopy() *BrokerUpgrades {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertSubjectType) DeepCopyInto(out *ClientCertSubjectType) {
+	*out = *in
+	if in.OrganizationalUnits != nil {
+		in, out := &in.OrganizationalUnits, &out.OrganizationalUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertSubjectType.
+func (in *ClientCertSubjectType) DeepCopy() *ClientCertSubjectType {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertSubjectType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectorType) DeepCopyInto(out *ConnectorType) {
 	*out = *in
@@ -1550,6 +2122,33 @@ func (in *ResourceTemplate) DeepCopy() *ResourceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteType) DeepCopyInto(out *RouteType) {
+	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	if in.FilterString != nil {
+		in, out := &in.FilterString, &out.FilterString
+		*out = new(string)
+		**out = **in
+	}
+	if in.TransformerClassName != nil {
+		in, out := &in.TransformerClassName, &out.TransformerClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteType.
+func (in *RouteType) DeepCopy() *RouteType {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageType) DeepCopyInto(out *StorageType) {
 	*out = *in
@@ -1565,6 +2164,86 @@ func (in *StorageType) DeepCopy() *StorageType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionSettingsType) DeepCopyInto(out *SubscriptionSettingsType) {
+	*out = *in
+	if in.FilterString != nil {
+		in, out := &in.FilterString, &out.FilterString
+		*out = new(string)
+		**out = **in
+	}
+	if in.Exclusive != nil {
+		in, out := &in.Exclusive, &out.Exclusive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastValue != nil {
+		in, out := &in.LastValue, &out.LastValue
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastValueKey != nil {
+		in, out := &in.LastValueKey, &out.LastValueKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.NonDestructive != nil {
+		in, out := &in.NonDestructive, &out.NonDestructive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RingSize != nil {
+		in, out := &in.RingSize, &out.RingSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxConsumers != nil {
+		in, out := &in.MaxConsumers, &out.MaxConsumers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConsumersBeforeDispatch != nil {
+		in, out := &in.ConsumersBeforeDispatch, &out.ConsumersBeforeDispatch
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DelayBeforeDispatch != nil {
+		in, out := &in.DelayBeforeDispatch, &out.DelayBeforeDispatch
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GroupRebalance != nil {
+		in, out := &in.GroupRebalance, &out.GroupRebalance
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GroupRebalancePauseDispatch != nil {
+		in, out := &in.GroupRebalancePauseDispatch, &out.GroupRebalancePauseDispatch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GroupBuckets != nil {
+		in, out := &in.GroupBuckets, &out.GroupBuckets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GroupFirstKey != nil {
+		in, out := &in.GroupFirstKey, &out.GroupFirstKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSettingsType.
+func (in *SubscriptionSettingsType) DeepCopy() *SubscriptionSettingsType {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionSettingsType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
 	*out = *in
@@ -20,6 +20,7 @@ import (
 	"context"
 	"reflect"
 	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	operatormetrics "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources"
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
@@ -105,6 +107,7 @@ type ActiveMQArtemisReconciler struct {
 	events        chan event.GenericEvent
 	log           logr.Logger
 	isOnOpenShift bool
+	recorder      *EventRecorder
 }
 
 func NewActiveMQArtemisReconciler(cluster cluster.Cluster, logger logr.Logger, isOpenShift bool) *ActiveMQArtemisReconciler {
@@ -113,6 +116,7 @@ func NewActiveMQArtemisReconciler(cluster cluster.Cluster, logger logr.Logger, i
 		Client:        cluster.GetClient(),
 		Scheme:        cluster.GetScheme(),
 		log:           logger,
+		recorder:      NewEventRecorder(cluster.GetEventRecorderFor("activemqartemis-controller")),
 	}
 }
 
@@ -150,7 +154,8 @@ func (r *ActiveMQArtemisReconciler) toBrokerClusterParent() *BrokerClusterReconc
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.10.0/pkg/reconcile
-func (r *ActiveMQArtemisReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *ActiveMQArtemisReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	defer operatormetrics.ObserveReconcile("activemqartemis", time.Now(), &reconcileErr)
 	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "Reconciling", "ActiveMQArtemis")
 
 	artemisResource := &brokerv1beta1.ActiveMQArtemis{}
@@ -275,6 +280,9 @@ func (r *ActiveMQArtemisReconciler) UpdateCRStatus(desired *brokerv1beta1.Active
 		return err
 	}
 
+	r.recorder.recordStatusEvents(desired, current.Status.Conditions, desired.Status.Conditions,
+		current.Status.Version.BrokerVersion, desired.Status.Version.BrokerVersion)
+
 	if !EqualCRStatus(&desired.Status, &current.Status) {
 		r.log.V(1).Info("cr.status update", "Namespace", desired.Namespace, "Name", desired.Name, "Observed status", desired.Status)
 		return resources.UpdateStatus(client, desired)
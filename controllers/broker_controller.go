@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"maps"
 	"sort"
+	"time"
 
 	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
 	brokerstatus "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/broker/status"
 	brokerversion "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/broker/version"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/brokervolumes"
+	operatormetrics "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/containers"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/persistentvolumeclaims"
@@ -69,6 +71,7 @@ type BrokerReconciler struct {
 	Scheme        *runtime.Scheme
 	log           logr.Logger
 	isOnOpenShift bool
+	recorder      *EventRecorder
 }
 
 func NewBrokerReconciler(cluster cluster.Cluster, logger logr.Logger, isOpenShift bool) *BrokerReconciler {
@@ -77,6 +80,7 @@ func NewBrokerReconciler(cluster cluster.Cluster, logger logr.Logger, isOpenShif
 		Client:        cluster.GetClient(),
 		Scheme:        cluster.GetScheme(),
 		log:           logger,
+		recorder:      NewEventRecorder(cluster.GetEventRecorderFor("broker-controller")),
 	}
 }
 
@@ -84,7 +88,8 @@ func NewBrokerReconciler(cluster cluster.Cluster, logger logr.Logger, isOpenShif
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokers/finalizers,verbs=update
 
-func (r *BrokerReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *BrokerReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	defer operatormetrics.ObserveReconcile("broker", time.Now(), &reconcileErr)
 	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "Reconciling", "Broker")
 
 	customResource := &v1beta2.Broker{}
@@ -177,6 +182,9 @@ func (r *BrokerReconciler) UpdateBrokerCRStatus(desired *v1beta2.Broker, client
 		return err
 	}
 
+	r.recorder.recordStatusEvents(desired, current.Status.Conditions, desired.Status.Conditions,
+		current.Status.Version.BrokerVersion, desired.Status.Version.BrokerVersion)
+
 	if !EqualBrokerCRStatus(&desired.Status, &current.Status) {
 		r.log.V(1).Info("cr.status update", "Namespace", desired.Namespace, "Name", desired.Name, "Observed status", desired.Status)
 		return resources.UpdateStatus(client, desired)
@@ -2030,57 +2038,45 @@ func (reconciler *BrokerReconcilerImpl) validate(customResource *v1beta2.Broker,
 		validationCondition = *condition
 	}
 
-	if validationCondition.Status != metav1.ConditionFalse && customResource.Spec.PodDisruptionBudget != nil {
-		condition := validatePodDisruptionForBroker(customResource)
-		if condition != nil {
-			validationCondition = *condition
-		}
-	}
-
 	if validationCondition.Status != metav1.ConditionFalse {
-		condition, retry = validateNoDupKeysInBrokerPropertiesForBroker(customResource)
-		if condition != nil {
+		if condition := validateBrokerSpec(customResource); condition != nil {
 			validationCondition = *condition
+			retry = false
 		}
 	}
 
 	if validationCondition.Status != metav1.ConditionFalse {
-		condition, retry = reconciler.validateStorage()
+		condition, retry = reconciler.validateRequiredSecrets(client)
 		if condition != nil {
 			validationCondition = *condition
 		}
 	}
+	brokerstatus.SetStatusConditionWithGeneration(customResource, validationCondition)
 
-	if validationCondition.Status != metav1.ConditionFalse {
-		condition := brokerversion.ValidateBrokerImageVersion(customResource)
-		if condition != nil {
-			validationCondition = *condition
-		}
-	}
+	return validationCondition.Status != metav1.ConditionFalse, retry
+}
 
-	if validationCondition.Status != metav1.ConditionFalse {
-		condition := validateReservedLabelsForBroker(customResource)
-		if condition != nil {
-			validationCondition = *condition
+// validateBrokerSpec runs the checks of the spec that depend on no other resource, at admission and on reconcile
+func validateBrokerSpec(customResource *v1beta2.Broker) *metav1.Condition {
+	if customResource.Spec.PodDisruptionBudget != nil {
+		if condition := validatePodDisruptionForBroker(customResource); condition != nil {
+			return condition
 		}
 	}
-
-	if validationCondition.Status != metav1.ConditionFalse {
-		condition, retry = validateEnvVarsForBroker(customResource)
-		if condition != nil {
-			validationCondition = *condition
-		}
+	if condition, _ := validateNoDupKeysInBrokerPropertiesForBroker(customResource); condition != nil {
+		return condition
 	}
-
-	if validationCondition.Status != metav1.ConditionFalse {
-		condition, retry = reconciler.validateRequiredSecrets(client)
-		if condition != nil {
-			validationCondition = *condition
-		}
+	if condition, _ := validateStorageForBroker(customResource); condition != nil {
+		return condition
 	}
-	brokerstatus.SetStatusConditionWithGeneration(customResource, validationCondition)
-
-	return validationCondition.Status != metav1.ConditionFalse, retry
+	if condition := brokerversion.ValidateBrokerImageVersion(customResource); condition != nil {
+		return condition
+	}
+	if condition := validateReservedLabelsForBroker(customResource); condition != nil {
+		return condition
+	}
+	condition, _ := validateEnvVarsForBroker(customResource)
+	return condition
 }
 
 func validateNoDupKeysInBrokerPropertiesForBroker(customResource *v1beta2.Broker) (*metav1.Condition, bool) {
@@ -2185,11 +2181,11 @@ func (reconciler *BrokerReconcilerImpl) validateRequiredSecrets(client rtclient.
 	return nil, false
 }
 
-func (reconciler *BrokerReconcilerImpl) validateStorage() (*metav1.Condition, bool) {
+func validateStorageForBroker(customResource *v1beta2.Broker) (*metav1.Condition, bool) {
 
-	if reconciler.customResource.Spec.PersistenceEnabled {
-		if reconciler.customResource.Spec.Storage.Size != "" {
-			_, err := resource.ParseQuantity(reconciler.customResource.Spec.Storage.Size)
+	if customResource.Spec.PersistenceEnabled {
+		if customResource.Spec.Storage.Size != "" {
+			_, err := resource.ParseQuantity(customResource.Spec.Storage.Size)
 			if err != nil {
 				return &metav1.Condition{
 					Type:    v1beta2.ValidConditionType,
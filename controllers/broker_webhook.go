@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-broker-arkmq-org-v1beta2-broker,mutating=false,failurePolicy=fail,sideEffects=None,groups=broker.arkmq.org,resources=brokers,verbs=create;update,versions=v1beta2,name=vbroker.broker.arkmq.org,admissionReviewVersions=v1
+
+// SetupWebhookWithManager serves the validating webhook of Brokers, that rejects the specs the
+// reconciler would report with a Valid=False condition, the checks of the resources the spec
+// references are left to the reconciler as they may be created later
+func (r *BrokerReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta2.Broker{}).
+		WithValidator(&brokerValidator{}).
+		Complete()
+}
+
+type brokerValidator struct{}
+
+var _ admission.CustomValidator = &brokerValidator{}
+
+func (validator *brokerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1beta2.Broker)
+	if !ok {
+		return nil, fmt.Errorf("expected a Broker but got a %T", obj)
+	}
+	return nil, validator.validate(cr)
+}
+
+func (validator *brokerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	previous, ok := oldObj.(*v1beta2.Broker)
+	if !ok {
+		return nil, fmt.Errorf("expected a Broker but got a %T", oldObj)
+	}
+	cr, ok := newObj.(*v1beta2.Broker)
+	if !ok {
+		return nil, fmt.Errorf("expected a Broker but got a %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(previous.Spec, cr.Spec) {
+		return nil, nil
+	}
+	return nil, validator.validate(cr)
+}
+
+func (validator *brokerValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (validator *brokerValidator) validate(cr *v1beta2.Broker) error {
+	if condition := validateBrokerSpec(cr); condition != nil {
+		return errors.New(condition.Message)
+	}
+	return nil
+}
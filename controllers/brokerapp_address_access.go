@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/appselector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Permissions of the access policy of a shared address
+const (
+	AddressPermissionProduce = "produce"
+	AddressPermissionConsume = "consume"
+)
+
+// sharedAddressAccess returns why the app is not granted the permission on the shared address of the owner,
+// nil when it is or when the owner does not share the address
+func sharedAddressAccess(app *broker.BrokerApp, owner *broker.BrokerApp, address string, permission string) error {
+	for _, shared := range owner.Spec.SharedAddresses {
+		if shared.Address != address {
+			continue
+		}
+		if shared.Access == nil {
+			return nil
+		}
+		rule := shared.Access.Consume
+		if permission == AddressPermissionProduce {
+			rule = shared.Access.Produce
+		}
+		if rule == nil {
+			return fmt.Errorf("app %s/%s does not grant %s on shared address '%s' to any app",
+				owner.Namespace, owner.Name, permission, address)
+		}
+		matches, err := accessRuleMatches(rule, app, owner)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate the %s access of shared address '%s' of app %s/%s: %v",
+				permission, address, owner.Namespace, owner.Name, err)
+		}
+		if !matches {
+			return fmt.Errorf("app %s/%s does not grant %s on shared address '%s' to app %s/%s",
+				owner.Namespace, owner.Name, permission, address, app.Namespace, app.Name)
+		}
+		return nil
+	}
+	return nil
+}
+
+// accessRuleMatches is true when the app matches each criterion set in the rule
+func accessRuleMatches(rule *broker.AddressAccessRuleType, app *broker.BrokerApp, owner *broker.BrokerApp) (bool, error) {
+	if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, app.Namespace) {
+		return false, nil
+	}
+	if len(rule.Apps) > 0 && !slices.Contains(rule.Apps, app.Name) {
+		return false, nil
+	}
+	if rule.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(rule.Selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(app.Labels)) {
+			return false, nil
+		}
+	}
+	if rule.Expression != "" {
+		return appselector.MatchesAccess(rule.Expression, app, owner)
+	}
+	return true, nil
+}
+
+// referencedAddressAccess returns why the app is not granted a permission of its references to the shared
+// addresses of the owners, the owners that are not in the list are not checked
+func referencedAddressAccess(app *broker.BrokerApp, owners []broker.BrokerApp) error {
+	for _, capability := range app.Spec.Capabilities {
+		for _, permission := range []string{AddressPermissionProduce, AddressPermissionConsume} {
+			addressRefs := capability.ConsumerOf
+			if permission == AddressPermissionProduce {
+				addressRefs = capability.ProducerOf
+			}
+			for _, addressRef := range addressRefs {
+				if addressRef.AppNamespace == "" || addressRef.AppName == "" {
+					continue
+				}
+				for index := range owners {
+					owner := &owners[index]
+					if owner.Namespace != addressRef.AppNamespace || owner.Name != addressRef.AppName {
+						continue
+					}
+					if err := sharedAddressAccess(app, owner, addressRef.Address, permission); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	for _, verb := range appVerbs(app) {
+		for _, addressRef := range verb.addressRefs {
+			for index := range owners {
+				owner := &owners[index]
+				if owner.Namespace != addressRef.AppNamespace || owner.Name != addressRef.AppName {
+					continue
+				}
+				for _, permission := range verb.permissions {
+					if err := sharedAddressAccess(app, owner, addressRef.Address, permission); err != nil {
+						return fmt.Errorf("%s: %v", verb.field, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateAddressAccess checks the access policies of the addresses of the app
+func (reconciler *BrokerAppInstanceReconciler) validateAddressAccess() error {
+	for _, addrType := range reconciler.instance.Spec.Addresses {
+		if addrType.Access != nil {
+			return NewValidationError(broker.ValidConditionAddressTypeError,
+				"address '%s': access is only allowed on spec.sharedAddresses", addrType.Address)
+		}
+	}
+	for _, addrType := range reconciler.instance.Spec.SharedAddresses {
+		if addrType.Access == nil {
+			continue
+		}
+		for _, permission := range []string{AddressPermissionProduce, AddressPermissionConsume} {
+			rule := addrType.Access.Consume
+			if permission == AddressPermissionProduce {
+				rule = addrType.Access.Produce
+			}
+			if rule == nil {
+				continue
+			}
+			if rule.Selector != nil {
+				if _, err := metav1.LabelSelectorAsSelector(rule.Selector); err != nil {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': invalid access.%s.selector: %v", addrType.Address, permission, err)
+				}
+			}
+			if rule.Expression != "" {
+				if err := appselector.ValidateAccessExpression(rule.Expression); err != nil {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': invalid access.%s.expression: %v", addrType.Address, permission, err)
+				}
+			}
+		}
+	}
+	return nil
+}
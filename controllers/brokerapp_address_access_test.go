@@ -0,0 +1,188 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sharedEvents is an owner app that only lets the audit apps of the default namespace consume its events
+func sharedEvents(ns string) *v1beta2.BrokerApp {
+	events := NewAddressType("events").WithPubSub(true).Build()
+	events.Access = &v1beta2.AddressAccessPolicyType{
+		Consume: &v1beta2.AddressAccessRuleType{
+			Namespaces: []string{ns},
+			Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"role": "audit"}},
+		},
+	}
+	owner := NewBrokerApp("owner", ns).
+		WithSharedAddresses(events).
+		WithServiceBinding("svc", ns, "owner-binding-secret", 61616).
+		Build()
+	owner.Labels = map[string]string{"team": "payments"}
+	return owner
+}
+
+func TestSharedAddressAccess_Rules(t *testing.T) {
+	ns := "default"
+	owner := sharedEvents(ns)
+
+	audit := NewBrokerApp("audit", ns).Build()
+	audit.Labels = map[string]string{"role": "audit"}
+	assert.NoError(t, sharedAddressAccess(audit, owner, "events", AddressPermissionConsume))
+
+	err := sharedAddressAccess(audit, owner, "events", AddressPermissionProduce)
+	if assert.Error(t, err) {
+		assert.Equal(t, "app default/owner does not grant produce on shared address 'events' to any app", err.Error())
+	}
+
+	other := NewBrokerApp("audit", "other").Build()
+	other.Labels = audit.Labels
+	err = sharedAddressAccess(other, owner, "events", AddressPermissionConsume)
+	if assert.Error(t, err) {
+		assert.Equal(t, "app default/owner does not grant consume on shared address 'events' to app other/audit", err.Error())
+	}
+
+	// addresses without a policy are open
+	assert.NoError(t, sharedAddressAccess(other, owner, "orders", AddressPermissionProduce))
+
+	rule := &v1beta2.AddressAccessRuleType{Apps: []string{"audit"}, Expression: `label(app, "team", "none") == label(owner, "team")`}
+	matches, err := accessRuleMatches(rule, audit, owner)
+	assert.NoError(t, err)
+	assert.False(t, matches)
+
+	audit.Labels["team"] = "payments"
+	matches, err = accessRuleMatches(rule, audit, owner)
+	assert.NoError(t, err)
+	assert.True(t, matches)
+
+	matches, err = accessRuleMatches(&v1beta2.AddressAccessRuleType{}, other, owner)
+	assert.NoError(t, err)
+	assert.True(t, matches)
+}
+
+func TestSharedAddressAccess_Placement(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).Build()
+	owner := sharedEvents(ns)
+	rogue := NewBrokerApp("rogue", ns).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("rogue").WithAppRef(ns, owner.Name).Build()).
+		Build()
+	audit := NewBrokerApp("audit", ns).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("audit").WithAppRef(ns, owner.Name).Build()).
+		Build()
+	audit.Labels = map[string]string{"role": "audit"}
+	env := NewTestEnvironment(ns, service, owner, rogue, audit)
+
+	_, updated := reconcileBrokerApp(t, env, audit.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "svc", updated.Status.Service.Name)
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: rogue.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Contains(t, deployed.Message, "does not grant consume on shared address 'events' to app default/rogue")
+	}
+	if assert.NotNil(t, updated.Status.Placement) && assert.Len(t, updated.Status.Placement.Candidates, 1) {
+		assert.Equal(t, "addressRef", updated.Status.Placement.Candidates[0].Category)
+	}
+}
+
+func TestSharedAddressAccess_ServiceGrantsNoRoles(t *testing.T) {
+	ns := "default"
+	svcName := "svc"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	owner := sharedEvents(ns)
+	// bound before the owner restricted the access
+	rogue := NewBrokerApp("rogue", ns).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("rogue").WithAppRef(ns, owner.Name).Build()).
+		WithServiceBinding(svcName, ns, "rogue-binding-secret", 61617).
+		Build()
+	audit := NewBrokerApp("audit", ns).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("audit").WithAppRef(ns, owner.Name).Build()).
+		WithServiceBinding(svcName, ns, "audit-binding-secret", 61618).
+		Build()
+	audit.Labels = map[string]string{"role": "audit"}
+	env := NewTestEnvironment(ns, oc, service, owner, rogue, audit)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	if assert.Len(t, updated.Status.RejectedApps, 1) {
+		assert.Equal(t, "rogue", updated.Status.RejectedApps[0].Name)
+		assert.Equal(t, "app default/owner does not grant consume on shared address 'events' to app default/rogue",
+			updated.Status.RejectedApps[0].Reason)
+	}
+
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	assert.NotContains(t, secret.Annotations[common.ProvisionedAppsAnnotation], AppIdentity(rogue))
+	var properties strings.Builder
+	for _, data := range secret.Data {
+		properties.Write(data)
+	}
+	assert.Contains(t, properties.String(), consumerRole(AppIdentity(audit)))
+	assert.NotContains(t, properties.String(), consumerRole(AppIdentity(rogue)))
+}
+
+func TestSharedAddressAccess_Validation(t *testing.T) {
+	ns := "default"
+
+	private := NewAddressType("orders").Build()
+	private.Access = &v1beta2.AddressAccessPolicyType{Consume: &v1beta2.AddressAccessRuleType{}}
+	invalidExpression := NewAddressType("events").Build()
+	invalidExpression.Access = &v1beta2.AddressAccessPolicyType{
+		Produce: &v1beta2.AddressAccessRuleType{Expression: `service.metadata.name == "svc"`},
+	}
+
+	for _, tc := range []struct {
+		app     *v1beta2.BrokerApp
+		message string
+	}{
+		{NewBrokerApp("private", ns).WithAddresses(private).Build(), "access is only allowed on spec.sharedAddresses"},
+		{NewBrokerApp("expression", ns).WithSharedAddresses(invalidExpression).Build(), "invalid access.produce.expression"},
+	} {
+		env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), tc.app)
+		_, updated := reconcileBrokerApp(t, env, tc.app.Name)
+
+		valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+		if assert.NotNil(t, valid, tc.app.Name) {
+			assert.Equal(t, metav1.ConditionFalse, valid.Status, tc.app.Name)
+			assert.Contains(t, valid.Message, tc.message, tc.app.Name)
+		}
+	}
+}
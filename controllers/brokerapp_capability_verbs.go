@@ -0,0 +1,289 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemporaryQueueNamespace is the first word of the temporary queues of request reply apps, no other address may use it
+const TemporaryQueueNamespace = "_tmp"
+
+// TemporaryQueuePrefix returns the prefix of the names of the temporary queues of the app, the dots of the
+// identity are replaced so the prefix of an app never matches the temporary queues of another
+func TemporaryQueuePrefix(app *broker.BrokerApp) string {
+	return TemporaryQueueNamespace + WildcardDelimiter + strings.ReplaceAll(AppIdentity(app), WildcardDelimiter, "_") + WildcardDelimiter
+}
+
+func browserRole(prefix string) string {
+	return fmt.Sprintf("%s-browser", prefix)
+}
+
+func requesterRole(prefix string) string {
+	return fmt.Sprintf("%s-requester", prefix)
+}
+
+func managerRole(prefix string) string {
+	return fmt.Sprintf("%s-manager", prefix)
+}
+
+// capabilityVerb is a capability list beyond producerOf and consumerOf with the permissions
+// a shared address must grant to it
+type capabilityVerb struct {
+	field       string
+	addressRefs []broker.AddressRef
+	permissions []string
+}
+
+func capabilityVerbs(capability *broker.AppCapabilityType) []capabilityVerb {
+	return []capabilityVerb{
+		{"browserOf", capability.BrowserOf, []string{AddressPermissionConsume}},
+		{"requestReplyOn", capability.RequestReplyOn, []string{AddressPermissionProduce}},
+		{"managerOf", capability.ManagerOf, []string{AddressPermissionProduce, AddressPermissionConsume}},
+	}
+}
+
+// capabilityAddressRefs returns the address refs of each list of the capability
+func capabilityAddressRefs(capability *broker.AppCapabilityType) [][]broker.AddressRef {
+	addressRefs := [][]broker.AddressRef{capability.ProducerOf, capability.ConsumerOf}
+	for _, verb := range capabilityVerbs(capability) {
+		addressRefs = append(addressRefs, verb.addressRefs)
+	}
+	return addressRefs
+}
+
+// appVerbs returns the verbs of the capabilities and the routes of the app
+func appVerbs(app *broker.BrokerApp) []capabilityVerb {
+	var verbs []capabilityVerb
+	for index := range app.Spec.Capabilities {
+		verbs = append(verbs, capabilityVerbs(&app.Spec.Capabilities[index])...)
+	}
+	return append(verbs, routeVerbs(app)...)
+}
+
+// appAddressRefs returns the address refs of each list of the capabilities and of the routes of the app
+func appAddressRefs(app *broker.BrokerApp) [][]broker.AddressRef {
+	var addressRefs [][]broker.AddressRef
+	for index := range app.Spec.Capabilities {
+		addressRefs = append(addressRefs, capabilityAddressRefs(&app.Spec.Capabilities[index])...)
+	}
+	for _, verb := range routeVerbs(app) {
+		addressRefs = append(addressRefs, verb.addressRefs)
+	}
+	return addressRefs
+}
+
+func hasRequestReply(app *broker.BrokerApp) bool {
+	for _, capability := range app.Spec.Capabilities {
+		if len(capability.RequestReplyOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// addressRefOwner returns the app that owns the referenced address
+func addressRefOwner(app *broker.BrokerApp, addressRef *broker.AddressRef) types.NamespacedName {
+	if addressRef.AppNamespace == "" && addressRef.AppName == "" {
+		return types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	}
+	return types.NamespacedName{Namespace: addressRef.AppNamespace, Name: addressRef.AppName}
+}
+
+// validateCapabilityVerbs ensures browserOf, requestReplyOn and managerOf only target addresses the app owns,
+// the shared addresses of other apps are checked on placement by checkVerbRefCapacity
+func (reconciler *BrokerAppInstanceReconciler) validateCapabilityVerbs() error {
+	owned := collectOwnedAddresses(reconciler.instance)
+	for _, capability := range reconciler.instance.Spec.Capabilities {
+		for _, verb := range capabilityVerbs(&capability) {
+			for index := range verb.addressRefs {
+				if err := checkAddressTarget(fmt.Sprintf("Spec.Capability.%s[%d]", verb.field, index), &verb.addressRefs[index], owned); err != nil {
+					return NewValidationError(broker.ValidConditionAddressTypeError, "%v", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkAddressTarget ensures a reference targets a plain address, owned by the app when it is local
+func checkAddressTarget(path string, addressRef *broker.AddressRef, owned map[string]bool) error {
+	switch {
+	case addressRef.Address == "":
+		return fmt.Errorf("%s.address must be specified", path)
+	case strings.Contains(addressRef.Address, FQQNSeparator) || isWildcardAddress(addressRef.Address):
+		return fmt.Errorf("%s.address should be a plain address name (no '::' or wildcards)", path)
+	case addressRef.PubSub != nil || len(addressRef.Subscriptions) > 0 || len(addressRef.SubscriptionSettings) > 0:
+		return fmt.Errorf("%s: pubSub and subscriptions are taken from the address", path)
+	case (addressRef.AppNamespace != "") != (addressRef.AppName != ""):
+		return fmt.Errorf("%s: appNamespace and appName must both be set (for cross-app reference) or both empty (for local reference)", path)
+	case addressRef.AppNamespace == "" && !owned[addressRef.Address]:
+		return fmt.Errorf("%s: address '%s' is not owned by the app (declare it or reference a shared address with appNamespace and appName)",
+			path, addressRef.Address)
+	}
+	return nil
+}
+
+// validateTemporaryQueueNamespace ensures no address of the app is in the namespace of temporary queues
+func (reconciler *BrokerAppInstanceReconciler) validateTemporaryQueueNamespace() error {
+	addresses := collectPolicyAddresses(reconciler.instance)
+	for _, addrTypes := range [][]broker.AddressType{reconciler.instance.Spec.Addresses, reconciler.instance.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			addresses = append(addresses, addrType.Address)
+		}
+	}
+	for _, addressRefs := range appAddressRefs(reconciler.instance) {
+		for _, addressRef := range addressRefs {
+			addresses = append(addresses, addressRef.Address)
+		}
+	}
+	for _, address := range addresses {
+		if strings.SplitN(address, WildcardDelimiter, 2)[0] == TemporaryQueueNamespace {
+			return NewValidationError(broker.ValidConditionAddressTypeError,
+				"address '%s': the '%s' namespace is reserved for temporary queues", address, TemporaryQueueNamespace)
+		}
+	}
+	return nil
+}
+
+// checkVerbRefCapacity validates that the shared addresses referenced by browserOf, requestReplyOn, managerOf
+// and routes are on the given service and grant the permissions of the verb
+func (reconciler *BrokerAppInstanceReconciler) checkVerbRefCapacity(service *broker.BrokerService) error {
+	for _, verb := range appVerbs(reconciler.instance) {
+		for _, addressRef := range verb.addressRefs {
+			if addressRef.AppNamespace == "" || addressRef.AppName == "" {
+				continue
+			}
+			refKey := types.NamespacedName{Namespace: addressRef.AppNamespace, Name: addressRef.AppName}
+			referencedApp := &broker.BrokerApp{}
+			if getErr := reconciler.Client.Get(context.Background(), refKey, referencedApp); getErr != nil {
+				if errors.IsNotFound(getErr) {
+					return fmt.Errorf("referenced app %s not found", refKey)
+				}
+				return fmt.Errorf("failed to lookup referenced app %s: %v", refKey, getErr)
+			}
+			if referencedApp.Status.Service == nil {
+				return fmt.Errorf("referenced app %s not yet provisioned on any service", refKey)
+			}
+			if referencedApp.Status.Service.Key() != serviceKey(service) {
+				return fmt.Errorf("referenced app %s is provisioned on different service %s, %s is not linked across services (this app would bind to: %s)",
+					refKey, referencedApp.Status.Service.Key(), verb.field, serviceKey(service))
+			}
+			if !sharesAddress(referencedApp, addressRef.Address) {
+				return fmt.Errorf("referenced app %s does not share address '%s' (add to spec.sharedAddresses)",
+					refKey, addressRef.Address)
+			}
+			for _, permission := range verb.permissions {
+				if accessErr := sharedAddressAccess(reconciler.instance, referencedApp, addressRef.Address, permission); accessErr != nil {
+					return fmt.Errorf("%s: %v", verb.field, accessErr)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sharesAddress(app *broker.BrokerApp, address string) bool {
+	for _, shared := range app.Spec.SharedAddresses {
+		if shared.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+// addCapabilityVerbProperties grants the roles of browserOf, requestReplyOn and managerOf, the consumers of the
+// request addresses are the repliers that send to the temporary queues of the app
+func addCapabilityVerbProperties(props map[string]string, app *broker.BrokerApp, repliers []string) {
+	identity := AppIdentity(app)
+	for _, capability := range app.Spec.Capabilities {
+		for _, addressRef := range capability.BrowserOf {
+			props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".browse=true\n", escapeForProperties(addressRef.Address), browserRole(identity))] = ""
+		}
+		for _, addressRef := range capability.RequestReplyOn {
+			props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".send=true\n", escapeForProperties(addressRef.Address), requesterRole(identity))] = ""
+		}
+		for _, addressRef := range capability.ManagerOf {
+			for _, permission := range []string{"manage", "deleteDurableQueue"} {
+				props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".%s=true\n", escapeForProperties(addressRef.Address), managerRole(identity), permission)] = ""
+			}
+		}
+	}
+
+	if !hasRequestReply(app) {
+		return
+	}
+	temporaryQueues := escapeForProperties(TemporaryQueuePrefix(app) + WildcardAnyWords)
+	for _, permission := range []string{"createAddress", "deleteAddress", "createNonDurableQueue", "deleteNonDurableQueue", "consume"} {
+		props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".%s=true\n", temporaryQueues, requesterRole(identity), permission)] = ""
+	}
+	for _, replier := range repliers {
+		props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".send=true\n", temporaryQueues, consumerRole(replier))] = ""
+	}
+}
+
+// requestRepliers returns the identities of the apps on the peer of the app that consume its request addresses
+func (reconciler *BrokerServiceInstanceReconciler) requestRepliers(app *broker.BrokerApp) ([]string, error) {
+	if !hasRequestReply(app) || app.Status.Service == nil {
+		return nil, nil
+	}
+	requests := map[types.NamespacedName]map[string]bool{}
+	for _, capability := range app.Spec.Capabilities {
+		for _, addressRef := range capability.RequestReplyOn {
+			owner := addressRefOwner(app, &addressRef)
+			if requests[owner] == nil {
+				requests[owner] = map[string]bool{}
+			}
+			requests[owner][addressRef.Address] = true
+		}
+	}
+
+	apps := &broker.BrokerAppList{}
+	key := app.Status.Service.Key()
+	if err := reconciler.Client.List(context.TODO(), apps, client.MatchingFields{common.AppServiceBindingField: key}); err != nil {
+		return nil, err
+	}
+	repliers := map[string]bool{}
+	for index := range apps.Items {
+		other := &apps.Items[index]
+		if other.Status.Service == nil || other.Status.Service.Key() != key || other.Status.Service.Peer != app.Status.Service.Peer {
+			continue
+		}
+		for _, capability := range other.Spec.Capabilities {
+			for _, addressRef := range capability.ConsumerOf {
+				if requests[addressRefOwner(other, &addressRef)][addressRef.Address] {
+					repliers[AppIdentity(other)] = true
+				}
+			}
+		}
+	}
+	result := make([]string, 0, len(repliers))
+	for replier := range repliers {
+		result = append(result, replier)
+	}
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestCapabilityVerbs_Validation(t *testing.T) {
+	ns := "default"
+	for _, tc := range []struct {
+		name       string
+		capability v1beta2.AppCapabilityType
+		message    string
+	}{
+		{"notOwned",
+			v1beta2.AppCapabilityType{BrowserOf: []v1beta2.AddressRef{NewAddressRef("orders").Build()}},
+			"Spec.Capability.browserOf[0]: address 'orders' is not owned by the app"},
+		{"subscriptions",
+			v1beta2.AppCapabilityType{
+				ConsumerOf: []v1beta2.AddressRef{NewAddressRef("events").WithSubscriptions("audit").Build()},
+				ManagerOf:  []v1beta2.AddressRef{NewAddressRef("events").WithSubscriptions("audit").Build()},
+			},
+			"Spec.Capability.managerOf[0]: pubSub and subscriptions are taken from the address"},
+		{"fqqn",
+			v1beta2.AppCapabilityType{RequestReplyOn: []v1beta2.AddressRef{NewAddressRef("events::audit").Build()}},
+			"Spec.Capability.requestReplyOn[0].address should be a plain address name"},
+		{"temporary",
+			v1beta2.AppCapabilityType{ProducerOf: []v1beta2.AddressRef{NewAddressRef("_tmp.default-other.replies").Build()}},
+			"address '_tmp.default-other.replies': the '_tmp' namespace is reserved for temporary queues"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			app := NewBrokerApp("app", ns).WithCapabilities(tc.capability).Build()
+			env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), app)
+
+			_, updated := reconcileBrokerApp(t, env, app.Name)
+			assert.Nil(t, updated.Status.Service)
+			valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+			if assert.NotNil(t, valid) {
+				assert.Equal(t, metav1.ConditionFalse, valid.Status)
+				assert.Contains(t, valid.Message, tc.message)
+			}
+		})
+	}
+
+	// owned addresses can be browsed and managed
+	app := NewBrokerApp("app", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithCapabilities(v1beta2.AppCapabilityType{
+			BrowserOf: []v1beta2.AddressRef{NewAddressRef("orders").Build()},
+			ManagerOf: []v1beta2.AddressRef{NewAddressRef("orders").Build()},
+		}).
+		Build()
+	env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), app)
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+	assert.NotNil(t, updated.Status.Service)
+}
+
+func TestCapabilityVerbs_SharedAddressAccess(t *testing.T) {
+	ns := "default"
+	orders := NewAddressType("orders").Build()
+	orders.Access = &v1beta2.AddressAccessPolicyType{Consume: &v1beta2.AddressAccessRuleType{}}
+	owner := NewBrokerApp("owner", ns).
+		WithSharedAddresses(orders).
+		WithServiceBinding("svc", ns, "owner-binding-secret", 61616).
+		Build()
+	monitor := NewBrokerApp("monitor", ns).
+		WithCapabilities(v1beta2.AppCapabilityType{BrowserOf: []v1beta2.AddressRef{NewAddressRef("orders").WithAppRef(ns, owner.Name).Build()}}).
+		Build()
+	admin := NewBrokerApp("admin", ns).
+		WithCapabilities(v1beta2.AppCapabilityType{ManagerOf: []v1beta2.AddressRef{NewAddressRef("orders").WithAppRef(ns, owner.Name).Build()}}).
+		Build()
+	env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), owner, monitor, admin)
+
+	// browse only needs consume
+	_, updated := reconcileBrokerApp(t, env, monitor.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "svc", updated.Status.Service.Name)
+	}
+
+	// manage needs produce and consume
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: admin.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Contains(t, deployed.Message, "managerOf: app default/owner does not grant produce on shared address 'orders' to any app")
+	}
+}
+
+func TestBrokerServiceCapabilityVerbs_Roles(t *testing.T) {
+	ns := "default"
+	replier := NewBrokerApp("replier", ns).
+		WithSharedAddresses(NewAddressType("requests").Build()).
+		WithConsumerOf(NewAddressRef("requests").Build()).
+		WithServiceBinding("svc", ns, "replier-binding-secret", 61616).
+		Build()
+	requester := NewBrokerApp("requester", ns).
+		WithAddresses(NewAddressType("audit").Build()).
+		WithCapabilities(v1beta2.AppCapabilityType{
+			RequestReplyOn: []v1beta2.AddressRef{NewAddressRef("requests").WithAppRef(ns, replier.Name).Build()},
+			BrowserOf:      []v1beta2.AddressRef{NewAddressRef("audit").Build()},
+			ManagerOf:      []v1beta2.AddressRef{NewAddressRef("audit").Build()},
+		}).
+		Build()
+	env := NewTestEnvironment(ns, withOperatorCA(t, ns), withOperatorIssuer(t, ns), NewBrokerService("svc", ns).Build(), replier, requester)
+
+	_, updated := reconcileBrokerApp(t, env, requester.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "svc", updated.Status.Service.Name)
+	}
+
+	// clients name their temporary queues with the prefix of the binding secret
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(requester.Name), Namespace: ns}, bindingSecret))
+	assert.Equal(t, "_tmp.default-requester.", string(bindingSecret.Data["temporary-queue-prefix"]))
+
+	reconcileBrokerService(t, env, "svc")
+
+	secret, err := mergedAppSecrets(env.Client, ns, "svc")
+	assert.NoError(t, err)
+
+	capabilities := string(secret.Data[AppIdentityPrefixed(updated, "capabilities.properties")])
+	assert.Contains(t, capabilities, "securityRoles.\"requests\".\"default-requester-requester\".send=true\n")
+	assert.Contains(t, capabilities, "securityRoles.\"audit\".\"default-requester-browser\".browse=true\n")
+	assert.Contains(t, capabilities, "securityRoles.\"audit\".\"default-requester-manager\".manage=true\n")
+	assert.Contains(t, capabilities, "securityRoles.\"audit\".\"default-requester-manager\".deleteDurableQueue=true\n")
+	for _, permission := range []string{"createAddress", "deleteAddress", "createNonDurableQueue", "deleteNonDurableQueue", "consume"} {
+		assert.Contains(t, capabilities, "securityRoles.\"_tmp.default-requester.#\".\"default-requester-requester\"."+permission+"=true\n")
+	}
+	// the consumers of the request address send the replies
+	assert.Contains(t, capabilities, "securityRoles.\"_tmp.default-requester.#\".\"default-replier-consumer\".send=true\n")
+	assert.NotContains(t, capabilities, "securityRoles.\"_tmp.default-requester.#\".\"default-requester-requester\".send=true\n")
+
+	roles := string(secret.Data[UnderscoreAppIdentityPrefixed(updated, common.GetCertRolesKey(jaasConfigRealmName(updated)))])
+	assert.Contains(t, roles, "default-requester-requester=default-requester\n")
+	assert.Contains(t, roles, "default-requester-browser=default-requester\n")
+	assert.Contains(t, roles, "default-requester-manager=default-requester\n")
+	assert.NotContains(t, roles, "default-requester-producer=")
+}
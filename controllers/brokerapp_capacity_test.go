@@ -622,7 +622,7 @@ func TestFindServiceWithCapacity(t *testing.T) {
 			}
 
 			// Call findServiceWithCapacity
-			chosen, assignedPort, err := reconciler.findServiceWithCapacity(serviceList)
+			chosen, _, assignedPort, err := reconciler.findServiceWithCapacity(serviceList)
 
 			// Check error expectation
 			if (err != nil) != tt.expectError {
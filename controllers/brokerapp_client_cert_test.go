@@ -0,0 +1,499 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// withOperatorIssuer returns an issuer secret with a new CA key pair in the operator namespace
+func withOperatorIssuer(t *testing.T, ns string) *corev1.Secret {
+	common.SetOperatorIssuerSecretName("op_issuer")
+	t.Cleanup(common.UnsetOperatorIssuerSecretName)
+
+	common.SetOperatorNameSpace(ns)
+	t.Cleanup(common.UnsetOperatorNameSpace)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test.ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "op_issuer",
+			Namespace: ns,
+		},
+		Data: map[string][]byte{
+			"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			"tls.key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}),
+		},
+	}
+}
+
+func parseCertPem(t *testing.T, certPem []byte) *x509.Certificate {
+	block, _ := pem.Decode(certPem)
+	assert.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestBrokerAppClientCert_Issued(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	issuer := withOperatorIssuer(t, ns)
+	ca := withOperatorCA(t, ns)
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+
+	env := NewTestEnvironment(ns, issuer, ca, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	result, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.NotNil(t, updatedApp.Status.ClientCert)
+	assert.Equal(t, "CN=my-app,OU=default", updatedApp.Status.ClientCert.Subject)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}, bindingSecret))
+	assert.Equal(t, ca.Data["ca.pem"], bindingSecret.Data["ca.crt"])
+
+	cert := parseCertPem(t, bindingSecret.Data["tls.crt"])
+	assert.Equal(t, "my-app", cert.Subject.CommonName)
+	assert.Equal(t, []string{ns}, cert.Subject.OrganizationalUnit)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.NoError(t, cert.CheckSignatureFrom(parseCertPem(t, issuer.Data["tls.crt"])))
+	assert.NotEmpty(t, bindingSecret.Data["tls.key"])
+
+	// revisit to rotate ahead of expiry
+	assert.True(t, updatedApp.Status.ClientCert.RenewalTime.Time.Before(cert.NotAfter))
+	assert.InDelta(t, (ClientCertDuration - ClientCertRenewBefore).Seconds(), result.RequeueAfter.Seconds(), 120)
+}
+
+func TestBrokerAppClientCert_Retained(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	issuer := withOperatorIssuer(t, ns)
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+
+	env := NewTestEnvironment(ns, issuer, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	bindingSecretKey := types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	issued := bindingSecret.Data["tls.crt"]
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	assert.Equal(t, issued, bindingSecret.Data["tls.crt"])
+}
+
+func TestBrokerAppClientCert_RotatedBeforeExpiry(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	issuer := withOperatorIssuer(t, ns)
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+
+	env := NewTestEnvironment(ns, issuer, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// replace with a cert that is within its renewal window
+	caIssuer, err := certutil.NewIssuerFromSecret(issuer)
+	assert.NoError(t, err)
+	expiringCert, expiringKey, err := caIssuer.IssueClientCert(AppCertSubject(app), time.Now(), ClientCertRenewBefore/2)
+	assert.NoError(t, err)
+
+	bindingSecretKey := types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	bindingSecret.Data["tls.crt"] = expiringCert
+	bindingSecret.Data["tls.key"] = expiringKey
+	assert.NoError(t, env.Client.Update(context.TODO(), bindingSecret))
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	assert.NotEqual(t, expiringCert, bindingSecret.Data["tls.crt"])
+	cert := parseCertPem(t, bindingSecret.Data["tls.crt"])
+	assert.True(t, cert.NotAfter.After(time.Now().Add(ClientCertRenewBefore)))
+}
+
+func TestBrokerAppClientCert_ReissuedWhenIssuerChanges(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	issuer := withOperatorIssuer(t, ns)
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+
+	env := NewTestEnvironment(ns, issuer, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	rotated := withOperatorIssuer(t, ns)
+	current := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: issuer.Name, Namespace: ns}, current))
+	current.Data = rotated.Data
+	assert.NoError(t, env.Client.Update(context.TODO(), current))
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}, bindingSecret))
+	cert := parseCertPem(t, bindingSecret.Data["tls.crt"])
+	assert.NoError(t, cert.CheckSignatureFrom(parseCertPem(t, rotated.Data["tls.crt"])))
+}
+
+func TestBrokerAppClientCert_NoIssuer(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	common.SetOperatorNameSpace(ns)
+	t.Cleanup(common.UnsetOperatorNameSpace)
+
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+
+	env := NewTestEnvironment(ns, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	result, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.Nil(t, updatedApp.Status.ClientCert)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}, bindingSecret))
+	assert.NotContains(t, bindingSecret.Data, "tls.crt")
+	assert.NotContains(t, bindingSecret.Data, "tls.key")
+	assert.Contains(t, bindingSecret.Data, "uri")
+}
+
+func TestBrokerServiceAcceptor_PinsClientCertSubject(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	pinned := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	pinned.Status.ClientCert = &v1beta2.BrokerAppClientCertStatus{Subject: AppCertSubject(pinned).String()}
+	provided := NewBrokerApp("billing", ns).
+		WithServiceBinding(svcName, ns, "billing-binding-secret", 61617).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, pinned, provided)
+
+	reconcileBrokerService(t, env, svcName)
+
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+
+	pinnedUsers := string(secret.Data[UnderscoreAppIdentityPrefixed(pinned, common.GetCertUsersKey(jaasConfigRealmName(pinned)))])
+	assert.Contains(t, pinnedUsers, "default-orders=/^CN=orders,OU=default$/\n")
+
+	providedUsers := string(secret.Data[UnderscoreAppIdentityPrefixed(provided, common.GetCertUsersKey(jaasConfigRealmName(provided)))])
+	assert.Contains(t, providedUsers, "default-billing=/^CN=billing,OU=default$/\n")
+}
+
+func TestBrokerServiceAcceptor_DeclaredClientCertSubject(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	app.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders.example.com",
+		OrganizationalUnits: []string{"team, a"},
+		Organizations:       []string{"example"},
+	}
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, svcName)
+
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+
+	// escaped for the properties format
+	users := string(secret.Data[UnderscoreAppIdentityPrefixed(app, common.GetCertUsersKey(jaasConfigRealmName(app)))])
+	assert.Contains(t, users, `default-orders=/^CN=orders\\.example\\.com,OU=team\\\\?,\\\\? a,O=example$/`+"\n")
+}
+
+func TestCertSubjectPattern(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		subject  pkix.Name
+		matches  []string
+		rejected []string
+	}{
+		{"default",
+			pkix.Name{CommonName: "orders", OrganizationalUnit: []string{"default"}},
+			[]string{"CN=orders,OU=default"},
+			[]string{"CN=orders,OU=default,O=example", "CN=orders.x,OU=default", "CN=ordersXOU=default", "OU=default,CN=orders"}},
+		// the JVM escapes the special characters of RFC 2253 and leading or trailing spaces
+		{"escaped",
+			pkix.Name{CommonName: "a+b=c", OrganizationalUnit: []string{"team, a", `x\y;"z"<>`, " #lead "}},
+			[]string{
+				`CN=a\+b\=c,OU=team\, a+OU=x\\y\;\"z\"\<\>+OU=\ \#lead\ `,
+				`CN=a\+b=c,OU=team\, a+OU=x\\y\;\"z\"\<\>+OU=\ #lead\ `,
+			},
+			[]string{`CN=a\+b\=c,OU=team\, a`, `CN=a\+b\=c,OU=teamX a+OU=x\\y\;\"z\"\<\>+OU=\ \#lead\ `}},
+		// the values of a multi-valued RDN are in the order of their encoding
+		{"multiValued",
+			pkix.Name{CommonName: "orders", OrganizationalUnit: []string{"payments", "eu"}, Organization: []string{"example"}},
+			[]string{
+				"CN=orders,OU=eu+OU=payments,O=example",
+				"CN=orders,OU=payments+OU=eu,O=example",
+			},
+			[]string{
+				"CN=orders,OU=eu,OU=payments,O=example",
+				"CN=orders,OU=eu+OU=eu,O=example",
+				"CN=orders,OU=eu,O=example",
+				"CN=orders,OU=eu+OU=payments+OU=other,O=example",
+			}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pattern := CertSubjectPattern(tc.subject)
+			assert.True(t, strings.HasPrefix(pattern, "/^") && strings.HasSuffix(pattern, "$/"), pattern)
+			re := regexp.MustCompile(strings.Trim(pattern, "/"))
+			assert.True(t, re.MatchString(tc.subject.String()), tc.subject.String())
+			for _, dn := range tc.matches {
+				assert.True(t, re.MatchString(dn), dn)
+			}
+			for _, dn := range tc.rejected {
+				assert.False(t, re.MatchString(dn), dn)
+			}
+		})
+	}
+
+	// the subject of an issued cert as the JVM renders it
+	issuer := withOperatorIssuer(t, "default")
+	ca, err := certutil.NewIssuerFromSecret(issuer)
+	assert.NoError(t, err)
+	subject := pkix.Name{CommonName: "orders", OrganizationalUnit: []string{"payments", "eu"}}
+	certPem, keyPem, err := ca.IssueClientCert(subject, time.Now(), time.Hour)
+	assert.NoError(t, err)
+	cert := ca.IssuedClientCert(certPem, keyPem, subject)
+	if assert.NotNil(t, cert) {
+		var rdns pkix.RDNSequence
+		_, err = asn1.Unmarshal(cert.RawSubject, &rdns)
+		assert.NoError(t, err)
+		// RFC 2253 lists the most specific RDN first and the values of an RDN in their encoded order
+		var names []string
+		for index := len(rdns) - 1; index >= 0; index-- {
+			var values []string
+			for _, attribute := range rdns[index] {
+				values = append(values, rfc2253AttributeTypes[attribute.Type.String()]+"="+fmt.Sprint(attribute.Value))
+			}
+			names = append(names, strings.Join(values, "+"))
+		}
+		jvmName := strings.Join(names, ",")
+		assert.Equal(t, "CN=orders,OU=eu+OU=payments", jvmName)
+		assert.Regexp(t, strings.Trim(CertSubjectPattern(subject), "/"), jvmName)
+	}
+}
+
+func TestBrokerAppClientCert_DeclaredSubject(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	issuer := withOperatorIssuer(t, ns)
+	svc := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("my-app", ns).Build()
+	app.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders.example.com",
+		OrganizationalUnits: []string{"payments"},
+	}
+
+	env := NewTestEnvironment(ns, issuer, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.NotNil(t, updatedApp.Status.ClientCert)
+	assert.Equal(t, "CN=orders.example.com,OU=payments", updatedApp.Status.ClientCert.Subject)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}, bindingSecret))
+	cert := parseCertPem(t, bindingSecret.Data["tls.crt"])
+	assert.Equal(t, "CN=orders.example.com,OU=payments", cert.Subject.String())
+}
+
+func TestBrokerServiceAppIdentities_OverlapRejected(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	orders := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	orders.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	impostor := NewBrokerApp("impostor", ns).
+		WithServiceBinding(svcName, ns, "impostor-binding-secret", 61617).
+		Build()
+	impostor.CreationTimestamp = metav1.NewTime(time.Now())
+	impostor.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders",
+		OrganizationalUnits: []string{ns},
+	}
+	env := NewTestEnvironment(ns, oc, service, orders, impostor)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Len(t, updated.Status.RejectedApps, 1)
+	assert.Equal(t, "impostor", updated.Status.RejectedApps[0].Name)
+	assert.Contains(t, updated.Status.RejectedApps[0].Reason, "overlaps app default/orders")
+
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	assert.Equal(t, AppIdentity(orders), secret.Annotations[common.ProvisionedAppsAnnotation])
+}
+
+func TestBrokerServiceAppIdentities_OverlapInAnotherOrder(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	orders := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	orders.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	orders.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders",
+		OrganizationalUnits: []string{"payments", "eu"},
+	}
+	impostor := NewBrokerApp("impostor", ns).
+		WithServiceBinding(svcName, ns, "impostor-binding-secret", 61617).
+		Build()
+	impostor.CreationTimestamp = metav1.NewTime(time.Now())
+	// the same values of a multi-valued RDN in another order are the same subject
+	impostor.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders",
+		OrganizationalUnits: []string{"eu", "payments"},
+	}
+	env := NewTestEnvironment(ns, oc, service, orders, impostor)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	if assert.Len(t, updated.Status.RejectedApps, 1) {
+		assert.Equal(t, "impostor", updated.Status.RejectedApps[0].Name)
+		assert.Equal(t, "client cert subject CN=orders,OU=eu+OU=payments overlaps app default/orders", updated.Status.RejectedApps[0].Reason)
+	}
+}
+
+func TestBrokerAppIdentityClash_ValidationError(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	svc := NewBrokerService(svcName, ns).Build()
+	orders := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	impostor := NewBrokerApp("impostor", ns).Build()
+	impostor.Spec.ClientCertSubject = &v1beta2.ClientCertSubjectType{
+		CommonName:          "orders",
+		OrganizationalUnits: []string{ns},
+	}
+
+	env := NewTestEnvironment(ns, svc, orders, impostor)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: impostor.Name, Namespace: ns}}
+	result, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.Nil(t, updatedApp.Status.Service)
+	validCondition := meta.FindStatusCondition(updatedApp.Status.Conditions, v1beta2.ValidConditionType)
+	assert.NotNil(t, validCondition)
+	assert.Equal(t, metav1.ConditionFalse, validCondition.Status)
+	assert.Equal(t, v1beta2.ValidConditionIdentityClashReason, validCondition.Reason)
+	assert.Contains(t, validCondition.Message, "CN=orders,OU=default")
+	assert.Contains(t, validCondition.Message, "default/orders")
+}
@@ -18,20 +18,27 @@ package controllers
 
 import (
 	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/appselector"
+	operatormetrics "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/secrets"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -46,6 +53,15 @@ import (
 const (
 	// FQQNSeparator is the separator used in Fully Qualified Queue Names (address-name::queue-name)
 	FQQNSeparator = "::"
+
+	// ClientCertDuration is the validity of an operator issued app client cert
+	ClientCertDuration = 90 * 24 * time.Hour
+
+	// ClientCertRenewBefore is how long before expiry an operator issued app client cert is rotated
+	ClientCertRenewBefore = 30 * 24 * time.Hour
+
+	// DrainRetryInterval is how often an app on a draining service looks for another service with capacity
+	DrainRetryInterval = 30 * time.Second
 )
 
 // isMulticastAddress determines if an address uses pubSub semantics
@@ -104,6 +120,11 @@ func collectOwnedAddresses(app *broker.BrokerApp) map[string]bool {
 		addresses[addrType.Address] = true
 	}
 
+	// Add dead letter and expiry addresses of declared addresses
+	for _, policyAddress := range collectPolicyAddresses(app) {
+		addresses[policyAddress] = true
+	}
+
 	// Add from capabilities (local addresses only - where appNamespace and appName are empty)
 	for _, capability := range app.Spec.Capabilities {
 		// Handle ProducerOf and ConsumerOf
@@ -130,6 +151,7 @@ func collectOwnedAddresses(app *broker.BrokerApp) map[string]bool {
 
 type BrokerAppReconciler struct {
 	*ReconcilerLoop
+	recorder *EventRecorder
 }
 
 type BrokerAppInstanceReconciler struct {
@@ -137,6 +159,18 @@ type BrokerAppInstanceReconciler struct {
 	instance *broker.BrokerApp
 	service  *broker.BrokerService
 	status   *broker.BrokerAppStatus
+
+	// requeueAfter schedules the next reconcile, for client cert rotation, migration progress, draining and statistics
+	requeueAfter time.Duration
+
+	// rebindDeferred is why the app is rebound once the migration in progress completes
+	rebindDeferred string
+
+	// migrationErr is why the messages pending migration could not be counted
+	migrationErr error
+
+	// placement is the last decision of findServiceWithCapacity
+	placement *broker.BrokerAppPlacementStatus
 }
 
 func (reconciler BrokerAppInstanceReconciler) validateSpec() error {
@@ -145,24 +179,68 @@ func (reconciler BrokerAppInstanceReconciler) validateSpec() error {
 		return err
 	}
 
+	if expression := reconciler.instance.Spec.ServiceSelectorExpression; expression != "" {
+		if err := appselector.ValidateExpression(expression); err != nil {
+			return NewValidationError(
+				broker.ValidConditionSpecSelectorError,
+				"invalid serviceSelectorExpression: %v", err)
+		}
+	}
+
 	// Validate capability address types (structural checks only)
 	if err := reconciler.verifyCapabilityAddressType(); err != nil {
 		return err
 	}
 
+	// Validate that wildcards are only used to subscribe
+	if err := reconciler.validateWildcardAddresses(); err != nil {
+		return err
+	}
+
+	// Validate that browserOf, requestReplyOn and managerOf target owned or shared addresses
+	if err := reconciler.validateCapabilityVerbs(); err != nil {
+		return err
+	}
+
+	// Validate that routes connect distinct owned or shared addresses
+	if err := reconciler.validateRoutes(); err != nil {
+		return err
+	}
+
+	// Validate that subscription settings apply to declared subscriptions
+	if err := reconciler.validateSubscriptionSettings(); err != nil {
+		return err
+	}
+
+	// Validate that no address is in the namespace of temporary queues
+	if err := reconciler.validateTemporaryQueueNamespace(); err != nil {
+		return err
+	}
+
 	// Validate that Addresses and SharedAddresses don't overlap
 	if err := reconciler.validateAddressesDisjoint(); err != nil {
 		return err
 	}
 
 	// Validate that declared addresses match their usage in capabilities
-	return reconciler.validateAddressCapabilityConsistency()
+	if err := reconciler.validateAddressCapabilityConsistency(); err != nil {
+		return err
+	}
+
+	// Validate the access policy of shared addresses
+	if err := reconciler.validateAddressAccess(); err != nil {
+		return err
+	}
+
+	// Validate the delivery policy of declared addresses
+	return reconciler.validateAddressSettings()
 }
 
-func (reconciler BrokerAppInstanceReconciler) processBindingSecret() error {
+func (reconciler *BrokerAppInstanceReconciler) processBindingSecret() error {
 
 	// Only manage binding secret if app has been bound to a service (status field exists)
 	if reconciler.status.Service == nil {
+		reconciler.status.ClientCert = nil
 		return nil
 	}
 
@@ -185,16 +263,220 @@ func (reconciler BrokerAppInstanceReconciler) processBindingSecret() error {
 		return fmt.Errorf("no port assigned for app %s", reconciler.instance.Name)
 	}
 
+	// the app is reached via the Service of the peer it is placed on
+	peerName := PeerName(reconciler.status.Service.Name, reconciler.status.Service.Peer)
+	deployedData := desired.Data
 	desired.Data = map[string][]byte{
 		// host as FQQN to work everywhere in the cluster
-		"host": []byte(fmt.Sprintf("%s.%s.svc.%s", reconciler.status.Service.Name, reconciler.status.Service.Namespace, common.GetClusterDomain())),
+		"host": []byte(fmt.Sprintf("%s.%s.svc.%s", peerName, reconciler.status.Service.Namespace, common.GetClusterDomain())),
 		"port": []byte(fmt.Sprintf("%d", port)),
-		"uri":  []byte(fmt.Sprintf("amqps://%s.%s.svc.%s:%d", reconciler.status.Service.Name, reconciler.status.Service.Namespace, common.GetClusterDomain(), port)),
+		"uri":  []byte(fmt.Sprintf("amqps://%s.%s.svc.%s:%d", peerName, reconciler.status.Service.Namespace, common.GetClusterDomain(), port)),
+	}
+
+	if hasRequestReply(reconciler.instance) {
+		// the broker only lets the app create temporary queues with its prefix
+		desired.Data["temporary-queue-prefix"] = []byte(TemporaryQueuePrefix(reconciler.instance))
+	}
+
+	if reconciler.service != nil && isHA(reconciler.service) {
+		// advertise each peer so that clients can fail over to the backup
+		peers := make([]string, 0, 2)
+		peerUris := make([]string, 0, 2)
+		for _, brokerName := range PeerBrokerNames(reconciler.service, reconciler.status.Service.Peer) {
+			peerHost := common.OrdinalFQDNS(brokerName, reconciler.service.Namespace, 0)
+			peers = append(peers, fmt.Sprintf("%s:%d", peerHost, port))
+			peerUris = append(peerUris, fmt.Sprintf("amqps://%s:%d", peerHost, port))
+		}
+		desired.Data["peers"] = []byte(strings.Join(peers, ","))
+		desired.Data["failover-uri"] = []byte(fmt.Sprintf("failover:(%s)", strings.Join(peerUris, ",")))
+	}
+
+	if reconciler.service != nil {
+		// reached from outside the cluster via the exposed endpoint, SNI routes to the app acceptor
+		if externalHost, externalPort, exposed := AppExternalEndpoint(reconciler.service, reconciler.instance, reconciler.status.Service); exposed {
+			desired.Data["external-host"] = []byte(externalHost)
+			desired.Data["external-port"] = []byte(fmt.Sprintf("%d", externalPort))
+			desired.Data["external-uri"] = []byte(fmt.Sprintf("amqps://%s:%d", externalHost, externalPort))
+		}
+	}
+
+	if err := reconciler.processClientCert(desired, deployedData); err != nil {
+		return err
 	}
 	reconciler.TrackDesired(desired)
 	return nil
 }
 
+// processClientCert adds the trusted CA and a client cert signed by the operator issuer to the binding secret.
+// The deployed cert is retained until its renewal time, or until the issuer changes
+func (reconciler *BrokerAppInstanceReconciler) processClientCert(desired *corev1.Secret, deployedData map[string][]byte) error {
+
+	if caSecret, err := common.GetOperatorCASecret(reconciler.Client); err == nil {
+		if caSecretKey, err := common.GetOperatorCASecretKey(reconciler.Client, caSecret); err == nil {
+			desired.Data["ca.crt"] = caSecret.Data[caSecretKey]
+		}
+	}
+
+	issuerSecret, err := common.GetOperatorIssuerSecret(reconciler.Client)
+	if err != nil {
+		// client certs are provided by the user
+		reconciler.log.V(2).Info("No operator issuer, client cert not issued", "reason", err)
+		reconciler.status.ClientCert = nil
+		return nil
+	}
+
+	issuer, err := certutil.NewIssuerFromSecret(issuerSecret)
+	if err != nil {
+		return NewTransientErrorWithCause(
+			broker.DeployedConditionCrudKindErrorReason,
+			"failed to load the operator issuer",
+			err)
+	}
+
+	now := time.Now()
+	subject := AppCertSubject(reconciler.instance)
+
+	certPem, keyPem := deployedData["tls.crt"], deployedData["tls.key"]
+	cert := issuer.IssuedClientCert(certPem, keyPem, subject)
+	if cert == nil || !now.Before(cert.NotAfter.Add(-ClientCertRenewBefore)) {
+		reconciler.log.V(1).Info("Issuing client cert", "app", reconciler.instance.Name, "subject", subject.String())
+		if certPem, keyPem, err = issuer.IssueClientCert(subject, now, ClientCertDuration); err != nil {
+			return NewTransientErrorWithCause(
+				broker.DeployedConditionCrudKindErrorReason,
+				"failed to issue client cert",
+				err)
+		}
+		cert = issuer.IssuedClientCert(certPem, keyPem, subject)
+	}
+
+	desired.Data["tls.crt"] = certPem
+	desired.Data["tls.key"] = keyPem
+
+	renewalTime := cert.NotAfter.Add(-ClientCertRenewBefore)
+	reconciler.status.ClientCert = &broker.BrokerAppClientCertStatus{
+		Subject:     CertSubjectDN(subject),
+		NotAfter:    metav1.NewTime(cert.NotAfter),
+		RenewalTime: metav1.NewTime(renewalTime),
+	}
+	reconciler.requeueWithin(renewalTime.Sub(now))
+	return nil
+}
+
+// requeueWithin schedules the next reconcile no later than after the duration
+func (reconciler *BrokerAppInstanceReconciler) requeueWithin(duration time.Duration) {
+	if reconciler.requeueAfter == 0 || reconciler.requeueAfter > duration {
+		reconciler.requeueAfter = duration
+	}
+}
+
+// AppCertSubject returns the subject of the client cert of the app, declared or CN=<name>,OU=<namespace>
+func AppCertSubject(app *broker.BrokerApp) pkix.Name {
+	if declared := app.Spec.ClientCertSubject; declared != nil {
+		return pkix.Name{
+			CommonName:         declared.CommonName,
+			OrganizationalUnit: declared.OrganizationalUnits,
+			Organization:       declared.Organizations,
+		}
+	}
+	return pkix.Name{
+		CommonName:         app.Name,
+		OrganizationalUnit: []string{app.Namespace},
+	}
+}
+
+// AppCertDN returns the distinguished name that authenticates as the app, the subject of
+// the issued client cert when there is one
+func AppCertDN(app *broker.BrokerApp) string {
+	if app.Status.ClientCert != nil {
+		return app.Status.ClientCert.Subject
+	}
+	return CertSubjectDN(AppCertSubject(app))
+}
+
+// CertSubjectDN returns the distinguished name of a cert with the subject, the values of a multi-valued
+// RDN are in the order of their encoding so subjects that only differ in that order have the same name
+func CertSubjectDN(subject pkix.Name) string {
+	var rdns pkix.RDNSequence
+	if encoded, err := asn1.Marshal(subject.ToRDNSequence()); err == nil {
+		if _, err = asn1.Unmarshal(encoded, &rdns); err == nil {
+			return rdns.String()
+		}
+	}
+	return subject.String()
+}
+
+// rfc2253AttributeTypes are the attribute type keywords of the RFC 2253 name of the JVM, other types are
+// rendered as their OID
+var rfc2253AttributeTypes = map[string]string{
+	"2.5.4.3":  "CN",
+	"2.5.4.6":  "C",
+	"2.5.4.7":  "L",
+	"2.5.4.8":  "ST",
+	"2.5.4.9":  "STREET",
+	"2.5.4.10": "O",
+	"2.5.4.11": "OU",
+}
+
+// CertSubjectPattern returns the users file pattern that authenticates exactly the subject. The broker
+// matches the RFC 2253 name of the JVM, which may escape the special characters of a value differently
+// and order the values of a multi-valued RDN differently, so only those differences are tolerated
+func CertSubjectPattern(subject pkix.Name) string {
+	rdns := subject.ToRDNSequence()
+	patterns := make([]string, 0, len(rdns))
+	// the most specific RDN comes first
+	for index := len(rdns) - 1; index >= 0; index-- {
+		patterns = append(patterns, rdnPattern(rdns[index]))
+	}
+	return "/^" + strings.Join(patterns, ",") + "$/"
+}
+
+// rdnPattern matches the values of the RDN in any order, the order of a multi-valued RDN is the order
+// of its encoding which differs from the order of the subject
+func rdnPattern(rdn pkix.RelativeDistinguishedNameSET) string {
+	values := make([]string, 0, len(rdn))
+	for _, attribute := range rdn {
+		attributeType, found := rfc2253AttributeTypes[attribute.Type.String()]
+		if !found {
+			attributeType = attribute.Type.String()
+		}
+		values = append(values, regexp.QuoteMeta(attributeType)+"="+attributeValuePattern(fmt.Sprint(attribute.Value)))
+	}
+	if len(values) == 1 {
+		return values[0]
+	}
+	var orders []string
+	for _, order := range permutations(values) {
+		orders = append(orders, strings.Join(order, `\+`))
+	}
+	return "(?:" + strings.Join(orders, "|") + ")"
+}
+
+func permutations(values []string) [][]string {
+	if len(values) <= 1 {
+		return [][]string{values}
+	}
+	var result [][]string
+	for index, first := range values {
+		rest := append(append([]string{}, values[:index]...), values[index+1:]...)
+		for _, order := range permutations(rest) {
+			result = append(result, append([]string{first}, order...))
+		}
+	}
+	return result
+}
+
+// attributeValuePattern matches the value with or without the escaping of its special characters
+func attributeValuePattern(value string) string {
+	var pattern strings.Builder
+	for _, c := range value {
+		if strings.ContainsRune(`,+"\<>;=# `, c) {
+			pattern.WriteString(`\\?`)
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(c)))
+	}
+	return pattern.String()
+}
+
 func NewBrokerAppReconciler(client client.Client, scheme *runtime.Scheme, config *rest.Config, logger logr.Logger) *BrokerAppReconciler {
 	reconciler := BrokerAppReconciler{ReconcilerLoop: &ReconcilerLoop{KubeBits: &KubeBits{
 		Client: client, Scheme: scheme, Config: config, log: logger}}}
@@ -206,7 +488,8 @@ func NewBrokerAppReconciler(client client.Client, scheme *runtime.Scheme, config
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerservices,verbs=get;list;watch;update
 
-func (reconciler *BrokerAppReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (reconciler *BrokerAppReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	defer operatormetrics.ObserveReconcile("brokerapp", time.Now(), &reconcileErr)
 	reqLogger := reconciler.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "Reconciling", "BrokerApp")
 
 	instance := &broker.BrokerApp{}
@@ -224,7 +507,7 @@ func (reconciler *BrokerAppReconciler) Reconcile(ctx context.Context, request ct
 	}
 
 	processor := BrokerAppInstanceReconciler{
-		BrokerAppReconciler: &BrokerAppReconciler{ReconcilerLoop: localLoop},
+		BrokerAppReconciler: &BrokerAppReconciler{ReconcilerLoop: localLoop, recorder: reconciler.recorder},
 		instance:            instance,
 		status:              instance.Status.DeepCopy(),
 	}
@@ -234,7 +517,11 @@ func (reconciler *BrokerAppReconciler) Reconcile(ctx context.Context, request ct
 		if err = processor.resolveBrokerService(); err == nil {
 			if err = processor.InitDeployed(instance, processor.getOwned()...); err == nil {
 				if err = processor.processBindingSecret(); err == nil {
-					err = processor.SyncDesiredWithDeployed(processor.instance)
+					if err = processor.SyncDesiredWithDeployed(processor.instance); err == nil {
+						processor.processMigration()
+						processor.processStatistics()
+						err = processor.processLinks()
+					}
 				}
 			}
 		}
@@ -257,8 +544,8 @@ func (reconciler *BrokerAppReconciler) Reconcile(ctx context.Context, request ct
 	if statusErr != nil {
 		return ctrl.Result{}, fmt.Errorf("Failed to update status: error %v", statusErr)
 	}
-	// Success
-	return ctrl.Result{}, nil
+	// Success, revisit to rotate the client cert
+	return ctrl.Result{RequeueAfter: processor.requeueAfter}, nil
 }
 
 // instance specifics for a reconciler loop
@@ -297,6 +584,12 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 	// Check if we have an existing binding in status
 	hasBinding := reconciler.status.Service != nil
 
+	// the binding and the service it is on before any reassignment, with why the app is rebound
+	previous := reconciler.status.Service
+	var boundService *broker.BrokerService
+	var rebindReason, rebindCause string
+	draining := false
+
 	if hasBinding {
 		deployedTo := reconciler.status.Service.Key()
 		// Try to find the bound service in the list
@@ -306,6 +599,7 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 				break
 			}
 		}
+		boundService = service
 
 		// if we found the service and if it still matches, is it still valid
 		// the findservice with capactity does the same checks, here we use them to validate the current service
@@ -321,6 +615,8 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 				reconciler.status.Service = nil
 				service = nil
 				needsServiceAssignment = true
+				rebindReason = "the app was rejected by the service"
+				rebindCause = BindingReasonRejected
 			}
 
 			if service != nil {
@@ -334,6 +630,24 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 					reconciler.status.Service = nil
 					service = nil
 					needsServiceAssignment = true
+					rebindReason = "the app no longer matches the service selector"
+					rebindCause = BindingReasonAppSelector
+				}
+			}
+
+			if service != nil {
+				matches, matchErr := reconciler.matchesServiceSelectorExpression(service)
+				if !matches || matchErr != nil {
+					reconciler.log.V(1).Info("Service no longer matches app serviceSelectorExpression, removing binding",
+						"app", reconciler.instance.Name,
+						"service", deployedTo,
+						"error", matchErr,
+					)
+					reconciler.status.Service = nil
+					service = nil
+					needsServiceAssignment = true
+					rebindReason = "the service no longer matches spec.serviceSelectorExpression"
+					rebindCause = BindingReasonServiceSelector
 				}
 			}
 
@@ -347,6 +661,37 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 					reconciler.status.Service = nil
 					service = nil
 					needsServiceAssignment = true
+					rebindReason = fmt.Sprintf("address references are no longer satisfied, %v", addrRefErr)
+					rebindCause = BindingReasonAddressRef
+				}
+
+				// Check that the app is on the peer that hosts its referenced addresses
+				if service != nil {
+					if peerErr := reconciler.checkPlacementPeer(service, reconciler.status.Service.Peer); peerErr != nil {
+						reconciler.log.V(1).Info("Peer placement no longer valid, reassigning",
+							"app", reconciler.instance.Name,
+							"service", deployedTo,
+							"peer", reconciler.status.Service.Peer,
+							"error", peerErr)
+						reconciler.status.Service = nil
+						service = nil
+						needsServiceAssignment = true
+						rebindReason = fmt.Sprintf("peer placement is no longer valid, %v", peerErr)
+						rebindCause = BindingReasonPeerPlacement
+					}
+				}
+
+				// Check that the assigned port is still in the service port pool
+				if service != nil && !portInPool(service.Spec.PortRange, reconciler.status.Service.AssignedPort) {
+					reconciler.log.V(1).Info("Assigned port outside the service port range, reassigning",
+						"app", reconciler.instance.Name,
+						"service", deployedTo,
+						"currentPort", reconciler.status.Service.AssignedPort)
+					reconciler.status.Service = nil
+					service = nil
+					needsServiceAssignment = true
+					rebindReason = "the assigned port is outside the service port range"
+					rebindCause = BindingReasonPortRange
 				}
 
 				// Check for address clashes with apps already on this service
@@ -359,8 +704,23 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 						reconciler.status.Service = nil
 						service = nil
 						needsServiceAssignment = true
+						rebindReason = fmt.Sprintf("address clash, %v", clashErr)
+						rebindCause = BindingReasonAddressClash
 					}
 				}
+
+				// Move off a draining service, the binding stays until another service has capacity
+				if service != nil && service.Spec.SchedulingPolicy == broker.SchedulingPolicies.Draining {
+					reconciler.log.V(1).Info("Service draining, reassigning",
+						"app", reconciler.instance.Name,
+						"service", deployedTo)
+					reconciler.status.Service = nil
+					service = nil
+					needsServiceAssignment = true
+					draining = true
+					rebindReason = "the service is draining"
+					rebindCause = BindingReasonDraining
+				}
 			}
 		}
 
@@ -372,9 +732,32 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 					"old-binding", deployedTo,
 					"matching-services", len(list.Items))
 				needsServiceAssignment = true
+				rebindReason = "the service no longer matches spec.serviceSelector"
+				rebindCause = BindingReasonServiceSelector
 			}
 			// else: no services match current selector, processStatus will handle it
 		}
+
+		// A migration moves the messages of the previous binding to the current binding, a further
+		// rebind waits for the migration to complete rather than strand the messages already moved
+		if needsServiceAssignment && reconciler.status.Migration != nil && boundService == nil {
+			// the bound service may no longer be selected
+			candidate := &broker.BrokerService{}
+			if getErr := reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: previous.Namespace, Name: previous.Name}, candidate); getErr == nil {
+				boundService = candidate
+			}
+		}
+		if needsServiceAssignment && reconciler.status.Migration != nil && boundService != nil &&
+			!reconciler.isAppRejectedByService(boundService) {
+			reconciler.log.V(1).Info("Rebind deferred until the migration completes",
+				"app", reconciler.instance.Name,
+				"service", deployedTo,
+				"reason", rebindReason)
+			reconciler.status.Service = previous
+			service = boundService
+			needsServiceAssignment = false
+			reconciler.rebindDeferred = rebindReason
+		}
 	} else {
 		// No binding yet, need initial assignment
 		needsServiceAssignment = true
@@ -384,35 +767,80 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 	if needsServiceAssignment {
 
 		if len(list.Items) == 0 {
+			reconciler.setPlacement(&broker.BrokerAppPlacementStatus{})
 			// No matching services is a runtime issue, not a CR validation issue
 			return NewTransientError(
 				broker.DeployedConditionNoMatchingServiceReason,
 				fmt.Sprintf("no matching services available for selector %v", opts))
 		}
 
-		var assignedPort int32
-		service, assignedPort, err = reconciler.findServiceWithCapacity(list)
+		var assignedPeer, assignedPort int32
+		service, assignedPeer, assignedPort, err = reconciler.findServiceWithCapacity(list)
+		if reconciler.placement != nil {
+			countPlacementRejections(reconciler.status.Placement, reconciler.placement)
+			reconciler.setPlacement(reconciler.placement)
+		}
 		if err != nil {
-			// If findServiceWithCapacity returned a TransientError, preserve it
-			if _, isTransient := err.(*TransientError); !isTransient {
+			// If findServiceWithCapacity returned a TransientError or ValidationError, preserve it
+			_, isTransient := err.(*TransientError)
+			_, isValidation := err.(*ValidationError)
+			if !isTransient && !isValidation {
 				// Otherwise wrap with NoServiceCapacity
 				err = NewTransientError(
 					broker.DeployedConditionNoServiceCapacityReason,
 					fmt.Sprintf("no service with capacity available for selector %v, %v", opts, err))
 			}
+			if reconciler.placement != nil {
+				reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonRejected, "%s", placementRejectedMessage(reconciler.placement))
+			}
 		}
-		if service != nil {
+		if err != nil && draining {
+			// stay on the draining service until another service has capacity
+			reconciler.log.V(1).Info("No service to drain to, binding kept",
+				"app", reconciler.instance.Name,
+				"service", previous.Key(),
+				"error", err)
+			reconciler.status.Service = previous
+			service = boundService
+			err = nil
+			reconciler.requeueWithin(DrainRetryInterval)
+		} else if service != nil {
+			if previous == nil {
+				operatormetrics.AppBinds.WithLabelValues(BindingReasonInitial).Inc()
+			} else {
+				operatormetrics.AppRebinds.WithLabelValues(rebindCause).Inc()
+			}
+
 			// Set service binding including assigned port
 			reconciler.status.Service = &broker.BrokerServiceBindingStatus{
 				Name:         service.Name,
 				Namespace:    service.Namespace,
 				Secret:       BindingsSecretName(reconciler.instance.Name),
 				AssignedPort: assignedPort,
+				Peer:         assignedPeer,
 			}
 			reconciler.log.V(1).Info("Assigned port to app",
 				"app", reconciler.instance.Name,
 				"service", service.Name,
+				"peer", assignedPeer,
 				"port", assignedPort)
+
+			if previous == nil {
+				reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeNormal, EventReasonBound,
+					"bound to service %s peer %d on port %d",
+					reconciler.status.Service.Key(), assignedPeer, assignedPort)
+			} else {
+				reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeNormal, EventReasonRebound,
+					"rebound from service %s peer %d on port %d to service %s peer %d on port %d, %s",
+					previous.Key(), previous.Peer, previous.AssignedPort,
+					reconciler.status.Service.Key(), assignedPeer, assignedPort, rebindReason)
+				reconciler.startMigration(previous, rebindReason)
+			}
+		} else if previous != nil {
+			operatormetrics.AppUnbinds.WithLabelValues(rebindCause).Inc()
+			reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonUnbound,
+				"unbound from service %s peer %d on port %d, %s",
+				previous.Key(), previous.Peer, previous.AssignedPort, rebindReason)
 		}
 	}
 
@@ -420,6 +848,25 @@ func (reconciler *BrokerAppInstanceReconciler) resolveBrokerService() error {
 	return err
 }
 
+// Binding reasons label the bind, unbind and rebind metrics of BrokerApps
+const (
+	BindingReasonInitial         = "initial" // the app had no binding
+	BindingReasonRejected        = "rejected"
+	BindingReasonAppSelector     = "appSelector"
+	BindingReasonServiceSelector = "serviceSelector"
+	BindingReasonAddressRef      = "addressRef"
+	BindingReasonPeerPlacement   = "peerPlacement"
+	BindingReasonPortRange       = "portRange"
+	BindingReasonAddressClash    = "addressClash"
+	BindingReasonDraining        = "draining"
+)
+
+// isSchedulable is true when the service accepts new bindings
+func isSchedulable(service *broker.BrokerService) bool {
+	policy := service.Spec.SchedulingPolicy
+	return policy != broker.SchedulingPolicies.Cordoned && policy != broker.SchedulingPolicies.Draining
+}
+
 func BindingsSecretName(crName string) string {
 	return fmt.Sprintf("%s-binding-secret", crName)
 }
@@ -455,6 +902,17 @@ func (reconciler *BrokerAppInstanceReconciler) matchesServiceSelector(service *b
 	return matches, nil
 }
 
+// matchesServiceSelectorExpression checks if the service matches the app's serviceSelectorExpression.
+// Returns (false, error) if CEL evaluation fails, the service is not selected.
+func (reconciler *BrokerAppInstanceReconciler) matchesServiceSelectorExpression(service *broker.BrokerService) (bool, error) {
+	matches, err := appselector.MatchesService(reconciler.instance, service, reconciler.Client)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate serviceSelectorExpression on service %s/%s: %v",
+			service.Namespace, service.Name, err)
+	}
+	return matches, nil
+}
+
 // RejectionCategory categorizes why a service cannot accept an app
 type RejectionCategory int
 
@@ -464,33 +922,61 @@ const (
 	RejectionSelectorError                          // CEL evaluation error
 	RejectionAddressRef                             // AddressRef dependency not satisfied
 	RejectionAddressClash                           // Address name conflict with existing app
-	RejectionMemory                                 // Insufficient memory capacity
+	RejectionIdentityClash                          // Client cert subject conflict with existing app
+	RejectionCapacity                               // Insufficient capacity in one or more dimensions
 	RejectionPortPool                               // Port pool exhausted or not configured
+	RejectionCordoned                               // Service cordoned or draining, no new bindings
 	RejectionOther                                  // Other errors
 )
 
+var rejectionCategoryNames = map[RejectionCategory]string{
+	RejectionNotDeployed:   "notDeployed",
+	RejectionSelector:      "selector",
+	RejectionSelectorError: "selectorError",
+	RejectionAddressRef:    "addressRef",
+	RejectionAddressClash:  "addressClash",
+	RejectionIdentityClash: "identityClash",
+	RejectionCapacity:      "capacity",
+	RejectionPortPool:      "portPool",
+	RejectionCordoned:      "cordoned",
+	RejectionOther:         "other",
+}
+
+// String returns the name of the category, the label of the rejection metric
+func (category RejectionCategory) String() string {
+	if name, found := rejectionCategoryNames[category]; found {
+		return name
+	}
+	return rejectionCategoryNames[RejectionOther]
+}
+
 // ServiceRejection tracks why a specific service rejected an app
 type ServiceRejection struct {
-	ServiceName string
-	Category    RejectionCategory
-	Message     string
+	ServiceName      string
+	ServiceNamespace string
+	Category         RejectionCategory
+	Message          string
+	// Dimensions with insufficient capacity, for RejectionCapacity
+	Dimensions []CapacityDimension
 }
 
-func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *broker.BrokerServiceList) (chosen *broker.BrokerService, assignedPort int32, err error) {
+func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *broker.BrokerServiceList) (chosen *broker.BrokerService, assignedPeer int32, assignedPort int32, err error) {
 	if len(list.Items) == 0 {
-		return nil, UnassignedPort, fmt.Errorf("no services in list")
+		return nil, 0, UnassignedPort, fmt.Errorf("no services in list")
 	}
 
-	// Get the app's resource requirements
-	appMemoryRequest := reconciler.instance.Spec.Resources.Requests.Memory()
+	// Get the app's capacity demand and the strategy that chooses between services
+	demand := appCapacityDemand(reconciler.instance)
+	strategy := placementStrategy(reconciler.instance, nil)
 
-	var bestService *broker.BrokerService
-	var bestServicePort int32 = UnassignedPort
-	var maxAvailable int64 = -1
+	var best *placementCandidate
 
 	// Track why services were rejected for better error messages
 	var rejections []ServiceRejection
 
+	// Services with capacity, among which the strategy chooses
+	var offers []*placementCandidate
+
 	for i := range list.Items {
 		service := &list.Items[i]
 
@@ -498,9 +984,10 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 		deployedCond := meta.FindStatusCondition(service.Status.Conditions, broker.DeployedConditionType)
 		if deployedCond == nil || deployedCond.Status != metav1.ConditionTrue {
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionNotDeployed,
-				Message:     "service not deployed yet (port discovery pending)",
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionNotDeployed,
+				Message:          "service not deployed yet (port discovery pending)",
 			})
 			continue
 		}
@@ -513,9 +1000,10 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 				"service", service.Name,
 				"error", matchErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionSelectorError,
-				Message:     fmt.Sprintf("selector evaluation failed: %v", matchErr),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionSelectorError,
+				Message:          fmt.Sprintf("selector evaluation failed: %v", matchErr),
 			})
 			continue
 		}
@@ -524,9 +1012,47 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 				"service", service.Name,
 				"app-namespace", reconciler.instance.Namespace)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionSelector,
-				Message:     "does not match selector",
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionSelector,
+				Message:          "does not match selector",
+			})
+			continue
+		}
+
+		// Check the app's own expression on the service
+		matches, matchErr = reconciler.matchesServiceSelectorExpression(service)
+		if matchErr != nil {
+			reconciler.log.V(1).Info("Failed to evaluate serviceSelectorExpression for service",
+				"service", service.Name,
+				"error", matchErr)
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionSelectorError,
+				Message:          fmt.Sprintf("selector evaluation failed: %v", matchErr),
+			})
+			continue
+		}
+		if !matches {
+			reconciler.log.V(1).Info("Service does not match app serviceSelectorExpression",
+				"service", service.Name)
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionSelector,
+				Message:          "does not match serviceSelectorExpression",
+			})
+			continue
+		}
+
+		// Cordoned and draining services are out of rotation
+		if !isSchedulable(service) {
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionCordoned,
+				Message:          fmt.Sprintf("service scheduling policy is %s", service.Spec.SchedulingPolicy),
 			})
 			continue
 		}
@@ -537,9 +1063,10 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 				"service", service.Name,
 				"error", addrRefErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionAddressRef,
-				Message:     addrRefErr.Error(),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionAddressRef,
+				Message:          addrRefErr.Error(),
 			})
 			continue
 		}
@@ -550,36 +1077,104 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 				"service", service.Name,
 				"error", clashErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionAddressClash,
-				Message:     clashErr.Error(),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionAddressClash,
+				Message:          clashErr.Error(),
+			})
+			continue
+		}
+
+		// Check for client cert subject clashes with apps already on this service
+		if clashErr := reconciler.checkIdentityClashOnService(service); clashErr != nil {
+			reconciler.log.V(1).Info("Service has identity clash",
+				"service", service.Name,
+				"error", clashErr)
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionIdentityClash,
+				Message:          clashErr.Error(),
+			})
+			continue
+		}
+
+		// Check the peer that hosts referenced addresses, if any
+		pinnedPeer, pinned, peerErr := reconciler.referencedPeer(service)
+		if peerErr != nil {
+			reconciler.log.V(1).Info("Service cannot satisfy addressRef peer placement",
+				"service", service.Name,
+				"error", peerErr)
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionAddressRef,
+				Message:          peerErr.Error(),
 			})
 			continue
 		}
 
-		// Check memory capacity
-		available, checkErr := reconciler.getAvailableMemory(service)
+		if pinned && (pinnedPeer < 0 || pinnedPeer >= PeerCount(service)) {
+			rejections = append(rejections, ServiceRejection{
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionAddressRef,
+				Message:          fmt.Sprintf("referenced addresses are on peer %d which does not exist", pinnedPeer),
+			})
+			continue
+		}
+
+		// Check capacity, choosing the peer with the placement strategy
+		peers, checkErr := reconciler.getPeerCapacity(service)
 		if checkErr != nil {
 			reconciler.log.V(1).Info("Failed to check capacity for service",
 				"service", service.Name,
 				"error", checkErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionOther,
-				Message:     fmt.Sprintf("error checking capacity: %v", checkErr),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionOther,
+				Message:          fmt.Sprintf("error checking capacity: %v", checkErr),
 			})
 			continue
 		}
 
-		if appMemoryRequest != nil && available < appMemoryRequest.Value() {
-			reconciler.log.V(1).Info("Service has insufficient memory capacity",
+		peerStrategy := placementStrategy(reconciler.instance, service)
+		links := len(reconciler.linkedAddresses(serviceKey(service)))
+		var candidate *placementCandidate
+		var shortDimensions []CapacityDimension
+		var shortfalls []string
+		for index := range peers {
+			if pinned && int32(index) != pinnedPeer {
+				continue
+			}
+			if dimensions, details := peers[index].insufficient(demand); len(dimensions) > 0 {
+				shortDimensions = append(shortDimensions, dimensions...)
+				shortfalls = append(shortfalls, fmt.Sprintf("peer %d: %s", index, strings.Join(details, ", ")))
+				continue
+			}
+			peerCandidate := &placementCandidate{
+				service:     service,
+				peer:        int32(index),
+				utilization: peers[index].utilization(demand),
+				apps:        peers[index].apps,
+				links:       links,
+			}
+			if peerCandidate.preferredTo(candidate, peerStrategy) {
+				candidate = peerCandidate
+			}
+		}
+
+		if candidate == nil {
+			reconciler.log.V(1).Info("Service has insufficient capacity",
 				"service", service.Name,
-				"available", available,
-				"required", appMemoryRequest.Value())
+				"shortfalls", shortfalls)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionMemory,
-				Message:     fmt.Sprintf("insufficient memory (available: %d, required: %d)", available, appMemoryRequest.Value()),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionCapacity,
+				Message:          fmt.Sprintf("insufficient capacity on %s", strings.Join(shortfalls, "; ")),
+				Dimensions:       orderedDimensions(shortDimensions),
 			})
 			continue
 		}
@@ -591,50 +1186,162 @@ func (reconciler *BrokerAppInstanceReconciler) findServiceWithCapacity(list *bro
 				"service", service.Name,
 				"error", listErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionOther,
-				Message:     fmt.Sprintf("error checking port capacity: %v", listErr),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionOther,
+				Message:          fmt.Sprintf("error checking port capacity: %v", listErr),
 			})
 			continue
 		}
 
-		usedPorts := collectUsedPorts(apps, reconciler.instance)
-		candidatePort, portErr := assignNextAvailablePort(usedPorts)
+		usedPorts := collectUsedPorts(service.Spec.PortRange, apps, reconciler.instance)
+		candidatePort, portErr := assignNextAvailablePort(service.Spec.PortRange, usedPorts)
 		if portErr != nil {
 			reconciler.log.V(1).Info("Service has no available ports",
 				"service", service.Name,
 				"error", portErr)
 			rejections = append(rejections, ServiceRejection{
-				ServiceName: service.Name,
-				Category:    RejectionPortPool,
-				Message:     fmt.Sprintf("port pool exhausted: %v", portErr),
+				ServiceName:      service.Name,
+				ServiceNamespace: service.Namespace,
+				Category:         RejectionPortPool,
+				Message:          fmt.Sprintf("port pool exhausted: %v", portErr),
 			})
 			continue
 		}
 
-		// Track service with most available capacity
-		if available > maxAvailable {
-			maxAvailable = available
-			bestService = service
-			bestServicePort = candidatePort
+		// Track the service preferred by the placement strategy
+		candidate.port = candidatePort
+		offers = append(offers, candidate)
+		if candidate.preferredTo(best, strategy) {
+			best = candidate
 		}
 	}
 
-	if bestService == nil {
-		return nil, UnassignedPort, reconciler.buildCapacityError(rejections, appMemoryRequest)
+	reconciler.placement = placementDecision(list, rejections, offers, best, strategy)
+
+	if best == nil {
+		return nil, 0, UnassignedPort, reconciler.buildCapacityError(rejections, demand)
 	}
 
 	reconciler.log.V(1).Info("Selected service with capacity",
-		"service", bestService.Name,
-		"available-memory", maxAvailable,
-		"assigned-port", bestServicePort)
-	return bestService, bestServicePort, nil
+		"service", best.service.Name,
+		"peer", best.peer,
+		"strategy", strategy,
+		"utilization", best.utilization,
+		"assigned-port", best.port)
+	return best.service, best.peer, best.port, nil
+}
+
+// placementDecision explains the choice of best among the services of the list, with the rejections of
+// the other services and the offers of those with capacity
+func placementDecision(list *broker.BrokerServiceList, rejections []ServiceRejection, offers []*placementCandidate,
+	best *placementCandidate, strategy broker.PlacementStrategy) *broker.BrokerAppPlacementStatus {
+
+	rejected := make(map[string]ServiceRejection, len(rejections))
+	for _, rejection := range rejections {
+		rejected[rejection.ServiceNamespace+":"+rejection.ServiceName] = rejection
+	}
+	offered := make(map[string]*placementCandidate, len(offers))
+	for _, offer := range offers {
+		offered[serviceKey(offer.service)] = offer
+	}
+
+	placement := &broker.BrokerAppPlacementStatus{}
+	for index := range list.Items {
+		service := &list.Items[index]
+		candidate := broker.BrokerAppPlacementCandidate{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+		}
+		if rejection, found := rejected[serviceKey(service)]; found {
+			candidate.Category = rejection.Category.String()
+			candidate.Message = rejection.Message
+		} else if offer, found := offered[serviceKey(service)]; found {
+			candidate.Message = fmt.Sprintf("capacity available on peer %d", offer.peer)
+			if offer.links > 0 {
+				candidate.Message += fmt.Sprintf(", %d shared addresses linked from other services", offer.links)
+			}
+		}
+		placement.Candidates = append(placement.Candidates, candidate)
+	}
+
+	if best != nil {
+		placement.Chosen = &broker.BrokerAppPlacementChoice{
+			Name:      best.service.Name,
+			Namespace: best.service.Namespace,
+			Peer:      best.peer,
+			Port:      best.port,
+			Strategy:  strategy,
+		}
+	}
+	return placement
+}
+
+// countPlacementRejections counts the rejections of a placement decision once, a decision made again with
+// the same outcome for each candidate is not counted
+func countPlacementRejections(previous *broker.BrokerAppPlacementStatus, placement *broker.BrokerAppPlacementStatus) {
+	if previous != nil && samePlacementOutcome(previous, placement) {
+		return
+	}
+	for _, candidate := range placement.Candidates {
+		if candidate.Category != "" {
+			operatormetrics.AppPlacementRejections.WithLabelValues(candidate.Category).Inc()
+		}
+	}
+}
+
+// samePlacementOutcome is true when the decisions chose the same service and rejected the same candidates
+// for the same reasons, the messages carry load figures that change between decisions
+func samePlacementOutcome(a *broker.BrokerAppPlacementStatus, b *broker.BrokerAppPlacementStatus) bool {
+	if !equality.Semantic.DeepEqual(a.Chosen, b.Chosen) || len(a.Candidates) != len(b.Candidates) {
+		return false
+	}
+	for index := range a.Candidates {
+		if a.Candidates[index].Name != b.Candidates[index].Name ||
+			a.Candidates[index].Namespace != b.Candidates[index].Namespace ||
+			a.Candidates[index].Category != b.Candidates[index].Category {
+			return false
+		}
+	}
+	return true
+}
+
+// placementRejectedMessage summarizes a placement decision that chose no service in one line that is stable
+// between decisions with the same outcome, the rejection of each candidate is in status.placement
+func placementRejectedMessage(placement *broker.BrokerAppPlacementStatus) string {
+	rejected := 0
+	categories := map[string]int{}
+	for _, candidate := range placement.Candidates {
+		if candidate.Category != "" {
+			categories[candidate.Category]++
+			rejected++
+		}
+	}
+	counts := make([]string, 0, len(categories))
+	for category, count := range categories {
+		counts = append(counts, fmt.Sprintf("%s: %d", category, count))
+	}
+	sort.Strings(counts)
+	return fmt.Sprintf("no service can take the app, %d of %d services rejected (%s), see status.placement",
+		rejected, len(placement.Candidates), strings.Join(counts, ", "))
+}
+
+// setPlacement records a placement decision in the status, the decision time of a decision that is
+// made again is kept
+func (reconciler *BrokerAppInstanceReconciler) setPlacement(placement *broker.BrokerAppPlacementStatus) {
+	placement.DecisionTime = metav1.Now()
+	if previous := reconciler.status.Placement; previous != nil &&
+		equality.Semantic.DeepEqual(previous.Candidates, placement.Candidates) &&
+		equality.Semantic.DeepEqual(previous.Chosen, placement.Chosen) {
+		placement.DecisionTime = previous.DecisionTime
+	}
+	reconciler.status.Placement = placement
 }
 
 // buildCapacityError analyzes the structured rejection data and constructs an informative error message
 func (reconciler *BrokerAppInstanceReconciler) buildCapacityError(
 	rejections []ServiceRejection,
-	appMemoryRequest *resource.Quantity,
+	demand capacityAmounts,
 ) error {
 	if len(rejections) == 0 {
 		return fmt.Errorf("no services available")
@@ -674,11 +1381,29 @@ func (reconciler *BrokerAppInstanceReconciler) buildCapacityError(
 
 	// Special case: all services rejected due to port pool exhaustion
 	if categoryCounts[RejectionPortPool] == totalServices {
-		return fmt.Errorf("all services have exhausted their port pools")
+		var details []string
+		for _, r := range rejections {
+			details = append(details, fmt.Sprintf("%s: %s", r.ServiceName, r.Message))
+		}
+		return NewTransientError(
+			broker.DeployedConditionPortPoolExhaustedReason,
+			fmt.Sprintf("all services have exhausted their port pools: %s", strings.Join(details, "; ")))
+	}
+
+	// Special case: all services rejected due to client cert subject clashes, the spec must change
+	if categoryCounts[RejectionIdentityClash] == totalServices {
+		var details []string
+		for _, r := range rejections {
+			details = append(details, fmt.Sprintf("%s: %s", r.ServiceName, r.Message))
+		}
+		return NewValidationError(
+			broker.ValidConditionIdentityClashReason,
+			"client cert subject %s overlaps apps on all matching services: %s",
+			AppCertDN(reconciler.instance), strings.Join(details, "; "))
 	}
 
 	// Determine primary blocking issue based on priority
-	// Priority: AddressRef > AddressClash > Memory > PortPool > Other
+	// Priority: AddressRef > AddressClash > IdentityClash > Capacity > PortPool > Other
 	var primaryMessage string
 
 	switch {
@@ -688,16 +1413,32 @@ func (reconciler *BrokerAppInstanceReconciler) buildCapacityError(
 	case categoryCounts[RejectionAddressClash] > 0:
 		primaryMessage = "address clash with existing apps"
 
-	case categoryCounts[RejectionMemory] > 0:
-		memoryStr := "unknown"
-		if appMemoryRequest != nil && !appMemoryRequest.IsZero() {
-			memoryStr = appMemoryRequest.String()
+	case categoryCounts[RejectionIdentityClash] > 0:
+		primaryMessage = "client cert subject clash with existing apps"
+
+	case categoryCounts[RejectionCapacity] > 0:
+		var dimensions []CapacityDimension
+		for _, r := range rejections {
+			if r.Category == RejectionCapacity {
+				dimensions = append(dimensions, r.Dimensions...)
+			}
+		}
+		dimensions = orderedDimensions(dimensions)
+		names := make([]string, len(dimensions))
+		for i, dimension := range dimensions {
+			names[i] = string(dimension)
+		}
+		primaryMessage = fmt.Sprintf("insufficient %s capacity", strings.Join(names, ", "))
+		if required := describeDemand(dimensions, demand); required != "" {
+			primaryMessage = fmt.Sprintf("%s (app requires %s)", primaryMessage, required)
 		}
-		primaryMessage = fmt.Sprintf("insufficient memory capacity (app requires %s)", memoryStr)
 
 	case categoryCounts[RejectionPortPool] > 0:
 		primaryMessage = "port pool exhausted"
 
+	case categoryCounts[RejectionCordoned] > 0:
+		primaryMessage = "services are cordoned"
+
 	default:
 		primaryMessage = "other compatibility issues"
 	}
@@ -736,9 +1477,24 @@ func (reconciler *BrokerAppInstanceReconciler) buildCapacityError(
 		}
 	}
 
-	if len(categoryServices[RejectionMemory]) > 0 {
-		errMsg.WriteString(fmt.Sprintf("  - Insufficient memory: %s\n",
-			formatServices(categoryServices[RejectionMemory])))
+	if len(categoryServices[RejectionIdentityClash]) > 0 {
+		errMsg.WriteString(fmt.Sprintf("  - Identity clashes: %s\n",
+			formatServices(categoryServices[RejectionIdentityClash])))
+		for _, r := range rejections {
+			if r.Category == RejectionIdentityClash {
+				errMsg.WriteString(fmt.Sprintf("      %s: %s\n", r.ServiceName, r.Message))
+			}
+		}
+	}
+
+	if len(categoryServices[RejectionCapacity]) > 0 {
+		errMsg.WriteString(fmt.Sprintf("  - Insufficient capacity: %s\n",
+			formatServices(categoryServices[RejectionCapacity])))
+		for _, r := range rejections {
+			if r.Category == RejectionCapacity {
+				errMsg.WriteString(fmt.Sprintf("      %s: %s\n", r.ServiceName, r.Message))
+			}
+		}
 	}
 
 	if len(categoryServices[RejectionPortPool]) > 0 {
@@ -746,6 +1502,11 @@ func (reconciler *BrokerAppInstanceReconciler) buildCapacityError(
 			formatServices(categoryServices[RejectionPortPool])))
 	}
 
+	if len(categoryServices[RejectionCordoned]) > 0 {
+		errMsg.WriteString(fmt.Sprintf("  - Cordoned: %s\n",
+			formatServices(categoryServices[RejectionCordoned])))
+	}
+
 	if len(categoryServices[RejectionSelector]) > 0 {
 		errMsg.WriteString(fmt.Sprintf("  - Selector mismatch: %s\n",
 			formatServices(categoryServices[RejectionSelector])))
@@ -777,52 +1538,93 @@ func (reconciler *BrokerAppInstanceReconciler) listOtherAppsForService(service *
 	}
 
 	// Filter out ourselves
-	result := make([]broker.BrokerApp, 0, len(apps.Items))
+	others := make([]broker.BrokerApp, 0, len(apps.Items))
 	for _, app := range apps.Items {
 		if app.Namespace == reconciler.instance.Namespace && app.Name == reconciler.instance.Name {
 			continue
 		}
-		result = append(result, app)
+		others = append(others, app)
 	}
-	return result, nil
+	return appsPlacedOnService(others, key), nil
 }
 
-func (reconciler *BrokerAppInstanceReconciler) getAvailableMemory(service *broker.BrokerService) (int64, error) {
-	// Get service's total memory limit (0 if not specified means unlimited)
-	serviceMemory := service.Spec.Resources.Limits.Memory()
-	if serviceMemory == nil || serviceMemory.IsZero() {
-		// No limit specified, treat as unlimited
-		return int64(^uint64(0) >> 1), nil // max int64
+// appsPlacedOnService returns the apps bound to the service with the key, a migrating app also holds
+// its previous binding until its messages are moved
+func appsPlacedOnService(apps []broker.BrokerApp, key string) []broker.BrokerApp {
+	result := make([]broker.BrokerApp, 0, len(apps))
+	for index := range apps {
+		app := &apps[index]
+		if app.Status.Service != nil && app.Status.Service.Key() == key {
+			result = append(result, *app)
+		}
+		if from := migratingFrom(app, key); from != nil {
+			result = append(result, *withBinding(app, from))
+		}
 	}
-	totalMemory := serviceMemory.Value()
+	return result
+}
 
-	// Find all other apps currently provisioned on this service
-	apps, err := reconciler.listOtherAppsForService(service)
-	if err != nil {
-		return 0, err
-	}
+// referencedPeer returns the peer that hosts the addresses this app references from other apps.
+// Cross-app address sharing on a service requires the apps to be placed on the same peer broker
+func (reconciler *BrokerAppInstanceReconciler) referencedPeer(service *broker.BrokerService) (peer int32, pinned bool, err error) {
+	var pinnedBy string
+	for _, addrList := range appAddressRefs(reconciler.instance) {
+		for _, addressRef := range addrList {
+			if addressRef.AppNamespace == "" || addressRef.AppName == "" {
+				continue
+			}
 
-	// Sum up memory requests of all provisioned apps
-	var usedMemory int64 = 0
-	for _, app := range apps {
-		appMemory := app.Spec.Resources.Requests.Memory()
-		if appMemory != nil {
-			usedMemory += appMemory.Value()
+			referencedApp := &broker.BrokerApp{}
+			refKey := types.NamespacedName{
+				Namespace: addressRef.AppNamespace,
+				Name:      addressRef.AppName,
+			}
+			// missing or unbound references are reported by checkAddressRefCapacity
+			if getErr := reconciler.Client.Get(context.TODO(), refKey, referencedApp); getErr != nil {
+				continue
+			}
+			if referencedApp.Status.Service == nil || referencedApp.Status.Service.Key() != serviceKey(service) {
+				continue
+			}
+
+			if pinned && referencedApp.Status.Service.Peer != peer {
+				return peer, pinned, fmt.Errorf("referenced apps %s and %s are placed on different peers (%d and %d)",
+					pinnedBy, refKey.String(), peer, referencedApp.Status.Service.Peer)
+			}
+			peer = referencedApp.Status.Service.Peer
+			pinned = true
+			pinnedBy = refKey.String()
 		}
 	}
+	return peer, pinned, nil
+}
 
-	available := totalMemory - usedMemory
-	if available < 0 {
-		available = 0
+// checkPlacementPeer validates that the peer exists and hosts the addresses this app references
+func (reconciler *BrokerAppInstanceReconciler) checkPlacementPeer(service *broker.BrokerService, peer int32) error {
+	if peer < 0 || peer >= PeerCount(service) {
+		return fmt.Errorf("peer %d does not exist on service %s", peer, serviceKey(service))
 	}
-
-	return available, nil
+	pinnedPeer, pinned, err := reconciler.referencedPeer(service)
+	if err != nil {
+		return err
+	}
+	if pinned && pinnedPeer != peer {
+		return fmt.Errorf("referenced addresses are on peer %d (app is on peer %d)", pinnedPeer, peer)
+	}
+	return nil
 }
 
 // checkAddressClashOnService checks if this app's direct addresses conflict with
 // apps already provisioned on the given service
 func (reconciler *BrokerAppInstanceReconciler) checkAddressClashOnService(service *broker.BrokerService) error {
-	myDirectAddresses := collectOwnedAddresses(reconciler.instance)
+	myDirectAddresses := map[string]string{}
+	for address := range collectOwnedAddresses(reconciler.instance) {
+		myDirectAddresses[address] = reconciler.instance.Namespace + "/" + reconciler.instance.Name
+	}
+	// the addresses consumed with a link are declared on the service, on behalf of the app that shares them
+	for address, owner := range reconciler.linkedAddresses(serviceKey(service)) {
+		myDirectAddresses[address] = owner
+	}
 
 	// If this app doesn't use any direct addresses, no clash possible
 	if len(myDirectAddresses) == 0 {
@@ -837,15 +1639,51 @@ func (reconciler *BrokerAppInstanceReconciler) checkAddressClashOnService(servic
 
 	// Check each provisioned app for address conflicts
 	for _, otherApp := range apps {
-		otherAddresses := collectOwnedAddresses(&otherApp)
+		otherAddresses := serviceAddressOwners(&otherApp)
 
-		// Check for clashes
-		for myAddr := range myDirectAddresses {
-			if otherAddresses[myAddr] {
+		// Check for clashes, apps linked to the same owner share its address
+		for myAddr, myOwner := range myDirectAddresses {
+			if otherOwner, found := otherAddresses[myAddr]; found && otherOwner != myOwner {
 				return fmt.Errorf("address '%s' already declared by %s/%s (use addressRef to share addresses)",
 					myAddr, otherApp.Namespace, otherApp.Name)
 			}
 		}
+
+		// Wildcard addresses receive the messages of the addresses they match
+		if err := reconciler.checkWildcardClash(reconciler.instance, otherAddresses); err != nil {
+			return err
+		}
+		if err := reconciler.checkWildcardClash(&otherApp, myDirectAddresses); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkIdentityClashOnService checks if the client cert subject of this app would also
+// authenticate as an app already provisioned on the given service
+func (reconciler *BrokerAppInstanceReconciler) checkIdentityClashOnService(service *broker.BrokerService) error {
+	myDN := AppCertDN(reconciler.instance)
+
+	apps, listErr := reconciler.listOtherAppsForService(service)
+	if listErr != nil {
+		return fmt.Errorf("failed to list apps for identity clash detection: %v", listErr)
+	}
+
+	rejected := make(map[string]bool, len(service.Status.RejectedApps))
+	for _, r := range service.Status.RejectedApps {
+		rejected[r.Namespace+"/"+r.Name] = true
+	}
+
+	for _, otherApp := range apps {
+		if rejected[otherApp.Namespace+"/"+otherApp.Name] {
+			continue
+		}
+		if AppCertDN(&otherApp) == myDN {
+			return fmt.Errorf("client cert subject %s already used by %s/%s (set spec.clientCertSubject to a distinct subject)",
+				myDN, otherApp.Namespace, otherApp.Name)
+		}
 	}
 
 	return nil
@@ -864,17 +1702,19 @@ func (reconciler *BrokerAppInstanceReconciler) checkAddressClashOnService(servic
 //
 //	App A: sharedAddresses: ["orders"], capabilities.producerOf[{address: "orders"}] -> PUBLIC
 //	App B: capabilities.consumerOf[{address: "orders", appNamespace: "ns", appName: "A"}] -> ALLOWED
+//
+// A consumer placed on another service than the referenced app is linked to its broker, see processLinks.
+// A producer is placed on the service of the referenced app.
 func (reconciler *BrokerAppInstanceReconciler) checkAddressRefCapacity(service *broker.BrokerService) error {
 	ctx := context.Background()
 
 	for _, capability := range reconciler.instance.Spec.Capabilities {
 		// Check ProducerOf and ConsumerOf cross-app references
-		allAddresses := [][]broker.AddressRef{
-			capability.ProducerOf,
-			capability.ConsumerOf,
-		}
-
-		for _, addrList := range allAddresses {
+		for _, permission := range []string{AddressPermissionProduce, AddressPermissionConsume} {
+			addrList := capability.ConsumerOf
+			if permission == AddressPermissionProduce {
+				addrList = capability.ProducerOf
+			}
 			for _, addressRef := range addrList {
 				// Only check cross-app references (where appNamespace and appName are set)
 				if addressRef.AppNamespace == "" || addressRef.AppName == "" {
@@ -919,8 +1759,12 @@ func (reconciler *BrokerAppInstanceReconciler) checkAddressRefCapacity(service *
 						return fmt.Errorf("failed to lookup service %s for referenced app %s/%s: %v",
 							refServiceKey, addressRef.AppNamespace, addressRef.AppName, getErr)
 					}
-					return fmt.Errorf("referenced app %s/%s is provisioned on different service %s (this app would bind to: %s)",
-						addressRef.AppNamespace, addressRef.AppName, refServiceKey, thisServiceKey)
+					if permission == AddressPermissionProduce {
+						// only consumers are linked, a producer sends to the broker of the owner
+						return fmt.Errorf("referenced app %s/%s is provisioned on different service %s, producerOf is not linked across services (this app would bind to: %s)",
+							addressRef.AppNamespace, addressRef.AppName, refServiceKey, thisServiceKey)
+					}
+					// a consumer on another service is linked to the broker of the referenced app
 				}
 
 				// Extract base address from FQQN if present
@@ -942,6 +1786,11 @@ func (reconciler *BrokerAppInstanceReconciler) checkAddressRefCapacity(service *
 						addressRef.AppNamespace, addressRef.AppName, addressRef.Address)
 				}
 
+				// Check that the owner grants the permission to this app
+				if accessErr := sharedAddressAccess(reconciler.instance, referencedApp, addressRef.Address, permission); accessErr != nil {
+					return accessErr
+				}
+
 				// Check for routing type conflict
 				currentIsMulticast := isMulticastAddress(addressRef.PubSub, addressRef.Subscriptions)
 
@@ -964,7 +1813,7 @@ func (reconciler *BrokerAppInstanceReconciler) checkAddressRefCapacity(service *
 		}
 	}
 
-	return nil
+	return reconciler.checkVerbRefCapacity(service)
 }
 
 func (reconciler *BrokerAppInstanceReconciler) verifyCapabilityAddressType() (err error) {
@@ -1234,6 +2083,98 @@ func (reconciler *BrokerAppInstanceReconciler) validateAddressCapabilityConsiste
 	return nil
 }
 
+// collectPolicyAddresses returns the dead letter and expiry addresses of the addresses declared
+// in spec.addresses and spec.sharedAddresses, in declaration order and without duplicates
+func collectPolicyAddresses(app *broker.BrokerApp) []string {
+	var policyAddresses []string
+	seen := make(map[string]bool)
+	add := func(address *string) {
+		if address != nil && *address != "" && !seen[*address] {
+			seen[*address] = true
+			policyAddresses = append(policyAddresses, *address)
+		}
+	}
+
+	for _, addrTypes := range [][]broker.AddressType{app.Spec.Addresses, app.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			if addrType.Settings != nil {
+				add(addrType.Settings.DeadLetterAddress)
+				add(addrType.Settings.ExpiryAddress)
+			}
+		}
+	}
+	return policyAddresses
+}
+
+// validateAddressSettings ensures the dead letter and expiry addresses of declared addresses can be
+// declared as anycast addresses owned by this app
+func (reconciler *BrokerAppInstanceReconciler) validateAddressSettings() error {
+	// Collect the addresses this app uses with pubSub semantics
+	multicastAddresses := make(map[string]bool)
+	for _, addrTypes := range [][]broker.AddressType{reconciler.instance.Spec.Addresses, reconciler.instance.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			if isMulticastAddress(addrType.PubSub, addrType.Subscriptions) {
+				multicastAddresses[addrType.Address] = true
+			}
+		}
+	}
+	for _, capability := range reconciler.instance.Spec.Capabilities {
+		for _, addressRefs := range [][]broker.AddressRef{capability.ProducerOf, capability.ConsumerOf} {
+			for _, addressRef := range addressRefs {
+				if addressRef.AppNamespace == "" && addressRef.AppName == "" && isMulticastAddress(addressRef.PubSub, addressRef.Subscriptions) {
+					multicastAddresses[extractBaseAddress(addressRef.Address)] = true
+				}
+			}
+		}
+	}
+
+	for _, addrTypes := range [][]broker.AddressType{reconciler.instance.Spec.Addresses, reconciler.instance.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			settings := addrType.Settings
+			if settings == nil {
+				continue
+			}
+
+			policyAddresses := map[string]*string{
+				"deadLetterAddress": settings.DeadLetterAddress,
+				"expiryAddress":     settings.ExpiryAddress,
+			}
+			for _, field := range []string{"deadLetterAddress", "expiryAddress"} {
+				policyAddress := policyAddresses[field]
+				if policyAddress == nil {
+					continue
+				}
+				if *policyAddress == "" {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': settings.%s cannot be empty", addrType.Address, field)
+				}
+				if strings.Contains(*policyAddress, FQQNSeparator) {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': settings.%s should not use FQQN format (no '::')", addrType.Address, field)
+				}
+				if *policyAddress == addrType.Address {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': settings.%s cannot be the address itself", addrType.Address, field)
+				}
+				if multicastAddresses[*policyAddress] {
+					return NewValidationError(broker.ValidConditionAddressTypeError,
+						"address '%s': settings.%s '%s' is used with pubSub semantics, "+
+							"dead letter and expiry addresses are declared without pubSub semantics",
+						addrType.Address, field, *policyAddress)
+				}
+			}
+
+			if settings.RedeliveryDelay != nil && settings.MaxRedeliveryDelay != nil && *settings.MaxRedeliveryDelay < *settings.RedeliveryDelay {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': settings.maxRedeliveryDelay %d is less than settings.redeliveryDelay %d",
+					addrType.Address, *settings.MaxRedeliveryDelay, *settings.RedeliveryDelay)
+			}
+		}
+	}
+
+	return nil
+}
+
 // isAppRejectedByService checks if this app appears in the service's RejectedApps list
 func (reconciler *BrokerAppInstanceReconciler) isAppRejectedByService(service *broker.BrokerService) bool {
 	appKey := reconciler.instance.Namespace + "/" + reconciler.instance.Name
@@ -1252,8 +2193,20 @@ func (reconciler *BrokerAppInstanceReconciler) processStatus(reconcilerError err
 	// Set Deployed condition (only updated when validation passes)
 	reconciler.setDeployedCondition(reconcilerError)
 
+	// An unbound app is not linked
+	if reconciler.status.Service == nil {
+		reconciler.status.Links = nil
+	}
+
+	// Set Migrating condition (only while messages are moved from a previous binding)
+	reconciler.setMigratingCondition()
+
 	// Set Ready condition (always reflects current generation)
 	reconciler.setReadyCondition()
+	if meta.IsStatusConditionTrue(reconciler.status.Conditions, broker.ReadyConditionType) &&
+		!meta.IsStatusConditionTrue(reconciler.instance.Status.Conditions, broker.ReadyConditionType) {
+		operatormetrics.AppTimeToReady.Observe(time.Since(reconciler.instance.CreationTimestamp.Time).Seconds())
+	}
 
 	// Update status-level observedGeneration
 	reconciler.status.ObservedGeneration = reconciler.instance.Generation
@@ -1279,6 +2232,7 @@ func (reconciler *BrokerAppInstanceReconciler) setValidCondition(err error) {
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = validErr.ConditionReason()
 		condition.Message = validErr.Error()
+		reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonValidationFailed, "%s", validErr.Error())
 
 		// Add note if app is already deployed on previous generation
 		deployedCond := meta.FindStatusCondition(reconciler.status.Conditions, broker.DeployedConditionType)
@@ -1488,13 +2442,8 @@ func (r *BrokerAppReconciler) enqueueAppsForReferencedApp() handler.EventHandler
 
 // hasAddressRefTo checks if app has any addressRef pointing to targetApp
 func hasAddressRefTo(app *broker.BrokerApp, targetApp types.NamespacedName) bool {
-	for _, capability := range app.Spec.Capabilities {
-		for _, addressRef := range capability.ProducerOf {
-			if addressRef.AppNamespace == targetApp.Namespace && addressRef.AppName == targetApp.Name {
-				return true
-			}
-		}
-		for _, addressRef := range capability.ConsumerOf {
+	for _, addressRefs := range appAddressRefs(app) {
+		for _, addressRef := range addressRefs {
 			if addressRef.AppNamespace == targetApp.Namespace && addressRef.AppName == targetApp.Name {
 				return true
 			}
@@ -1504,12 +2453,15 @@ func hasAddressRefTo(app *broker.BrokerApp, targetApp types.NamespacedName) bool
 }
 
 func (r *BrokerAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = NewEventRecorder(mgr.GetEventRecorderFor("brokerapp-controller"))
+
 	// Note: Namespace informer is set up in main.go for CEL evaluation
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&broker.BrokerApp{}).
 		Owns(&corev1.Secret{}).
 		Watches(&broker.BrokerService{}, r.enqueueAppsForService()).
 		Watches(&broker.BrokerApp{}, r.enqueueAppsForReferencedApp()).
+		Watches(&broker.BrokerApp{}, r.enqueueLinkedApps()).
 		WithOptions(controller.Options{
 			// capacity allocation requires serial processing
 			MaxConcurrentReconciles: 1,
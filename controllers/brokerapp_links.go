@@ -0,0 +1,342 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"fmt"
+	"sort"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LinkCertSubject returns the subject of the client cert the brokers of the apps that share addresses with
+// the app present to its acceptor, distinct from the subject of the app
+func LinkCertSubject(app *broker.BrokerApp) pkix.Name {
+	subject := AppCertSubject(app)
+	subject.CommonName = subject.CommonName + ":link"
+	return subject
+}
+
+func linkRole(prefix string) string {
+	return fmt.Sprintf("%s-link", prefix)
+}
+
+// linkConnectorName returns the name of the connector from the broker of the owner to the acceptor of the consumer
+func linkConnectorName(owner *broker.BrokerApp, consumer *broker.BrokerApp) string {
+	return fmt.Sprintf("%s-link-%s", AppIdentity(owner), AppIdentity(consumer))
+}
+
+// linkBridgeName returns the name of the bridge that moves the messages of a queue of the owner to the consumer
+func linkBridgeName(owner *broker.BrokerApp, consumer *broker.BrokerApp, queue appQueue) string {
+	return fmt.Sprintf("%s-%s", linkConnectorName(owner, consumer), queue.fqqn())
+}
+
+// linkAddress returns the address of a link, address::queue for a subscription
+func linkAddress(queue appQueue) string {
+	if queue.multicast {
+		return queue.fqqn()
+	}
+	return queue.address
+}
+
+// consumerLinks returns the queues of the owner the consumer consumes with a link when placed on the service
+// with the key, none when the owner is on the same service or not bound. The addresses the owner does not
+// share, or does not grant consume on, are not linked
+func consumerLinks(consumer *broker.BrokerApp, owner *broker.BrokerApp, key string) []appQueue {
+	if owner.Status.Service == nil || owner.Status.Service.Key() == key {
+		return nil
+	}
+	var queues []appQueue
+	for _, capability := range consumer.Spec.Capabilities {
+		for _, addressRef := range capability.ConsumerOf {
+			if addressRef.AppNamespace != owner.Namespace || addressRef.AppName != owner.Name {
+				continue
+			}
+			shared := false
+			for _, sharedAddrType := range owner.Spec.SharedAddresses {
+				shared = shared || sharedAddrType.Address == addressRef.Address
+			}
+			if !shared || sharedAddressAccess(consumer, owner, addressRef.Address, AddressPermissionConsume) != nil {
+				continue
+			}
+			if isMulticastAddress(addressRef.PubSub, addressRef.Subscriptions) {
+				for _, subscription := range addressRef.Subscriptions {
+					queues = append(queues, appQueue{address: addressRef.Address, queue: subscription, multicast: true})
+				}
+			} else {
+				queues = append(queues, appQueue{address: addressRef.Address, queue: addressRef.Address})
+			}
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].fqqn() < queues[j].fqqn()
+	})
+	return queues
+}
+
+// referencedOwners returns the apps the app consumes shared addresses of, missing apps are reported by
+// checkAddressRefCapacity
+func (reconciler *BrokerAppInstanceReconciler) referencedOwners() []broker.BrokerApp {
+	seen := map[types.NamespacedName]bool{}
+	var owners []broker.BrokerApp
+	for _, capability := range reconciler.instance.Spec.Capabilities {
+		for _, addressRef := range capability.ConsumerOf {
+			if addressRef.AppNamespace == "" || addressRef.AppName == "" {
+				continue
+			}
+			key := types.NamespacedName{Namespace: addressRef.AppNamespace, Name: addressRef.AppName}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			owner := &broker.BrokerApp{}
+			if err := reconciler.Client.Get(context.TODO(), key, owner); err != nil {
+				continue
+			}
+			owners = append(owners, *owner)
+		}
+	}
+	return owners
+}
+
+// linkedAddresses returns the addresses the app consumes with a link when placed on the service with the key,
+// with the app that shares each as namespace/name
+func (reconciler *BrokerAppInstanceReconciler) linkedAddresses(key string) map[string]string {
+	addresses := map[string]string{}
+	for _, owner := range reconciler.referencedOwners() {
+		for _, queue := range consumerLinks(reconciler.instance, &owner, key) {
+			addresses[queue.address] = owner.Namespace + "/" + owner.Name
+		}
+	}
+	return addresses
+}
+
+// serviceAddressOwners returns the addresses an app declares on the broker of its service, each with the app
+// that owns it, the app itself or the app that shares an address it consumes with a link
+func serviceAddressOwners(app *broker.BrokerApp) map[string]string {
+	owners := map[string]string{}
+	for address := range collectOwnedAddresses(app) {
+		owners[address] = app.Namespace + "/" + app.Name
+	}
+	for _, link := range app.Status.Links {
+		if link.Direction == broker.LinkDirections.Inbound {
+			owners[extractBaseAddress(link.Address)] = link.App
+		}
+	}
+	return owners
+}
+
+// processLinks reports the links of the app that is bound to a service, inbound from the apps that share the
+// addresses it consumes and outbound to the apps that consume its shared addresses, when they are bound to
+// other services
+func (reconciler *BrokerAppInstanceReconciler) processLinks() error {
+	binding := reconciler.status.Service
+	if binding == nil {
+		reconciler.status.Links = nil
+		return nil
+	}
+
+	var links []broker.BrokerAppLinkStatus
+	for index, owners := 0, reconciler.referencedOwners(); index < len(owners); index++ {
+		owner := &owners[index]
+		for _, queue := range consumerLinks(reconciler.instance, owner, binding.Key()) {
+			links = append(links, broker.BrokerAppLinkStatus{
+				Address:   linkAddress(queue),
+				Direction: broker.LinkDirections.Inbound,
+				App:       owner.Namespace + "/" + owner.Name,
+				Service:   owner.Status.Service.Namespace + "/" + owner.Status.Service.Name,
+				Peer:      owner.Status.Service.Peer,
+				Port:      owner.Status.Service.AssignedPort,
+				Bridge:    linkBridgeName(owner, reconciler.instance, queue),
+			})
+		}
+	}
+
+	apps := &broker.BrokerAppList{}
+	if err := reconciler.Client.List(context.TODO(), apps); err != nil {
+		return NewTransientErrorWithCause(
+			broker.DeployedConditionCrudKindErrorReason,
+			"failed to list BrokerApps",
+			err)
+	}
+	// the consumers are linked to the current binding
+	owner := reconciler.instance.DeepCopy()
+	owner.Status.Service = binding
+	self := types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
+	for index := range apps.Items {
+		consumer := &apps.Items[index]
+		if (consumer.Namespace == self.Namespace && consumer.Name == self.Name) ||
+			consumer.Status.Service == nil || !hasAddressRefTo(consumer, self) {
+			continue
+		}
+		for _, queue := range consumerLinks(consumer, owner, consumer.Status.Service.Key()) {
+			links = append(links, broker.BrokerAppLinkStatus{
+				Address:   linkAddress(queue),
+				Direction: broker.LinkDirections.Outbound,
+				App:       consumer.Namespace + "/" + consumer.Name,
+				Service:   consumer.Status.Service.Namespace + "/" + consumer.Status.Service.Name,
+				Peer:      consumer.Status.Service.Peer,
+				Port:      consumer.Status.Service.AssignedPort,
+				Bridge:    linkBridgeName(owner, consumer, queue),
+			})
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Direction != links[j].Direction {
+			return links[i].Direction < links[j].Direction
+		}
+		if links[i].App != links[j].App {
+			return links[i].App < links[j].App
+		}
+		return links[i].Address < links[j].Address
+	})
+	reconciler.status.Links = links
+	return nil
+}
+
+// enqueueLinkedApps enqueues the apps that share the addresses the changed app consumes, the links they report
+// follow the binding of the app and are torn down with its references
+func (r *BrokerAppReconciler) enqueueLinkedApps() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		app := obj.(*broker.BrokerApp)
+
+		seen := map[types.NamespacedName]bool{}
+		var requests []reconcile.Request
+		enqueue := func(key types.NamespacedName) {
+			if !seen[key] {
+				seen[key] = true
+				requests = append(requests, reconcile.Request{NamespacedName: key})
+			}
+		}
+		for _, capability := range app.Spec.Capabilities {
+			for _, addressRef := range capability.ConsumerOf {
+				if addressRef.AppNamespace != "" && addressRef.AppName != "" {
+					enqueue(types.NamespacedName{Namespace: addressRef.AppNamespace, Name: addressRef.AppName})
+				}
+			}
+		}
+		for _, link := range app.Status.Links {
+			if namespace, name, found := strings.Cut(link.App, "/"); found && link.Direction == broker.LinkDirections.Inbound {
+				enqueue(types.NamespacedName{Namespace: namespace, Name: name})
+			}
+		}
+		return requests
+	})
+}
+
+// processLinkBridges moves the messages of the queues of the app consumed by apps bound to other services,
+// with a bridge per queue to the acceptor of the consumer. The bridges present a link cert of the consumer
+func (reconciler *BrokerServiceInstanceReconciler) processLinkBridges(desired *corev1.Secret, app *broker.BrokerApp) error {
+	var outbound []broker.BrokerAppLinkStatus
+	for _, link := range app.Status.Links {
+		if link.Direction == broker.LinkDirections.Outbound {
+			outbound = append(outbound, link)
+		}
+	}
+	if len(outbound) == 0 {
+		return nil
+	}
+
+	issuerSecret, err := common.GetOperatorIssuerSecret(reconciler.Client)
+	if err != nil {
+		// without a link cert the consumers on other services receive nothing
+		reconciler.log.V(1).Info("No operator issuer, shared addresses are not linked", "app", appName(app), "reason", err)
+		return nil
+	}
+	issuer, err := certutil.NewIssuerFromSecret(issuerSecret)
+	if err != nil {
+		return err
+	}
+
+	trustStorePath, err := reconciler.getTrustStorePath(reconciler.instance)
+	if err != nil {
+		return err
+	}
+
+	buf := NewPropsWithHeader()
+	fmt.Fprintln(buf, "# moves the messages of the shared addresses to the acceptors of the consumers on other services")
+	connectors := map[string]bool{}
+	for _, link := range outbound {
+		namespace, name, _ := strings.Cut(link.App, "/")
+		consumer := &broker.BrokerApp{}
+		if err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, consumer); err != nil {
+			// a consumer that is gone is unlinked by the reconcile of the app
+			reconciler.log.V(1).Info("Not linking to a missing consumer", "app", appName(app), "consumer", link.App, "reason", err)
+			err = nil
+			continue
+		}
+
+		// the link is verified against the consumer, the status of the app may be behind
+		target := consumer.Status.Service
+		if target == nil || target.Namespace+"/"+target.Name != link.Service || target.Peer != link.Peer || target.AssignedPort != link.Port {
+			continue
+		}
+		var queue *appQueue
+		for _, consumed := range consumerLinks(consumer, app, target.Key()) {
+			if linkBridgeName(app, consumer, consumed) == link.Bridge {
+				queue = &consumed
+				break
+			}
+		}
+		if queue == nil {
+			continue
+		}
+
+		connector := linkConnectorName(app, consumer)
+		if !connectors[connector] {
+			connectors[connector] = true
+			keyStorePath, certErr := reconciler.processConnectorCert(desired, issuer, connector, LinkCertSubject(consumer))
+			if certErr != nil {
+				return certErr
+			}
+			writeConnector(buf, connector, PeerName(target.Name, target.Peer), target.Namespace, target.AssignedPort, keyStorePath, trustStorePath)
+		}
+
+		if queue.multicast {
+			// the subscription of the consumer retains the messages on this broker while the link is down
+			fmt.Fprintf(buf, "addressConfigurations.\"%s\".queueConfigs.\"%s\".routingType=MULTICAST\n",
+				escapeForProperties(queue.address), escapeForProperties(queue.queue))
+			fmt.Fprintf(buf, "addressConfigurations.\"%s\".queueConfigs.\"%s\".address=%s\n",
+				escapeForProperties(queue.address), escapeForProperties(queue.queue), escapeForProperties(queue.address))
+		}
+		writeBridge(buf, link.Bridge, *queue, connector)
+	}
+	desired.Data[AppIdentityPrefixed(app, "links.properties")] = buf.Bytes()
+
+	return nil
+}
+
+// hasInboundLinks is true when the brokers of other services send messages to the acceptor of the app
+func hasInboundLinks(app *broker.BrokerApp) bool {
+	for _, link := range app.Status.Links {
+		if link.Direction == broker.LinkDirections.Inbound {
+			return true
+		}
+	}
+	return false
+}
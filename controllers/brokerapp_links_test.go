@@ -0,0 +1,251 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// linkedApps returns an owner of orders and events bound to the cordoned service east, and a consumer
+// of both that can only be placed on the service west
+func linkedApps(ns string) (east, west *v1beta2.BrokerService, owner, consumer *v1beta2.BrokerApp) {
+	east = NewBrokerService("east", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	west = NewBrokerService("west", ns).Build()
+	owner = NewBrokerApp("owner", ns).
+		WithSharedAddresses(
+			NewAddressType("orders").Build(),
+			NewAddressType("events").WithPubSub(true).Build(),
+		).
+		WithServiceBinding("east", ns, "owner-binding-secret", 61616).
+		Build()
+	consumer = NewBrokerApp("consumer", ns).
+		WithConsumerOf(
+			NewAddressRef("orders").WithAppRef(ns, owner.Name).Build(),
+			NewAddressRef("events").WithSubscriptions("audit").WithAppRef(ns, owner.Name).Build(),
+		).
+		Build()
+	return east, west, owner, consumer
+}
+
+func TestBrokerAppLinks_ConsumerOnAnotherService(t *testing.T) {
+	ns := "default"
+	east, west, owner, consumer := linkedApps(ns)
+	env := NewTestEnvironment(ns, east, west, owner, consumer)
+
+	_, updated := reconcileBrokerApp(t, env, consumer.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "west", updated.Status.Service.Name)
+	}
+	assert.Equal(t, []v1beta2.BrokerAppLinkStatus{
+		{
+			Address:   "events::audit",
+			Direction: v1beta2.LinkDirections.Inbound,
+			App:       "default/owner",
+			Service:   "default/east",
+			Port:      61616,
+			Bridge:    "default-owner-link-default-consumer-events::audit",
+		},
+		{
+			Address:   "orders",
+			Direction: v1beta2.LinkDirections.Inbound,
+			App:       "default/owner",
+			Service:   "default/east",
+			Port:      61616,
+			Bridge:    "default-owner-link-default-consumer-orders::orders",
+		},
+	}, updated.Status.Links)
+	if assert.NotNil(t, updated.Status.Placement) {
+		for _, candidate := range updated.Status.Placement.Candidates {
+			if candidate.Name == "west" {
+				assert.Equal(t, "capacity available on peer 0, 2 shared addresses linked from other services", candidate.Message)
+			}
+		}
+	}
+
+	// the owner reports the same links, outbound to the consumer
+	_, updatedOwner := reconcileBrokerApp(t, env, owner.Name)
+	if assert.Len(t, updatedOwner.Status.Links, 2) {
+		for _, link := range updatedOwner.Status.Links {
+			assert.Equal(t, v1beta2.LinkDirections.Outbound, link.Direction)
+			assert.Equal(t, "default/consumer", link.App)
+			assert.Equal(t, "default/west", link.Service)
+			assert.Equal(t, updated.Status.Service.AssignedPort, link.Port)
+		}
+		assert.Equal(t, "default-owner-link-default-consumer-events::audit", updatedOwner.Status.Links[0].Bridge)
+	}
+}
+
+func TestBrokerAppLinks_PreferOwnerService(t *testing.T) {
+	ns := "default"
+	east, west, owner, consumer := linkedApps(ns)
+	east.Spec.SchedulingPolicy = v1beta2.SchedulingPolicies.Schedulable
+	env := NewTestEnvironment(ns, east, west, owner, consumer)
+
+	// spread would prefer the empty service, co-locating with the owner needs no link
+	_, updated := reconcileBrokerApp(t, env, consumer.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "east", updated.Status.Service.Name)
+	}
+	assert.Empty(t, updated.Status.Links)
+}
+
+func TestBrokerAppLinks_ProducerOnAnotherService(t *testing.T) {
+	ns := "default"
+	east, west, owner, _ := linkedApps(ns)
+	producer := NewBrokerApp("producer", ns).
+		WithProducerOf(NewAddressRef("orders").WithAppRef(ns, owner.Name).Build()).
+		Build()
+	env := NewTestEnvironment(ns, east, west, owner, producer)
+
+	// producers are not linked, they are placed on the service of the owner
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: producer.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	if assert.NotNil(t, updated.Status.Placement) {
+		for _, candidate := range updated.Status.Placement.Candidates {
+			if candidate.Name == "west" {
+				assert.Equal(t, "addressRef", candidate.Category)
+				assert.Contains(t, candidate.Message, "is provisioned on different service default:east, producerOf is not linked across services")
+			}
+		}
+	}
+}
+
+func TestBrokerAppLinks_AddressClash(t *testing.T) {
+	ns := "default"
+	east, west, owner, consumer := linkedApps(ns)
+	local := NewBrokerApp("local", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("west", ns, "local-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, east, west, owner, local, consumer)
+
+	// the linked orders would be declared on west on behalf of the owner
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: consumer.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	if assert.NotNil(t, updated.Status.Placement) {
+		for _, candidate := range updated.Status.Placement.Candidates {
+			if candidate.Name == "west" {
+				assert.Equal(t, "addressClash", candidate.Category)
+				assert.Equal(t, "address 'orders' already declared by default/local (use addressRef to share addresses)", candidate.Message)
+			}
+		}
+	}
+}
+
+func TestBrokerServiceLinks_Bridges(t *testing.T) {
+	ns := "default"
+	oc := withOperatorCA(t, ns)
+	issuer := withOperatorIssuer(t, ns)
+	east, west, owner, consumer := linkedApps(ns)
+	env := NewTestEnvironment(ns, oc, issuer, east, west, owner, consumer)
+
+	_, updatedConsumer := reconcileBrokerApp(t, env, consumer.Name)
+	_, updatedOwner := reconcileBrokerApp(t, env, owner.Name)
+	port := updatedConsumer.Status.Service.AssignedPort
+
+	reconcileBrokerService(t, env, "east")
+
+	secret, err := mergedAppSecrets(env.Client, ns, "east")
+	assert.NoError(t, err)
+
+	// a bridge per queue moves the messages to the acceptor of the consumer
+	links := string(secret.Data[AppIdentityPrefixed(updatedOwner, "links.properties")])
+	connector := "default-owner-link-default-consumer"
+	assert.Contains(t, links, "connectorConfigurations.\""+connector+"\".params.host=west.default.svc.cluster.local\n")
+	assert.Contains(t, links, "connectorConfigurations.\""+connector+"\".params.port="+fmt.Sprint(port)+"\n")
+	assert.Contains(t, links, "connectorConfigurations.\""+connector+"\".params.keyStorePath=/amq/extra/secrets/"+
+		AppPropertiesSecretNameForApp("east", updatedOwner)+"/_"+connector+".pemcfg\n")
+	assert.Contains(t, links, "bridgeConfigurations.\""+connector+"-orders\\:\\:orders\".queueName=orders\n")
+	assert.Contains(t, links, "bridgeConfigurations.\""+connector+"-orders\\:\\:orders\".forwardingAddress=orders::orders\n")
+	assert.Contains(t, links, "bridgeConfigurations.\""+connector+"-events\\:\\:audit\".queueName=audit\n")
+	assert.Contains(t, links, "bridgeConfigurations.\""+connector+"-events\\:\\:audit\".staticConnectors="+connector+"\n")
+
+	// the subscription of the consumer retains the events on the broker of the owner
+	assert.Contains(t, links, "addressConfigurations.\"events\".queueConfigs.\"audit\".routingType=MULTICAST\n")
+
+	// with a link cert of the consumer
+	cert := parseCertPem(t, secret.Data["_"+connector+"-tls.crt"])
+	assert.Equal(t, LinkCertSubject(updatedConsumer).String(), cert.Subject.String())
+
+	// the acceptor of the consumer accepts the link cert with send on the linked addresses
+	reconcileBrokerService(t, env, "west")
+
+	current, err := mergedAppSecrets(env.Client, ns, "west")
+	assert.NoError(t, err)
+	assert.Contains(t, string(current.Data[UnderscoreAppIdentityPrefixed(updatedConsumer, common.GetCertUsersKey(jaasConfigRealmName(updatedConsumer)))]),
+		"default-consumer-link=/^CN=consumer:link,OU=default$/\n")
+	assert.Contains(t, string(current.Data[UnderscoreAppIdentityPrefixed(updatedConsumer, common.GetCertRolesKey(jaasConfigRealmName(updatedConsumer)))]),
+		"default-consumer-link=default-consumer-link\n")
+	capabilities := string(current.Data[AppIdentityPrefixed(updatedConsumer, "capabilities.properties")])
+	assert.Contains(t, capabilities, "addressConfigurations.\"orders\".routingTypes=ANYCAST\n")
+	assert.Contains(t, capabilities, "addressConfigurations.\"events\".routingTypes=MULTICAST\n")
+	assert.Contains(t, capabilities, "securityRoles.\"orders\".\"default-consumer-link\".send=true\n")
+	assert.Contains(t, capabilities, "securityRoles.\"events\".\"default-consumer-link\".send=true\n")
+	assert.Contains(t, capabilities, "addressConfigurations.\"events\".queueConfigs.\"audit\".routingType=MULTICAST\n")
+}
+
+func TestBrokerServiceLinks_TornDown(t *testing.T) {
+	ns := "default"
+	oc := withOperatorCA(t, ns)
+	issuer := withOperatorIssuer(t, ns)
+	east, west, owner, consumer := linkedApps(ns)
+	env := NewTestEnvironment(ns, oc, issuer, east, west, owner, consumer)
+
+	reconcileBrokerApp(t, env, consumer.Name)
+	reconcileBrokerApp(t, env, owner.Name)
+	reconcileBrokerService(t, env, "east")
+
+	secret, err := mergedAppSecrets(env.Client, ns, "east")
+	assert.NoError(t, err)
+	assert.Contains(t, secret.Data, AppIdentityPrefixed(owner, "links.properties"))
+
+	// the consumer no longer references the shared addresses
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: consumer.Name, Namespace: ns}, updated))
+	updated.Spec.Capabilities = nil
+	assert.NoError(t, env.Client.Update(context.TODO(), updated))
+
+	_, updated = reconcileBrokerApp(t, env, consumer.Name)
+	assert.Empty(t, updated.Status.Links)
+	_, updatedOwner := reconcileBrokerApp(t, env, owner.Name)
+	assert.Empty(t, updatedOwner.Status.Links)
+
+	reconcileBrokerService(t, env, "east")
+
+	secret, err = mergedAppSecrets(env.Client, ns, "east")
+	assert.NoError(t, err)
+	assert.NotContains(t, secret.Data, AppIdentityPrefixed(owner, "links.properties"))
+	assert.NotContains(t, secret.Data, "_default-owner-link-default-consumer-tls.crt")
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	operatormetrics "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestRejectionCategoryString(t *testing.T) {
+	assert.Equal(t, "capacity", RejectionCapacity.String())
+	assert.Equal(t, "cordoned", RejectionCordoned.String())
+	assert.Equal(t, "other", RejectionCategory(100).String())
+}
+
+func TestBrokerAppMetrics_Binds(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).Build()
+	app := NewBrokerApp("fresh", ns).WithAddresses(NewAddressType("fresh").Build()).Build()
+	env := NewTestEnvironment(ns, service, app)
+
+	binds := testutil.ToFloat64(operatormetrics.AppBinds.WithLabelValues(BindingReasonInitial))
+	reconciles := testutil.CollectAndCount(operatormetrics.ReconcileDuration)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	assert.NotNil(t, updated.Status.Service)
+	assert.Equal(t, binds+1, testutil.ToFloat64(operatormetrics.AppBinds.WithLabelValues(BindingReasonInitial)))
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(operatormetrics.ReconcileDuration), reconciles)
+
+	// already bound, nothing counted
+	reconcileBrokerApp(t, env, app.Name)
+	assert.Equal(t, binds+1, testutil.ToFloat64(operatormetrics.AppBinds.WithLabelValues(BindingReasonInitial)))
+}
+
+func TestBrokerAppMetrics_DrainingRebind(t *testing.T) {
+	ns := "default"
+
+	draining := NewBrokerService("draining", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Draining).Build()
+	open := NewBrokerService("open", ns).Build()
+	app := NewBrokerApp("bound", ns).
+		WithAddresses(NewAddressType("bound").Build()).
+		WithServiceBinding("draining", ns, "bound-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, draining, open, app)
+
+	rebinds := testutil.ToFloat64(operatormetrics.AppRebinds.WithLabelValues(BindingReasonDraining))
+	cordoned := testutil.ToFloat64(operatormetrics.AppPlacementRejections.WithLabelValues(RejectionCordoned.String()))
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "open", updated.Status.Service.Name)
+	}
+	assert.Equal(t, rebinds+1, testutil.ToFloat64(operatormetrics.AppRebinds.WithLabelValues(BindingReasonDraining)))
+	assert.Equal(t, cordoned+1, testutil.ToFloat64(operatormetrics.AppPlacementRejections.WithLabelValues(RejectionCordoned.String())))
+}
+
+func TestBrokerAppMetrics_ReconcileErrors(t *testing.T) {
+	ns := "default"
+
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	app := NewBrokerApp("fresh", ns).WithAddresses(NewAddressType("fresh").Build()).Build()
+	env := NewTestEnvironment(ns, cordoned, app)
+
+	errors := testutil.ToFloat64(operatormetrics.ReconcileErrors.WithLabelValues("brokerapp"))
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	assert.Equal(t, errors+1, testutil.ToFloat64(operatormetrics.ReconcileErrors.WithLabelValues("brokerapp")))
+}
+
+func TestBrokerAppMetrics_RejectionsCountedOncePerDecision(t *testing.T) {
+	ns := "default"
+
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	app := NewBrokerApp("waiting", ns).WithAddresses(NewAddressType("waiting").Build()).Build()
+	env := NewTestEnvironment(ns, cordoned, app)
+
+	rejections := testutil.ToFloat64(operatormetrics.AppPlacementRejections.WithLabelValues(RejectionCordoned.String()))
+
+	// the same decision is made on every reconcile while the app waits for a service
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	for attempt := 0; attempt < 3; attempt++ {
+		_, err := env.Reconciler.Reconcile(context.TODO(), req)
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, rejections+1, testutil.ToFloat64(operatormetrics.AppPlacementRejections.WithLabelValues(RejectionCordoned.String())))
+}
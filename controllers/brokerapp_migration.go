@@ -0,0 +1,403 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/environments"
+	mgmt "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/artemis"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MigrationPollInterval is how often the messages pending on the previous binding of a migrating app are counted
+const MigrationPollInterval = 10 * time.Second
+
+// appQueue is a queue of the app on the broker
+type appQueue struct {
+	address   string
+	queue     string
+	multicast bool
+}
+
+func (q appQueue) fqqn() string {
+	return q.address + FQQNSeparator + q.queue
+}
+
+func (q appQueue) routingType() string {
+	if q.multicast {
+		return "MULTICAST"
+	}
+	return "ANYCAST"
+}
+
+// appMigrationQueues returns the queues the app declares on the broker, ordered by fully qualified name.
+// The anycast queues of addresses referenced from other apps move with their owner
+func appMigrationQueues(app *broker.BrokerApp) []appQueue {
+	tracker, err := newAppAddressTracker(app)
+	if err != nil {
+		// inconsistent addresses are reported by validation
+		return nil
+	}
+	var queues []appQueue
+	for name, entry := range tracker.names {
+		if fqqn := strings.SplitN(name, FQQNSeparator, 2); len(fqqn) > 1 {
+			queues = append(queues, appQueue{address: fqqn[0], queue: fqqn[1], multicast: true})
+		} else if entry.isOwned && !entry.isMulticast {
+			queues = append(queues, appQueue{address: name, queue: name})
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].fqqn() < queues[j].fqqn()
+	})
+	return queues
+}
+
+// AppServiceBindingKeys returns the field indexer keys of the services an app is provisioned on,
+// the service of its binding and, while migrating, the service of its previous binding
+func AppServiceBindingKeys(app *broker.BrokerApp) []string {
+	var keys []string
+	if app.Status.Service != nil {
+		keys = append(keys, app.Status.Service.Key())
+	}
+	if migration := app.Status.Migration; migration != nil && (len(keys) == 0 || keys[0] != migration.From.Key()) {
+		keys = append(keys, migration.From.Key())
+	}
+	return keys
+}
+
+// migratingFrom returns the previous binding of an app that is migrating from the service
+func migratingFrom(app *broker.BrokerApp, serviceKey string) *broker.BrokerServiceBindingStatus {
+	migration := app.Status.Migration
+	if migration == nil || migration.From.Key() != serviceKey {
+		return nil
+	}
+	if current := app.Status.Service; current != nil && current.Key() == serviceKey && current.Peer == migration.From.Peer {
+		// the messages are on the broker of the current binding
+		return nil
+	}
+	return &migration.From
+}
+
+// withBinding returns a copy of the app as provisioned by a previous binding
+func withBinding(app *broker.BrokerApp, binding *broker.BrokerServiceBindingStatus) *broker.BrokerApp {
+	view := app.DeepCopy()
+	view.Status.Service = binding.DeepCopy()
+	view.Status.Migration = nil
+	view.Status.Links = nil
+	return view
+}
+
+// MigrationCertSubject returns the subject of the client cert the previous binding of a migrating app
+// presents to the acceptor of the current binding, distinct from the subject of the app
+func MigrationCertSubject(app *broker.BrokerApp) pkix.Name {
+	subject := AppCertSubject(app)
+	subject.CommonName = subject.CommonName + ":migration"
+	return subject
+}
+
+func migrationRole(prefix string) string {
+	return fmt.Sprintf("%s-migration", prefix)
+}
+
+// startMigration retains the previous binding until the messages on its queues are moved to the current binding
+func (reconciler *BrokerAppInstanceReconciler) startMigration(previous *broker.BrokerServiceBindingStatus, reason string) {
+	current := reconciler.status.Service
+
+	if migration := reconciler.status.Migration; migration != nil {
+		if migration.From.Key() == current.Key() && migration.From.Peer == current.Peer {
+			// back on the broker that holds the messages
+			reconciler.status.Migration = nil
+		}
+		// otherwise the messages of the previous binding are moved to the current binding
+		return
+	}
+
+	if previous.Key() == current.Key() && previous.Peer == current.Peer {
+		// the messages remain on the same broker
+		return
+	}
+
+	if len(appMigrationQueues(reconciler.instance)) == 0 {
+		return
+	}
+
+	previousService := &broker.BrokerService{}
+	if err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: previous.Namespace, Name: previous.Name}, previousService); errors.IsNotFound(err) {
+		// the brokers of the previous service and their messages are gone
+		return
+	}
+
+	reconciler.log.V(1).Info("Migrating messages from the previous binding",
+		"app", reconciler.instance.Name,
+		"from", previous.Key(),
+		"peer", previous.Peer,
+		"reason", reason)
+	reconciler.status.Migration = &broker.BrokerAppMigrationStatus{
+		From:      *previous,
+		Reason:    reason,
+		StartTime: metav1.Now(),
+	}
+}
+
+// processMigration counts the messages pending on the previous binding, the previous binding
+// is retired once its queues are drained
+func (reconciler *BrokerAppInstanceReconciler) processMigration() {
+	migration := reconciler.status.Migration
+	if migration == nil {
+		return
+	}
+
+	pending, err := reconciler.pendingMigrationMessages(&migration.From)
+	if err != nil {
+		reconciler.log.V(1).Info("Unable to count the messages pending migration",
+			"app", reconciler.instance.Name,
+			"from", migration.From.Key(),
+			"error", err)
+		reconciler.migrationErr = err
+	} else if pending == 0 {
+		reconciler.log.V(1).Info("Migration complete, retiring the previous binding",
+			"app", reconciler.instance.Name,
+			"from", migration.From.Key(),
+			"peer", migration.From.Peer)
+		reconciler.status.Migration = nil
+		return
+	} else {
+		migration.PendingMessages = &pending
+	}
+
+	reconciler.requeueWithin(MigrationPollInterval)
+}
+
+// queueMessageCount returns the number of messages on a queue of a broker
+var queueMessageCount = func(client client.Client, brokerName string, host string, queue appQueue) (int64, error) {
+	return mgmt.GetArtemisAgentForRestricted(client, brokerName, host).GetQueueMessageCount(queue.address, queue.queue, queue.routingType())
+}
+
+// pendingMigrationMessages returns the number of messages on the queues of the previous binding.
+// With ha, the queues are counted on the live broker of the peer
+func (reconciler *BrokerAppInstanceReconciler) pendingMigrationMessages(from *broker.BrokerServiceBindingStatus) (int64, error) {
+	service := &broker.BrokerService{}
+	if err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: from.Namespace, Name: from.Name}, service); err != nil {
+		if errors.IsNotFound(err) {
+			// the brokers of the previous service and their messages are gone
+			return 0, nil
+		}
+		return 0, err
+	}
+	if from.Peer < 0 || from.Peer >= PeerCount(service) {
+		// the peer and its messages are gone
+		return 0, nil
+	}
+
+	queues := appMigrationQueues(reconciler.instance)
+	var lastErr error
+	for _, brokerName := range PeerBrokerNames(service, from.Peer) {
+		mbeanBrokerName := environments.ResolveBrokerNameFromEnvs(service.Spec.Env, brokerName)
+		host := common.OrdinalFQDNS(brokerName, service.Namespace, 0)
+
+		var pending int64
+		deployed := false
+		var countErr error
+		for _, queue := range queues {
+			count, err := queueMessageCount(reconciler.Client, mbeanBrokerName, host, queue)
+			if err != nil {
+				if err.Error() == mgmt.QUEUE_NOT_EXISTS {
+					continue
+				}
+				countErr = err
+				break
+			}
+			deployed = true
+			pending += count
+		}
+		if countErr != nil {
+			lastErr = countErr
+			continue
+		}
+		if deployed {
+			return pending, nil
+		}
+	}
+	// no queue is deployed on a reachable broker, the queues are gone unless a broker was unreachable
+	return 0, lastErr
+}
+
+// setMigratingCondition reports the progress of a migration, the condition is removed once complete
+func (reconciler *BrokerAppInstanceReconciler) setMigratingCondition() {
+	migration := reconciler.status.Migration
+	if migration == nil {
+		meta.RemoveStatusCondition(&reconciler.status.Conditions, broker.MigratingConditionType)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               broker.MigratingConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             broker.MigratingConditionMessagesPendingReason,
+		ObservedGeneration: reconciler.instance.Generation,
+	}
+	from := fmt.Sprintf("%s/%s peer %d", migration.From.Namespace, migration.From.Name, migration.From.Peer)
+	switch {
+	case reconciler.migrationErr != nil:
+		condition.Reason = broker.MigratingConditionUnknownReason
+		condition.Message = fmt.Sprintf("moving messages from %s, unable to count pending messages: %v", from, reconciler.migrationErr)
+	case reconciler.migrationIssuerMissing():
+		// the previous binding has no bridge to the current binding without a migration cert
+		condition.Reason = broker.MigratingConditionNoIssuerReason
+		condition.Message = fmt.Sprintf("no operator issuer, waiting for consumers to drain the previous binding %s", from)
+		reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonMigrationWaiting, "%s", condition.Message)
+	case reconciler.rebindDeferred != "":
+		condition.Reason = broker.MigratingConditionRebindDeferredReason
+		condition.Message = fmt.Sprintf("moving messages from %s, rebind deferred until complete: %s", from, reconciler.rebindDeferred)
+	case migration.PendingMessages != nil:
+		condition.Message = fmt.Sprintf("moving %d pending messages from %s", *migration.PendingMessages, from)
+	default:
+		condition.Message = fmt.Sprintf("moving messages from %s", from)
+	}
+	meta.SetStatusCondition(&reconciler.status.Conditions, condition)
+}
+
+// migrationIssuerMissing is true when the previous binding of a placed app can't move its messages to the
+// current binding, the migration cert is issued by the operator issuer
+func (reconciler *BrokerAppInstanceReconciler) migrationIssuerMissing() bool {
+	target := reconciler.status.Service
+	if target == nil || target.AssignedPort == UnassignedPort {
+		return false
+	}
+	_, err := common.GetOperatorIssuerSecret(reconciler.Client)
+	return err != nil
+}
+
+// processMigrationSource retains the acceptor and queues of the previous binding of a migrating app,
+// with a bridge per queue that moves its messages to the acceptor of the current binding
+func (reconciler *BrokerServiceInstanceReconciler) processMigrationSource(desired *corev1.Secret, app *broker.BrokerApp, from *broker.BrokerServiceBindingStatus) error {
+	source := withBinding(app, from)
+	if err := reconciler.processCapabilities(desired, source); err != nil {
+		return err
+	}
+	if err := reconciler.processAcceptor(desired, source); err != nil {
+		return err
+	}
+
+	target := app.Status.Service
+	if target == nil || target.AssignedPort == UnassignedPort {
+		// not placed, the messages are retained until the app is bound
+		return nil
+	}
+
+	issuerSecret, err := common.GetOperatorIssuerSecret(reconciler.Client)
+	if err != nil {
+		// without a migration cert the messages drain through the consumers of the previous binding
+		reconciler.log.V(1).Info("No operator issuer, messages are not moved", "app", appName(app), "reason", err)
+		return nil
+	}
+	issuer, err := certutil.NewIssuerFromSecret(issuerSecret)
+	if err != nil {
+		return err
+	}
+
+	connector := migrationRole(AppIdentity(app))
+	keyStorePath, err := reconciler.processConnectorCert(desired, issuer, connector, MigrationCertSubject(app))
+	if err != nil {
+		return err
+	}
+
+	trustStorePath, err := reconciler.getTrustStorePath(reconciler.instance)
+	if err != nil {
+		return err
+	}
+
+	buf := NewPropsWithHeader()
+	fmt.Fprintln(buf, "# moves the messages to the acceptor of the current binding")
+	writeConnector(buf, connector, PeerName(target.Name, target.Peer), target.Namespace, target.AssignedPort, keyStorePath, trustStorePath)
+
+	for _, queue := range appMigrationQueues(app) {
+		writeBridge(buf, fmt.Sprintf("%s-%s", connector, queue.fqqn()), queue, connector)
+	}
+	desired.Data[AppIdentityPrefixed(source, "migration.properties")] = buf.Bytes()
+
+	return nil
+}
+
+// processConnectorCert issues the client cert a connector presents into the secret and returns the path
+// of its key store, the cert is retained until its renewal time, or until the issuer changes
+func (reconciler *BrokerServiceInstanceReconciler) processConnectorCert(desired *corev1.Secret, issuer *certutil.Issuer, connector string, subject pkix.Name) (string, error) {
+	certKey := fmt.Sprintf("_%s-tls.crt", connector)
+	keyKey := fmt.Sprintf("_%s-tls.key", connector)
+	var certPem, keyPem []byte
+	if obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Secret{}), desired.Name); obj != nil {
+		deployed := obj.(*corev1.Secret)
+		certPem, keyPem = deployed.Data[certKey], deployed.Data[keyKey]
+	}
+	now := time.Now()
+	if cert := issuer.IssuedClientCert(certPem, keyPem, subject); cert == nil || !now.Before(cert.NotAfter.Add(-ClientCertRenewBefore)) {
+		var err error
+		if certPem, keyPem, err = issuer.IssueClientCert(subject, now, ClientCertDuration); err != nil {
+			return "", err
+		}
+	}
+	desired.Data[certKey] = certPem
+	desired.Data[keyKey] = keyPem
+
+	pemCfgKey := fmt.Sprintf("_%s.pemcfg", connector)
+	pemCfg := NewPropsWithHeader()
+	fmt.Fprintf(pemCfg, "source.key=%s%s/%s\n", common.SecretPathBase, desired.Name, keyKey)
+	fmt.Fprintf(pemCfg, "source.cert=%s%s/%s\n", common.SecretPathBase, desired.Name, certKey)
+	desired.Data[pemCfgKey] = pemCfg.Bytes()
+
+	return fmt.Sprintf("%s%s/%s", common.SecretPathBase, desired.Name, pemCfgKey), nil
+}
+
+// writeConnector writes a tls connector to the acceptor of an app on a peer
+func writeConnector(buf *bytes.Buffer, connector string, peerName string, namespace string, port int32, keyStorePath string, trustStorePath string) {
+	host := fmt.Sprintf("%s.%s.svc.%s", peerName, namespace, common.GetClusterDomain())
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".factoryClassName=org.apache.activemq.artemis.core.remoting.impl.netty.NettyConnectorFactory\n", connector)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.host=%s\n", connector, host)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.port=%d\n", connector, port)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.sslEnabled=true\n", connector)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.keyStoreType=PEMCFG\n", connector)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.keyStorePath=%s\n", connector, keyStorePath)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.trustStoreType=PEMCA\n", connector)
+	fmt.Fprintf(buf, "connectorConfigurations.\"%s\".params.trustStorePath=%s\n", connector, trustStorePath)
+}
+
+// writeBridge writes a bridge that moves the messages of a queue to the connector.
+// Subscription queues are sent to their fully qualified name to avoid a fan out to the other subscriptions
+func writeBridge(buf *bytes.Buffer, name string, queue appQueue, connector string) {
+	bridge := escapeForProperties(name)
+	fmt.Fprintf(buf, "bridgeConfigurations.\"%s\".queueName=%s\n", bridge, queue.queue)
+	fmt.Fprintf(buf, "bridgeConfigurations.\"%s\".forwardingAddress=%s\n", bridge, queue.fqqn())
+	fmt.Fprintf(buf, "bridgeConfigurations.\"%s\".staticConnectors=%s\n", bridge, connector)
+	fmt.Fprintf(buf, "bridgeConfigurations.\"%s\".reconnectAttempts=-1\n", bridge)
+	fmt.Fprintf(buf, "bridgeConfigurations.\"%s\".useDuplicateDetection=true\n", bridge)
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	mgmt "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/artemis"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// withQueueMessageCounts replaces the jolokia queue count with fixed counts by fully qualified queue name
+func withQueueMessageCounts(t *testing.T, counts map[string]int64, err error) *[]string {
+	original := queueMessageCount
+	t.Cleanup(func() { queueMessageCount = original })
+
+	hosts := []string{}
+	queueMessageCount = func(_ client.Client, _ string, host string, queue appQueue) (int64, error) {
+		hosts = append(hosts, host)
+		if err != nil {
+			return 0, err
+		}
+		count, found := counts[queue.fqqn()]
+		if !found {
+			return 0, errors.New(mgmt.QUEUE_NOT_EXISTS)
+		}
+		return count, nil
+	}
+	return &hosts
+}
+
+func reconcileBrokerApp(t *testing.T, env *TestEnvironment, name string) (ctrl.Result, *v1beta2.BrokerApp) {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: env.Namespace}}
+	result, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	return result, updated
+}
+
+func TestAppMigrationQueues(t *testing.T) {
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(NewAddressType("orders").Build()).
+		WithSharedAddresses(NewAddressType("events").WithSubscriptions("audit").Build()).
+		WithConsumerOf(
+			NewAddressRef("invoices").WithAppRef("test", "billing").Build(),
+			NewAddressRef("payments").WithSubscriptions("ledger").WithAppRef("test", "billing").Build(),
+		).
+		Build()
+
+	// invoices is a queue of billing, the ledger subscription is declared by this app
+	assert.Equal(t, []appQueue{
+		{address: "events", queue: "audit", multicast: true},
+		{address: "orders", queue: "orders"},
+		{address: "payments", queue: "ledger", multicast: true},
+	}, appMigrationQueues(app))
+}
+
+func TestBrokerAppMigration_Rebind(t *testing.T) {
+	ns := "default"
+
+	old := NewBrokerService("old", ns).WithLabels(map[string]string{"tier": "old"}).Build()
+	replacement := NewBrokerService("new", ns).WithLabels(map[string]string{"tier": "new"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tier": "new"}}).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("old", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, withOperatorIssuer(t, ns), old, replacement, app)
+
+	hosts := withQueueMessageCounts(t, map[string]int64{"orders::orders": 5}, nil)
+
+	result, updated := reconcileBrokerApp(t, env, app.Name)
+
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "new", updated.Status.Service.Name)
+	}
+	if assert.NotNil(t, updated.Status.Migration) {
+		assert.Equal(t, "old", updated.Status.Migration.From.Name)
+		assert.Equal(t, int32(61616), updated.Status.Migration.From.AssignedPort)
+		assert.Equal(t, "the service no longer matches spec.serviceSelector", updated.Status.Migration.Reason)
+		assert.Equal(t, int64(5), *updated.Status.Migration.PendingMessages)
+	}
+	migrating := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.MigratingConditionType)
+	if assert.NotNil(t, migrating) {
+		assert.Equal(t, metav1.ConditionTrue, migrating.Status)
+		assert.Equal(t, v1beta2.MigratingConditionMessagesPendingReason, migrating.Reason)
+		assert.Equal(t, "moving 5 pending messages from default/old peer 0", migrating.Message)
+	}
+	assert.Contains(t, *hosts, "old-ss-0.old-hdls-svc.default.svc.cluster.local")
+	assert.LessOrEqual(t, result.RequeueAfter, MigrationPollInterval)
+
+	// the previous binding is retired once drained
+	withQueueMessageCounts(t, map[string]int64{"orders::orders": 0}, nil)
+
+	_, updated = reconcileBrokerApp(t, env, app.Name)
+
+	assert.Nil(t, updated.Status.Migration)
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, v1beta2.MigratingConditionType))
+	assert.Equal(t, "new", updated.Status.Service.Name)
+}
+
+func TestBrokerAppMigration_NoQueues(t *testing.T) {
+	ns := "default"
+
+	old := NewBrokerService("old", ns).WithLabels(map[string]string{"tier": "old"}).Build()
+	replacement := NewBrokerService("new", ns).WithLabels(map[string]string{"tier": "new"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tier": "new"}}).
+		WithServiceBinding("old", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, old, replacement, app)
+
+	withQueueMessageCounts(t, nil, errors.New("unexpected count"))
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	assert.Equal(t, "new", updated.Status.Service.Name)
+	assert.Nil(t, updated.Status.Migration)
+}
+
+func TestBrokerAppMigration_NoIssuer(t *testing.T) {
+	ns := "default"
+
+	old := NewBrokerService("old", ns).WithLabels(map[string]string{"tier": "old"}).Build()
+	replacement := NewBrokerService("new", ns).WithLabels(map[string]string{"tier": "new"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tier": "new"}}).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("old", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, old, replacement, app)
+	events := record.NewFakeRecorder(10)
+	env.Reconciler.recorder = NewEventRecorder(events)
+
+	withQueueMessageCounts(t, map[string]int64{"orders::orders": 5}, nil)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// without a migration cert the messages stay on the previous binding until consumed
+	assert.Equal(t, "new", updated.Status.Service.Name)
+	assert.NotNil(t, updated.Status.Migration)
+	migrating := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.MigratingConditionType)
+	if assert.NotNil(t, migrating) {
+		assert.Equal(t, metav1.ConditionTrue, migrating.Status)
+		assert.Equal(t, v1beta2.MigratingConditionNoIssuerReason, migrating.Reason)
+		assert.Equal(t, "no operator issuer, waiting for consumers to drain the previous binding default/old peer 0", migrating.Message)
+	}
+	assert.Contains(t, recordedEvents(events), "Warning MigrationWaiting no operator issuer, waiting for consumers to drain the previous binding default/old peer 0")
+}
+
+func TestBrokerAppMigration_PendingUnknown(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("new", ns).Build()
+	old := NewBrokerService("old", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("new", ns, "orders-binding-secret", 61616).
+		WithMigrationFrom("old", ns, 61620, 0).
+		Build()
+	env := NewTestEnvironment(ns, service, old, app)
+
+	withQueueMessageCounts(t, nil, errors.New("connection refused"))
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the previous binding is retained until its queues can be counted
+	assert.NotNil(t, updated.Status.Migration)
+	migrating := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.MigratingConditionType)
+	if assert.NotNil(t, migrating) {
+		assert.Equal(t, v1beta2.MigratingConditionUnknownReason, migrating.Reason)
+		assert.Contains(t, migrating.Message, "connection refused")
+	}
+}
+
+func TestBrokerAppMigration_RebindDeferred(t *testing.T) {
+	ns := "default"
+
+	current := NewBrokerService("current", ns).WithLabels(map[string]string{"tier": "old"}).Build()
+	other := NewBrokerService("other", ns).WithLabels(map[string]string{"tier": "new"}).Build()
+	old := NewBrokerService("old", ns).WithLabels(map[string]string{"tier": "old"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"tier": "new"}}).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("current", ns, "orders-binding-secret", 61616).
+		WithMigrationFrom("old", ns, 61616, 0).
+		Build()
+	env := NewTestEnvironment(ns, withOperatorIssuer(t, ns), current, other, old, app)
+
+	withQueueMessageCounts(t, map[string]int64{"orders::orders": 3}, nil)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the messages already moved stay with the current binding until the migration completes
+	assert.Equal(t, "current", updated.Status.Service.Name)
+	assert.Equal(t, "old", updated.Status.Migration.From.Name)
+	migrating := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.MigratingConditionType)
+	if assert.NotNil(t, migrating) {
+		assert.Equal(t, v1beta2.MigratingConditionRebindDeferredReason, migrating.Reason)
+		assert.Contains(t, migrating.Message, "the service no longer matches spec.serviceSelector")
+	}
+}
+
+func TestBrokerAppMigration_PortsHeldBySource(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("my-broker", ns).WithPortRange(61616, 61617).Build()
+	migrating := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("elsewhere", ns, "orders-binding-secret", 61616).
+		WithMigrationFrom("my-broker", ns, 61616, 0).
+		Build()
+	app := NewBrokerApp("billing", ns).Build()
+	env := NewTestEnvironment(ns, service, migrating, app)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the port of the previous binding is held until the migration completes
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, int32(61617), updated.Status.Service.AssignedPort)
+	}
+}
+
+func TestBrokerServiceMigration_Bridges(t *testing.T) {
+	ns := "default"
+
+	oc := withOperatorCA(t, ns)
+	issuer := withOperatorIssuer(t, ns)
+	old := NewBrokerService("old", ns).Build()
+	replacement := NewBrokerService("new", ns).WithPeers(2).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithSharedAddresses(NewAddressType("events").WithSubscriptions("audit").Build()).
+		WithProducerOf(NewAddressRef("orders").Build()).
+		WithServiceBinding("new", ns, "orders-binding-secret", 61700).
+		WithServicePeer(1).
+		WithMigrationFrom("old", ns, 61616, 0).
+		Build()
+	env := NewTestEnvironment(ns, oc, issuer, old, replacement, app)
+
+	reconcileBrokerService(t, env, "old")
+
+	secret, err := mergedAppSecrets(env.Client, ns, "old")
+	assert.NoError(t, err)
+
+	// the acceptor and queues of the previous binding are retained
+	assert.Contains(t, string(secret.Data[AppIdentityPrefixed(app, "acceptor.properties")]),
+		"acceptorConfigurations.\"61616\".params.port=61616\n")
+	assert.Contains(t, string(secret.Data[AppIdentityPrefixed(app, "capabilities.properties")]),
+		"addressConfigurations.\"orders\".queueConfigs.\"orders\".routingType=ANYCAST\n")
+
+	// a bridge per queue moves the messages to the acceptor of the current binding
+	bridges := string(secret.Data[AppIdentityPrefixed(app, "migration.properties")])
+	assert.Contains(t, bridges, "connectorConfigurations.\"default-orders-migration\".params.host=new-peer-1.default.svc.cluster.local\n")
+	assert.Contains(t, bridges, "connectorConfigurations.\"default-orders-migration\".params.port=61700\n")
+	assert.Contains(t, bridges, "connectorConfigurations.\"default-orders-migration\".params.keyStorePath=/amq/extra/secrets/"+
+		AppPropertiesSecretNameForApp("old", app)+"/_default-orders-migration.pemcfg\n")
+	assert.Contains(t, bridges, "bridgeConfigurations.\"default-orders-migration-orders\\:\\:orders\".queueName=orders\n")
+	assert.Contains(t, bridges, "bridgeConfigurations.\"default-orders-migration-orders\\:\\:orders\".forwardingAddress=orders::orders\n")
+	assert.Contains(t, bridges, "bridgeConfigurations.\"default-orders-migration-events\\:\\:audit\".queueName=audit\n")
+	assert.Contains(t, bridges, "bridgeConfigurations.\"default-orders-migration-events\\:\\:audit\".forwardingAddress=events::audit\n")
+	assert.Contains(t, bridges, "bridgeConfigurations.\"default-orders-migration-events\\:\\:audit\".staticConnectors=default-orders-migration\n")
+
+	// with a cert issued for the migration subject
+	cert := parseCertPem(t, secret.Data["_default-orders-migration-tls.crt"])
+	assert.Equal(t, MigrationCertSubject(app).String(), cert.Subject.String())
+
+	// the migrating app is not provisioned on the previous service
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: "old", Namespace: ns}, updated))
+	assert.NotContains(t, updated.Status.ProvisionedApps, AppIdentity(app))
+	assert.Empty(t, updated.Status.RejectedApps)
+
+	// the current binding accepts the migration cert with send on the queues of the app
+	reconcileBrokerService(t, env, "new")
+
+	current, err := mergedAppSecrets(env.Client, ns, PeerName("new", 1))
+	assert.NoError(t, err)
+	assert.Contains(t, string(current.Data[UnderscoreAppIdentityPrefixed(app, common.GetCertUsersKey(jaasConfigRealmName(app)))]),
+		"default-orders-migration=/^CN=orders:migration,OU=default$/\n")
+	assert.Contains(t, string(current.Data[UnderscoreAppIdentityPrefixed(app, common.GetCertRolesKey(jaasConfigRealmName(app)))]),
+		"default-orders-migration=default-orders-migration\n")
+	capabilities := string(current.Data[AppIdentityPrefixed(app, "capabilities.properties")])
+	assert.Contains(t, capabilities, "securityRoles.\"orders\".\"default-orders-migration\".send=true\n")
+	assert.Contains(t, capabilities, "securityRoles.\"events\".\"default-orders-migration\".send=true\n")
+	assert.NotContains(t, string(current.Data[AppIdentityPrefixed(app, "migration.properties")]), "bridgeConfigurations")
+}
+
+func TestBrokerServiceMigration_Retired(t *testing.T) {
+	ns := "default"
+
+	oc := withOperatorCA(t, ns)
+	old := NewBrokerService("old", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("new", ns, "orders-binding-secret", 61700).
+		WithMigrationFrom("old", ns, 61616, 0).
+		Build()
+	env := NewTestEnvironment(ns, oc, old, app)
+
+	reconcileBrokerService(t, env, "old")
+
+	secret, err := mergedAppSecrets(env.Client, ns, "old")
+	assert.NoError(t, err)
+	assert.Contains(t, secret.Data, AppIdentityPrefixed(app, "acceptor.properties"))
+	// without an operator issuer the messages drain through the consumers of the previous binding
+	assert.NotContains(t, secret.Data, AppIdentityPrefixed(app, "migration.properties"))
+
+	app.Status.Migration = nil
+	assert.NoError(t, env.Client.Status().Update(context.TODO(), app))
+
+	reconcileBrokerService(t, env, "old")
+
+	secret, err = mergedAppSecrets(env.Client, ns, "old")
+	assert.NoError(t, err)
+	assert.NotContains(t, secret.Data, AppIdentityPrefixed(app, "acceptor.properties"))
+	assert.NotContains(t, secret.Data, AppIdentityPrefixed(app, "capabilities.properties"))
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// CapacityDimension names a resource that bounds the apps placed on a peer of a BrokerService
+type CapacityDimension string
+
+const (
+	CapacityMemory      CapacityDimension = "memory"
+	CapacityCPU         CapacityDimension = "cpu"
+	CapacityStorage     CapacityDimension = "storage"
+	CapacityConnections CapacityDimension = "connections"
+	CapacityAddresses   CapacityDimension = "addresses"
+	CapacityQueues      CapacityDimension = "queues"
+)
+
+// CapacityDimensions lists the dimensions in the order they are checked and reported
+var CapacityDimensions = []CapacityDimension{
+	CapacityMemory,
+	CapacityCPU,
+	CapacityStorage,
+	CapacityConnections,
+	CapacityAddresses,
+	CapacityQueues,
+}
+
+// capacityAmounts holds an amount per dimension, memory and storage in bytes and cpu in millicores
+type capacityAmounts map[CapacityDimension]int64
+
+// appCapacityDemand returns the amount of each dimension an app claims, connections only when declared
+func appCapacityDemand(app *broker.BrokerApp) capacityAmounts {
+	demand := capacityAmounts{
+		CapacityMemory:  app.Spec.Resources.Requests.Memory().Value(),
+		CapacityCPU:     app.Spec.Resources.Requests.Cpu().MilliValue(),
+		CapacityStorage: app.Spec.Resources.Requests.Storage().Value(),
+	}
+	if app.Spec.MaxConnections != nil {
+		demand[CapacityConnections] = int64(*app.Spec.MaxConnections)
+	}
+	// inconsistent addresses are reported by validation
+	if tracker, err := newAppAddressTracker(app); err == nil {
+		demand[CapacityAddresses], demand[CapacityQueues] = tracker.counts()
+	}
+	return demand
+}
+
+// serviceCapacityLimits returns the limit of each dimension bounded on a peer of the service
+func serviceCapacityLimits(service *broker.BrokerService) capacityAmounts {
+	limits := capacityAmounts{}
+
+	if memory := service.Spec.Resources.Limits.Memory(); !memory.IsZero() {
+		limits[CapacityMemory] = memory.Value()
+	}
+	if cpu := service.Spec.Resources.Limits.Cpu(); !cpu.IsZero() {
+		limits[CapacityCPU] = cpu.MilliValue()
+	}
+
+	// each peer has a journal claim, the ephemeral journal is not bounded
+	if persistence := service.Spec.Persistence; persistence != nil {
+		size := persistence.Storage.Size
+		if size == "" {
+			size = DefaultJournalStorageSize
+		}
+		if storage, err := resource.ParseQuantity(size); err == nil {
+			limits[CapacityStorage] = storage.Value()
+		}
+	}
+
+	if capacity := service.Spec.Capacity; capacity != nil {
+		if capacity.MaxConnections != nil {
+			limits[CapacityConnections] = int64(*capacity.MaxConnections)
+		}
+		if capacity.MaxAddresses != nil {
+			limits[CapacityAddresses] = int64(*capacity.MaxAddresses)
+		}
+		if capacity.MaxQueues != nil {
+			limits[CapacityQueues] = int64(*capacity.MaxQueues)
+		}
+	}
+	return limits
+}
+
+// capacityQuantity returns an amount of a dimension as a quantity in the notation of its limit
+func capacityQuantity(dimension CapacityDimension, amount int64) *resource.Quantity {
+	switch dimension {
+	case CapacityMemory, CapacityStorage:
+		return resource.NewQuantity(amount, resource.BinarySI)
+	case CapacityCPU:
+		return resource.NewMilliQuantity(amount, resource.DecimalSI)
+	default:
+		return resource.NewQuantity(amount, resource.DecimalSI)
+	}
+}
+
+// formatCapacity returns an amount of a dimension in the notation of its limit
+func formatCapacity(dimension CapacityDimension, amount int64) string {
+	return capacityQuantity(dimension, amount).String()
+}
+
+// resourceList returns the non zero amounts keyed by dimension
+func (amounts capacityAmounts) resourceList() corev1.ResourceList {
+	list := corev1.ResourceList{}
+	for dimension, amount := range amounts {
+		if amount != 0 {
+			list[corev1.ResourceName(dimension)] = *capacityQuantity(dimension, amount)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+// orderedDimensions returns the distinct dimensions in the order of CapacityDimensions
+func orderedDimensions(dimensions []CapacityDimension) []CapacityDimension {
+	present := make(map[CapacityDimension]bool, len(dimensions))
+	for _, dimension := range dimensions {
+		present[dimension] = true
+	}
+	var ordered []CapacityDimension
+	for _, dimension := range CapacityDimensions {
+		if present[dimension] {
+			ordered = append(ordered, dimension)
+		}
+	}
+	return ordered
+}
+
+// peerCapacity is the capacity of a peer and the amounts claimed by the apps placed on it
+type peerCapacity struct {
+	limits capacityAmounts
+	used   capacityAmounts
+	apps   int
+}
+
+// insufficient returns the dimensions of the demand that exceed the capacity available on the peer,
+// with a description of each shortfall
+func (p *peerCapacity) insufficient(demand capacityAmounts) (dimensions []CapacityDimension, details []string) {
+	for _, dimension := range CapacityDimensions {
+		limit, bounded := p.limits[dimension]
+		if !bounded {
+			continue
+		}
+
+		required, declared := demand[dimension]
+		if dimension == CapacityConnections && !declared {
+			dimensions = append(dimensions, dimension)
+			details = append(details, "connections (spec.maxConnections is required, the service bounds connections)")
+			continue
+		}
+
+		available := limit - p.used[dimension]
+		if available < 0 {
+			available = 0
+		}
+		if required > available {
+			dimensions = append(dimensions, dimension)
+			details = append(details, fmt.Sprintf("%s (available: %s, required: %s)",
+				dimension, formatCapacity(dimension, available), formatCapacity(dimension, required)))
+		}
+	}
+	return dimensions, details
+}
+
+// utilization returns the largest fraction of a bounded dimension that is claimed once the demand is placed on the peer
+func (p *peerCapacity) utilization(demand capacityAmounts) float64 {
+	var utilization float64
+	for dimension, limit := range p.limits {
+		if limit <= 0 {
+			continue
+		}
+		if claimed := float64(p.used[dimension]+demand[dimension]) / float64(limit); claimed > utilization {
+			utilization = claimed
+		}
+	}
+	return utilization
+}
+
+// getPeerCapacity returns the capacity of each peer of the service and the amounts claimed by the other apps placed on it
+func (reconciler *BrokerAppInstanceReconciler) getPeerCapacity(service *broker.BrokerService) ([]peerCapacity, error) {
+	// Find all other apps currently provisioned on this service
+	apps, err := reconciler.listOtherAppsForService(service)
+	if err != nil {
+		return nil, err
+	}
+	return peerCapacities(service, apps), nil
+}
+
+// peerCapacities returns the capacity of each peer of the service and the amounts claimed by the apps placed on it
+func peerCapacities(service *broker.BrokerService, apps []broker.BrokerApp) []peerCapacity {
+	limits := serviceCapacityLimits(service)
+	peers := make([]peerCapacity, PeerCount(service))
+	for index := range peers {
+		peers[index] = peerCapacity{limits: limits, used: capacityAmounts{}}
+	}
+
+	for i := range apps {
+		peer := apps[i].Status.Service.Peer
+		if peer < 0 || int(peer) >= len(peers) {
+			// placed on a removed peer, pending reassignment
+			continue
+		}
+		for dimension, amount := range appCapacityDemand(&apps[i]) {
+			peers[peer].used[dimension] += amount
+		}
+		peers[peer].apps++
+	}
+	return peers
+}
+
+// placementStrategy returns the strategy that places the app, the strategy of the app takes precedence
+// over the strategy of the service. A nil service returns the strategy that chooses between services
+func placementStrategy(app *broker.BrokerApp, service *broker.BrokerService) broker.PlacementStrategy {
+	if app.Spec.PlacementStrategy != "" {
+		return app.Spec.PlacementStrategy
+	}
+	if service != nil && service.Spec.PlacementStrategy != "" {
+		return service.Spec.PlacementStrategy
+	}
+	return broker.PlacementStrategies.Spread
+}
+
+// placementCandidate is a peer of a service with capacity for the app
+type placementCandidate struct {
+	service     *broker.BrokerService
+	peer        int32
+	port        int32
+	utilization float64
+	apps        int
+	links       int
+}
+
+// preferredTo is true when the strategy prefers this candidate to the other, the other is kept on ties.
+// The candidate with the fewest shared addresses linked from other services is preferred first
+//
+// * `binpack` prefers the most utilized peer, then the peer with most apps
+// * `spread` prefers the least utilized peer, then the peer with fewest apps
+// * `leastApps` prefers the peer with fewest apps, then the least utilized peer
+func (c *placementCandidate) preferredTo(other *placementCandidate, strategy broker.PlacementStrategy) bool {
+	if other == nil {
+		return true
+	}
+	if c.links != other.links {
+		return c.links < other.links
+	}
+	switch strategy {
+	case broker.PlacementStrategies.Binpack:
+		if c.utilization != other.utilization {
+			return c.utilization > other.utilization
+		}
+		return c.apps > other.apps
+	case broker.PlacementStrategies.LeastApps:
+		if c.apps != other.apps {
+			return c.apps < other.apps
+		}
+		return c.utilization < other.utilization
+	default:
+		if c.utilization != other.utilization {
+			return c.utilization < other.utilization
+		}
+		return c.apps < other.apps
+	}
+}
+
+// describeDemand returns the amount the app claims of each dimension
+func describeDemand(dimensions []CapacityDimension, demand capacityAmounts) string {
+	var required []string
+	for _, dimension := range dimensions {
+		if amount, declared := demand[dimension]; declared {
+			required = append(required, fmt.Sprintf("%s %s", dimension, formatCapacity(dimension, amount)))
+		}
+	}
+	return strings.Join(required, ", ")
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestPlacementStatus_Chosen(t *testing.T) {
+	ns := "default"
+
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	small := NewBrokerService("small", ns).WithMemoryLimit("64Mi").Build()
+	large := NewBrokerService("large", ns).WithMemoryLimit("1Gi").Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithMemoryRequest("128Mi").
+		Build()
+	env := NewTestEnvironment(ns, cordoned, small, large, app)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	placement := updated.Status.Placement
+	if assert.NotNil(t, placement) {
+		assert.False(t, placement.DecisionTime.IsZero())
+		assert.Equal(t, &v1beta2.BrokerAppPlacementChoice{
+			Name:      "large",
+			Namespace: ns,
+			Peer:      0,
+			Port:      61616,
+			Strategy:  v1beta2.PlacementStrategies.Spread,
+		}, placement.Chosen)
+
+		categories := map[string]string{}
+		for _, candidate := range placement.Candidates {
+			assert.Equal(t, ns, candidate.Namespace)
+			categories[candidate.Name] = candidate.Category
+		}
+		assert.Equal(t, map[string]string{"cordoned": "cordoned", "small": "capacity", "large": ""}, categories)
+	}
+
+	// the binding is kept without another decision
+	decided := placement.DecisionTime
+	_, updated = reconcileBrokerApp(t, env, app.Name)
+	if assert.NotNil(t, updated.Status.Placement) {
+		assert.Equal(t, decided, updated.Status.Placement.DecisionTime)
+	}
+}
+
+func TestPlacementStatus_Pending(t *testing.T) {
+	ns := "default"
+
+	small := NewBrokerService("small", ns).WithMemoryLimit("64Mi").Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithMemoryRequest("128Mi").
+		Build()
+	env := NewTestEnvironment(ns, small, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Equal(t, v1beta2.DeployedConditionNoServiceCapacityReason, deployed.Reason)
+	}
+
+	placement := updated.Status.Placement
+	if assert.NotNil(t, placement) {
+		assert.Nil(t, placement.Chosen)
+		if assert.Len(t, placement.Candidates, 1) {
+			assert.Equal(t, "small", placement.Candidates[0].Name)
+			assert.Equal(t, "capacity", placement.Candidates[0].Category)
+			assert.Contains(t, placement.Candidates[0].Message, "insufficient capacity on peer 0")
+		}
+	}
+
+	// the same decision keeps its time
+	decided := metav1.NewTime(placement.DecisionTime.Add(-time.Minute))
+	updated.Status.Placement.DecisionTime = decided
+	assert.NoError(t, env.Client.Status().Update(context.TODO(), updated))
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	if assert.NotNil(t, updated.Status.Placement) {
+		assert.True(t, decided.Equal(&updated.Status.Placement.DecisionTime))
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAppCapacityDemand(t *testing.T) {
+	app := NewBrokerApp("orders-app", "test").
+		WithMemoryRequest("512Mi").
+		WithResourceRequest(corev1.ResourceCPU, "500m").
+		WithResourceRequest(corev1.ResourceStorage, "1Gi").
+		WithMaxConnections(10).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithSharedAddresses(NewAddressType("events").WithSubscriptions("a", "b").Build()).
+		WithConsumerOf(NewAddressRef("invoices").WithAppRef("test", "billing").Build()).
+		Build()
+
+	demand := appCapacityDemand(app)
+
+	assert.Equal(t, capacityAmounts{
+		CapacityMemory:      512 * 1024 * 1024,
+		CapacityCPU:         500,
+		CapacityStorage:     1024 * 1024 * 1024,
+		CapacityConnections: 10,
+		// orders and events, invoices is owned by billing
+		CapacityAddresses: 2,
+		// orders and the two subscriptions of events
+		CapacityQueues: 3,
+	}, demand)
+
+	// connections are only claimed when declared
+	assert.NotContains(t, appCapacityDemand(NewBrokerApp("other", "test").Build()), CapacityConnections)
+}
+
+func TestBrokerAppPlacement_InsufficientCapacity(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	tests := []struct {
+		name      string
+		service   *v1beta2.BrokerService
+		existing  *v1beta2.BrokerApp
+		app       *v1beta2.BrokerApp
+		primary   string
+		shortfall string
+	}{
+		{
+			name:      "cpu",
+			service:   NewBrokerService(svcName, ns).WithResourceLimit(corev1.ResourceCPU, "1").Build(),
+			existing:  NewBrokerApp("existing", ns).WithResourceRequest(corev1.ResourceCPU, "750m").Build(),
+			app:       NewBrokerApp("new-app", ns).WithResourceRequest(corev1.ResourceCPU, "500m").Build(),
+			primary:   "insufficient cpu capacity (app requires cpu 500m)",
+			shortfall: "peer 0: cpu (available: 250m, required: 500m)",
+		},
+		{
+			name: "storage",
+			service: func() *v1beta2.BrokerService {
+				service := NewBrokerService(svcName, ns).Build()
+				service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{Storage: v1beta2.StorageType{Size: "2Gi"}}
+				return service
+			}(),
+			existing:  NewBrokerApp("existing", ns).WithResourceRequest(corev1.ResourceStorage, "1536Mi").Build(),
+			app:       NewBrokerApp("new-app", ns).WithResourceRequest(corev1.ResourceStorage, "1Gi").Build(),
+			primary:   "insufficient storage capacity (app requires storage 1Gi)",
+			shortfall: "peer 0: storage (available: 512Mi, required: 1Gi)",
+		},
+		{
+			name:      "connections",
+			service:   NewBrokerService(svcName, ns).WithCapacity(v1beta2.BrokerServiceCapacityType{MaxConnections: ptr.To(int32(10))}).Build(),
+			existing:  NewBrokerApp("existing", ns).WithMaxConnections(8).Build(),
+			app:       NewBrokerApp("new-app", ns).WithMaxConnections(5).Build(),
+			primary:   "insufficient connections capacity (app requires connections 5)",
+			shortfall: "peer 0: connections (available: 2, required: 5)",
+		},
+		{
+			name:      "undeclared connections",
+			service:   NewBrokerService(svcName, ns).WithCapacity(v1beta2.BrokerServiceCapacityType{MaxConnections: ptr.To(int32(10))}).Build(),
+			existing:  NewBrokerApp("existing", ns).WithMaxConnections(1).Build(),
+			app:       NewBrokerApp("new-app", ns).Build(),
+			primary:   "insufficient connections capacity",
+			shortfall: "peer 0: connections (spec.maxConnections is required, the service bounds connections)",
+		},
+		{
+			name:      "addresses",
+			service:   NewBrokerService(svcName, ns).WithCapacity(v1beta2.BrokerServiceCapacityType{MaxAddresses: ptr.To(int32(2))}).Build(),
+			existing:  NewBrokerApp("existing", ns).WithAddresses(NewAddressType("a").Build(), NewAddressType("b").Build()).Build(),
+			app:       NewBrokerApp("new-app", ns).WithAddresses(NewAddressType("c").Build()).Build(),
+			primary:   "insufficient addresses capacity (app requires addresses 1)",
+			shortfall: "peer 0: addresses (available: 0, required: 1)",
+		},
+		{
+			name:      "queues",
+			service:   NewBrokerService(svcName, ns).WithCapacity(v1beta2.BrokerServiceCapacityType{MaxQueues: ptr.To(int32(3))}).Build(),
+			existing:  NewBrokerApp("existing", ns).WithAddresses(NewAddressType("a").Build(), NewAddressType("b").Build()).Build(),
+			app:       NewBrokerApp("new-app", ns).WithAddresses(NewAddressType("events").WithSubscriptions("x", "y").Build()).Build(),
+			primary:   "insufficient queues capacity (app requires queues 2)",
+			shortfall: "peer 0: queues (available: 1, required: 2)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.existing.Status.Service = &v1beta2.BrokerServiceBindingStatus{
+				Name: svcName, Namespace: ns, Secret: "existing-binding-secret", AssignedPort: 61616,
+			}
+			env := NewTestEnvironment(ns, tt.service, tt.existing, tt.app)
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: tt.app.Name, Namespace: ns}}
+			_, err := env.Reconciler.Reconcile(context.TODO(), req)
+			assert.Error(t, err)
+
+			updated := &v1beta2.BrokerApp{}
+			assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+			assert.Nil(t, updated.Status.Service)
+
+			deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+			if assert.NotNil(t, deployed) {
+				assert.Equal(t, v1beta2.DeployedConditionNoServiceCapacityReason, deployed.Reason)
+				assert.Contains(t, deployed.Message, tt.primary)
+				assert.Contains(t, deployed.Message, tt.shortfall)
+			}
+		})
+	}
+}
+
+func TestBrokerAppPlacement_Strategies(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	tests := []struct {
+		name            string
+		serviceStrategy v1beta2.PlacementStrategy
+		appStrategy     v1beta2.PlacementStrategy
+		expectedPeer    int32
+	}{
+		{name: "spread by default, the least utilized peer", expectedPeer: 1},
+		{name: "binpack, the most utilized peer", serviceStrategy: v1beta2.PlacementStrategies.Binpack, expectedPeer: 0},
+		{name: "leastApps, the peer with fewest apps", serviceStrategy: v1beta2.PlacementStrategies.LeastApps, expectedPeer: 2},
+		{name: "the app strategy takes precedence", serviceStrategy: v1beta2.PlacementStrategies.Binpack, appStrategy: v1beta2.PlacementStrategies.LeastApps, expectedPeer: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewBrokerService(svcName, ns).
+				WithPeers(3).
+				WithMemoryLimit("4Gi").
+				WithPlacementStrategy(tt.serviceStrategy).
+				Build()
+
+			// peer 0 is the most utilized, peer 1 is the least utilized, peer 2 has the fewest apps
+			placed := []struct {
+				peer   int32
+				memory string
+			}{
+				{0, "1536Mi"}, {0, "1536Mi"},
+				{1, "128Mi"}, {1, "128Mi"}, {1, "128Mi"},
+				{2, "1Gi"},
+			}
+			objects := []client.Object{service}
+			for i, p := range placed {
+				name := fmt.Sprintf("existing-%d", i)
+				objects = append(objects, NewBrokerApp(name, ns).
+					WithMemoryRequest(p.memory).
+					WithServiceBinding(svcName, ns, name+"-binding-secret", 61616+int32(i)).
+					WithServicePeer(p.peer).
+					Build())
+			}
+			app := NewBrokerApp("new-app", ns).
+				WithMemoryRequest("256Mi").
+				WithPlacementStrategy(tt.appStrategy).
+				Build()
+			objects = append(objects, app)
+
+			env := NewTestEnvironment(ns, objects...)
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+			_, err := env.Reconciler.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+
+			updated := &v1beta2.BrokerApp{}
+			assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+			if assert.NotNil(t, updated.Status.Service) {
+				assert.Equal(t, tt.expectedPeer, updated.Status.Service.Peer)
+			}
+		})
+	}
+}
+
+func TestBrokerAppPlacement_BinpackAcrossServices(t *testing.T) {
+	ns := "default"
+
+	empty := NewBrokerService("empty", ns).WithMemoryLimit("4Gi").Build()
+	busy := NewBrokerService("busy", ns).WithMemoryLimit("4Gi").Build()
+	existing := NewBrokerApp("existing", ns).
+		WithMemoryRequest("2Gi").
+		WithServiceBinding("busy", ns, "existing-binding-secret", 61616).
+		Build()
+	app := NewBrokerApp("new-app", ns).
+		WithMemoryRequest("256Mi").
+		WithPlacementStrategy(v1beta2.PlacementStrategies.Binpack).
+		Build()
+
+	env := NewTestEnvironment(ns, empty, busy, existing, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "busy", updated.Status.Service.Name)
+	}
+}
+
+func TestBrokerAppPlacement_DefaultAcrossServicesOfDifferentSizes(t *testing.T) {
+	ns := "default"
+
+	// large has the most free memory, small is the least utilized
+	small := NewBrokerService("small", ns).WithMemoryLimit("1Gi").Build()
+	large := NewBrokerService("large", ns).WithMemoryLimit("4Gi").Build()
+	existing := NewBrokerApp("existing", ns).
+		WithMemoryRequest("2Gi").
+		WithServiceBinding("large", ns, "existing-binding-secret", 61616).
+		Build()
+	app := NewBrokerApp("new-app", ns).
+		WithMemoryRequest("256Mi").
+		Build()
+
+	env := NewTestEnvironment(ns, small, large, existing, app)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// spread, not the most free memory of earlier releases
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "small", updated.Status.Service.Name)
+	}
+	if assert.NotNil(t, updated.Status.Placement) && assert.NotNil(t, updated.Status.Placement.Chosen) {
+		assert.Equal(t, v1beta2.PlacementStrategies.Spread, updated.Status.Placement.Chosen.Strategy)
+	}
+}
+
+func TestBrokerServiceAcceptor_ConnectionsAllowed(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithMaxConnections(5).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	unbounded := NewBrokerApp("billing", ns).
+		WithServiceBinding(svcName, ns, "billing-binding-secret", 61617).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, app, unbounded)
+
+	reconcileBrokerService(t, env, svcName)
+
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	assert.Contains(t, string(secret.Data[AppIdentityPrefixed(app, "acceptor.properties")]),
+		"acceptorConfigurations.\"61616\".params.connectionsAllowed=5\n")
+	assert.NotContains(t, string(secret.Data[AppIdentityPrefixed(unbounded, "acceptor.properties")]), "connectionsAllowed")
+}
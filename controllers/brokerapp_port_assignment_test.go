@@ -23,6 +23,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -274,3 +275,75 @@ func TestBrokerAppPortAssignment_MultipleApps(t *testing.T) {
 
 	assert.NotEqual(t, updatedApp1.Status.Service.AssignedPort, updatedApp2.Status.Service.AssignedPort)
 }
+
+func TestBrokerAppPortAssignment_PortRange(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	svc := NewBrokerService(svcName, ns).WithPortRange(30000, 30010, 30000).Build()
+	existing := NewBrokerApp("existing", ns).
+		WithServiceBinding(svcName, ns, "existing-binding-secret", 30001).
+		Build()
+	app := NewBrokerApp("test-app", ns).Build()
+
+	env := NewTestEnvironment(ns, svc, existing, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.NotNil(t, updatedApp.Status.Service)
+	assert.Equal(t, int32(30002), updatedApp.Status.Service.AssignedPort)
+}
+
+func TestBrokerAppPortAssignment_PortRangeExhausted(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	svc := NewBrokerService(svcName, ns).WithPortRange(30000, 30001, 30001).Build()
+	existing := NewBrokerApp("existing", ns).
+		WithServiceBinding(svcName, ns, "existing-binding-secret", 30000).
+		Build()
+	app := NewBrokerApp("test-app", ns).Build()
+
+	env := NewTestEnvironment(ns, svc, existing, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.Nil(t, updatedApp.Status.Service)
+
+	deployed := meta.FindStatusCondition(updatedApp.Status.Conditions, v1beta2.DeployedConditionType)
+	assert.NotNil(t, deployed)
+	assert.Equal(t, v1.ConditionFalse, deployed.Status)
+	assert.Equal(t, v1beta2.DeployedConditionPortPoolExhaustedReason, deployed.Reason)
+	assert.Contains(t, deployed.Message, "[30000-30001]")
+}
+
+func TestBrokerAppPortAssignment_ReassignedOutsidePortRange(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	// the range was configured after the app was bound
+	svc := NewBrokerService(svcName, ns).WithPortRange(30000, 30010).Build()
+	app := NewBrokerApp("test-app", ns).
+		WithServiceBinding(svcName, ns, "test-app-binding-secret", 61616).
+		Build()
+
+	env := NewTestEnvironment(ns, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.NotNil(t, updatedApp.Status.Service)
+	assert.Equal(t, svcName, updatedApp.Status.Service.Name)
+	assert.Equal(t, int32(30000), updatedApp.Status.Service.AssignedPort)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+)
+
+// routeVerbs returns the endpoints of the routes of the app, a route consumes from its source and produces to its target
+func routeVerbs(app *broker.BrokerApp) []capabilityVerb {
+	var verbs []capabilityVerb
+	for index, route := range app.Spec.Routes {
+		verbs = append(verbs,
+			capabilityVerb{fmt.Sprintf("routes[%d].from", index), []broker.AddressRef{route.From}, []string{AddressPermissionConsume}},
+			capabilityVerb{fmt.Sprintf("routes[%d].to", index), []broker.AddressRef{route.To}, []string{AddressPermissionProduce}})
+	}
+	return verbs
+}
+
+// validateRoutes ensures the routes have distinct names and divert between distinct addresses the app owns,
+// the shared addresses of other apps are checked on placement by checkVerbRefCapacity
+func (reconciler *BrokerAppInstanceReconciler) validateRoutes() error {
+	owned := collectOwnedAddresses(reconciler.instance)
+	names := map[string]bool{}
+	for index := range reconciler.instance.Spec.Routes {
+		route := &reconciler.instance.Spec.Routes[index]
+		path := fmt.Sprintf("Spec.Routes[%d]", index)
+		if names[route.Name] {
+			return NewValidationError(broker.ValidConditionAddressTypeError, "%s: route name '%s' is declared more than once", path, route.Name)
+		}
+		names[route.Name] = true
+		for _, err := range []error{checkAddressTarget(path+".from", &route.From, owned), checkAddressTarget(path+".to", &route.To, owned)} {
+			if err != nil {
+				return NewValidationError(broker.ValidConditionAddressTypeError, "%v", err)
+			}
+		}
+		if route.From.Address == route.To.Address && addressRefOwner(reconciler.instance, &route.From) == addressRefOwner(reconciler.instance, &route.To) {
+			return NewValidationError(broker.ValidConditionAddressTypeError, "%s: from and to are the same address '%s'", path, route.From.Address)
+		}
+		if route.Exclusive && route.From.AppNamespace != "" {
+			return NewValidationError(broker.ValidConditionAddressTypeError,
+				"%s: an exclusive route takes the messages of the consumers of '%s', it must be from an address owned by the app", path, route.From.Address)
+		}
+	}
+	return nil
+}
+
+// addRouteProperties generates a divert for each route, the name is prefixed with the app identity so the
+// diverts of apps on the same broker never clash
+func addRouteProperties(props map[string]string, app *broker.BrokerApp) {
+	for _, route := range app.Spec.Routes {
+		name := AppIdentityPrefixed(app, route.Name)
+		prefix := fmt.Sprintf("divertConfigurations.\"%s\".", escapeForProperties(name))
+		props[fmt.Sprintf("%sroutingName=%s\n", prefix, name)] = ""
+		props[fmt.Sprintf("%saddress=%s\n", prefix, route.From.Address)] = ""
+		props[fmt.Sprintf("%sforwardingAddress=%s\n", prefix, route.To.Address)] = ""
+		props[fmt.Sprintf("%sexclusive=%t\n", prefix, route.Exclusive)] = ""
+		filterString := ""
+		if route.FilterString != nil {
+			filterString = *route.FilterString
+		}
+		props[fmt.Sprintf("%sfilterString=%s\n", prefix, escapePropertyValue(filterString))] = ""
+		if route.TransformerClassName != nil {
+			props[fmt.Sprintf("%stransformerConfiguration.className=%s\n", prefix, escapePropertyValue(*route.TransformerClassName))] = ""
+		}
+	}
+}
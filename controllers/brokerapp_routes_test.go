@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func routesApp(ns string, routes ...v1beta2.RouteType) *v1beta2.BrokerApp {
+	app := NewBrokerApp("app", ns).
+		WithAddresses(NewAddressType("orders").Build(), NewAddressType("audit").Build()).
+		Build()
+	app.Spec.Routes = routes
+	return app
+}
+
+func TestBrokerServiceRoutes_Properties(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	filter := `region = 'eu\west'`
+	transformer := "org.example.AuditTransformer"
+	app := routesApp("test",
+		v1beta2.RouteType{Name: "copy", From: NewAddressRef("orders").Build(), To: NewAddressRef("audit").Build(), FilterString: &filter, TransformerClassName: &transformer},
+		v1beta2.RouteType{Name: "move", From: NewAddressRef("audit").Build(), To: NewAddressRef("orders").Build(), Exclusive: true},
+	)
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-app-capabilities.properties"])
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".routingName=test-app-copy\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".address=orders\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".forwardingAddress=audit\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".exclusive=false\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".filterString=region = 'eu\\\\west'\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".transformerConfiguration.className=org.example.AuditTransformer\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-move\".exclusive=true\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-move\".filterString=\n")
+	assert.NotContains(t, props, "divertConfigurations.\"test-app-move\".transformerConfiguration")
+}
+
+func TestBrokerServiceRoutes_MultiLineValues(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	filter := "region = 'eu'\r\nsecurityRoles.\"#\".attacker.send=true"
+	transformer := "org.example.AuditTransformer\nsecurityRoles.\"#\".attacker.consume=true"
+	app := routesApp("test",
+		v1beta2.RouteType{Name: "copy", From: NewAddressRef("orders").Build(), To: NewAddressRef("audit").Build(), FilterString: &filter, TransformerClassName: &transformer},
+	)
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	// a line break in a value never starts another property
+	props := string(secret.Data["test-app-capabilities.properties"])
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".filterString=region = 'eu'\\r\\nsecurityRoles.\"#\".attacker.send=true\n")
+	assert.Contains(t, props, "divertConfigurations.\"test-app-copy\".transformerConfiguration.className=org.example.AuditTransformer\\nsecurityRoles.\"#\".attacker.consume=true\n")
+	for _, line := range strings.Split(props, "\n") {
+		assert.False(t, strings.HasPrefix(line, "securityRoles.\"#\".attacker"), line)
+	}
+}
+
+func TestRoutes_Validation(t *testing.T) {
+	ns := "default"
+	for _, tc := range []struct {
+		name    string
+		route   v1beta2.RouteType
+		message string
+	}{
+		{"notOwned",
+			v1beta2.RouteType{Name: "r", From: NewAddressRef("orders").Build(), To: NewAddressRef("other").Build()},
+			"Spec.Routes[1].to: address 'other' is not owned by the app"},
+		{"duplicate",
+			v1beta2.RouteType{Name: "first", From: NewAddressRef("audit").Build(), To: NewAddressRef("orders").Build()},
+			"Spec.Routes[1]: route name 'first' is declared more than once"},
+		{"same",
+			v1beta2.RouteType{Name: "r", From: NewAddressRef("orders").Build(), To: NewAddressRef("orders").Build()},
+			"Spec.Routes[1]: from and to are the same address 'orders'"},
+		{"subscriptions",
+			v1beta2.RouteType{Name: "r", From: NewAddressRef("orders").WithSubscriptions("audit").Build(), To: NewAddressRef("audit").Build()},
+			"Spec.Routes[1].from: pubSub and subscriptions are taken from the address"},
+		{"exclusiveShared",
+			v1beta2.RouteType{Name: "r", From: NewAddressRef("orders").WithAppRef(ns, "owner").Build(), To: NewAddressRef("audit").Build(), Exclusive: true},
+			"Spec.Routes[1]: an exclusive route takes the messages of the consumers of 'orders', it must be from an address owned by the app"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			first := v1beta2.RouteType{Name: "first", From: NewAddressRef("orders").Build(), To: NewAddressRef("audit").Build()}
+			app := routesApp(ns, first, tc.route)
+			env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), app)
+
+			_, updated := reconcileBrokerApp(t, env, app.Name)
+			assert.Nil(t, updated.Status.Service)
+			valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+			if assert.NotNil(t, valid) {
+				assert.Equal(t, metav1.ConditionFalse, valid.Status)
+				assert.Contains(t, valid.Message, tc.message)
+			}
+		})
+	}
+}
+
+func TestRoutes_SharedAddressAccess(t *testing.T) {
+	ns := "default"
+	payments := NewAddressType("payments").Build()
+	payments.Access = &v1beta2.AddressAccessPolicyType{Consume: &v1beta2.AddressAccessRuleType{}}
+	owner := NewBrokerApp("owner", ns).
+		WithSharedAddresses(payments).
+		WithServiceBinding("svc", ns, "owner-binding-secret", 61616).
+		Build()
+	// copying from a shared address needs consume
+	auditor := routesApp(ns, v1beta2.RouteType{Name: "copy", From: NewAddressRef("payments").WithAppRef(ns, owner.Name).Build(), To: NewAddressRef("audit").Build()})
+	auditor.Name = "auditor"
+	// forwarding to a shared address needs produce
+	forwarder := routesApp(ns, v1beta2.RouteType{Name: "forward", From: NewAddressRef("audit").Build(), To: NewAddressRef("payments").WithAppRef(ns, owner.Name).Build()})
+	forwarder.Name = "forwarder"
+	env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), owner, auditor, forwarder)
+
+	_, updated := reconcileBrokerApp(t, env, auditor.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "svc", updated.Status.Service.Name)
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: forwarder.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Contains(t, deployed.Message, "routes[0].to: app default/owner does not grant produce on shared address 'payments' to any app")
+	}
+}
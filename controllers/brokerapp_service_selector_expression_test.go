@@ -0,0 +1,138 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestServiceSelectorExpression_Placement(t *testing.T) {
+	ns := "default"
+
+	zoneA := NewBrokerService("zone-a", ns).WithLabels(map[string]string{"type": "broker", "zone": "a"}).Build()
+	zoneB := NewBrokerService("zone-b", ns).WithLabels(map[string]string{"type": "broker", "zone": "b"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceSelectorExpression(`label(service, "zone") == "b"`).
+		Build()
+	env := NewTestEnvironment(ns, zoneA, zoneB, app)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "zone-b", updated.Status.Service.Name)
+	}
+
+	// the service no longer matches, the app is rebound
+	updated.Spec.ServiceSelectorExpression = `label(service, "zone") == "a"`
+	assert.NoError(t, env.Client.Update(context.TODO(), updated))
+
+	_, updated = reconcileBrokerApp(t, env, app.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "zone-a", updated.Status.Service.Name)
+	}
+}
+
+func TestServiceSelectorExpression_NoMatch(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).WithLabels(map[string]string{"type": "broker", "zone": "a"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceSelectorExpression(`label(service, "zone") == "b"`).
+		Build()
+	// the expression fails to evaluate on a service without a semver label
+	failing := NewBrokerApp("billing", ns).
+		WithAddresses(NewAddressType("billing").Build()).
+		WithServiceSelectorExpression(`compareSemver(label(service, "version"), "2.40.0") >= 0`).
+		Build()
+	env := NewTestEnvironment(ns, service, app, failing)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Equal(t, v1beta2.DeployedConditionNoMatchingServiceReason, deployed.Reason)
+	}
+
+	req.Name = failing.Name
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed = meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Equal(t, v1beta2.DeployedConditionSelectorEvaluationError, deployed.Reason)
+		assert.Contains(t, deployed.Message, "serviceSelectorExpression")
+	}
+}
+
+func TestServiceSelectorExpression_Invalid(t *testing.T) {
+	ns := "default"
+
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceSelectorExpression(`label(service, "zone")`).
+		Build()
+	env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), app)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	if assert.NotNil(t, valid) {
+		assert.Equal(t, metav1.ConditionFalse, valid.Status)
+		assert.Equal(t, v1beta2.ValidConditionSpecSelectorError, valid.Reason)
+		assert.Contains(t, valid.Message, "invalid serviceSelectorExpression")
+	}
+	assert.Nil(t, updated.Status.Service)
+}
+
+func TestServiceSelectorExpression_ServiceRejectsMismatchedBinding(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).WithLabels(map[string]string{"type": "broker", "zone": "a"}).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceSelectorExpression(`label(service, "zone") == "b"`).
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		Build()
+
+	reconciler := &BrokerServiceInstanceReconciler{
+		BrokerServiceReconciler: NewBrokerServiceReconciler(NewTestEnvironment(ns, service).Client, nil, nil, ctrl.Log),
+		instance:                service,
+	}
+	valid, reason := reconciler.validateAppForProvisioning(app, serviceKey(service))
+	assert.False(t, valid)
+	assert.Equal(t, "service does not match serviceSelectorExpression", reason)
+
+	app.Spec.ServiceSelectorExpression = `label(service, "zone") == "a"`
+	valid, _ = reconciler.validateAppForProvisioning(app, serviceKey(service))
+	assert.True(t, valid)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/environments"
+	mgmt "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/artemis"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultStatisticsInterval is how often the statistics of the queues of an app are collected
+	DefaultStatisticsInterval = time.Minute
+
+	// MinStatisticsInterval and MaxStatisticsInterval bound spec.statisticsInterval
+	MinStatisticsInterval = 15 * time.Second
+	MaxStatisticsInterval = 15 * time.Minute
+)
+
+// statisticsTimeout bounds a collection, the reconcile of every app waits on it
+var statisticsTimeout = 5 * time.Second
+
+// statisticsInterval returns the bounded interval between statistics collections of the app
+func statisticsInterval(app *broker.BrokerApp) time.Duration {
+	if app.Spec.StatisticsInterval == nil {
+		return DefaultStatisticsInterval
+	}
+	interval := app.Spec.StatisticsInterval.Duration
+	if interval < MinStatisticsInterval {
+		return MinStatisticsInterval
+	}
+	if interval > MaxStatisticsInterval {
+		return MaxStatisticsInterval
+	}
+	return interval
+}
+
+// appStatisticsQueues returns the queues the app owns or consumes, ordered by fully qualified name
+func appStatisticsQueues(app *broker.BrokerApp) []appQueue {
+	tracker, err := newAppAddressTracker(app)
+	if err != nil {
+		// inconsistent addresses are reported by validation
+		return nil
+	}
+	var queues []appQueue
+	for name, entry := range tracker.names {
+		if fqqn := strings.SplitN(name, FQQNSeparator, 2); len(fqqn) > 1 {
+			queues = append(queues, appQueue{address: fqqn[0], queue: fqqn[1], multicast: true})
+		} else if !entry.isMulticast && (entry.isOwned || len(entry.consumerRoles) > 0) {
+			queues = append(queues, appQueue{address: name, queue: name})
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].fqqn() < queues[j].fqqn()
+	})
+	return queues
+}
+
+var queueStatistics = func(client client.Client, brokerName string, host string, queue appQueue) (*mgmt.QueueStatistics, error) {
+	return mgmt.GetArtemisAgentForRestricted(client, brokerName, host).GetQueueStatistics(queue.address, queue.queue, queue.routingType())
+}
+
+// processStatistics collects the statistics of the queues of a provisioned app from the broker of its peer,
+// once per statistics interval or when the queues of the app change
+func (reconciler *BrokerAppInstanceReconciler) processStatistics() {
+	if reconciler.status.Service == nil || reconciler.service == nil {
+		reconciler.status.Addresses = nil
+		reconciler.status.StatisticsTime = nil
+		return
+	}
+	if !meta.IsStatusConditionTrue(reconciler.status.Conditions, broker.DeployedConditionType) {
+		// the queues are on the broker once the app is provisioned
+		return
+	}
+
+	interval := statisticsInterval(reconciler.instance)
+	queues := appStatisticsQueues(reconciler.instance)
+	now := time.Now()
+	if last := reconciler.status.StatisticsTime; last != nil && sameQueues(reconciler.status.Addresses, queues) {
+		if due := last.Add(interval); now.Before(due) {
+			reconciler.requeueWithin(due.Sub(now))
+			return
+		}
+	}
+	reconciler.requeueWithin(interval)
+
+	addresses, err := reconciler.collectStatistics(queues)
+	if err != nil {
+		// the statistics of the last collection are retained
+		reconciler.log.V(1).Info("Unable to collect queue statistics",
+			"app", reconciler.instance.Name,
+			"service", reconciler.status.Service.Key(),
+			"error", err)
+		return
+	}
+	reconciler.status.Addresses = addresses
+	collected := metav1.NewTime(now)
+	reconciler.status.StatisticsTime = &collected
+}
+
+// collectStatistics reads the statistics of the deployed queues from the first broker of the peer that answers,
+// a collection that exceeds statisticsTimeout is abandoned until the next interval
+func (reconciler *BrokerAppInstanceReconciler) collectStatistics(queues []appQueue) ([]broker.BrokerAppAddressStatus, error) {
+	service := reconciler.service
+	deadline := time.Now().Add(statisticsTimeout)
+	var lastErr error
+	for _, brokerName := range PeerBrokerNames(service, reconciler.status.Service.Peer) {
+		if lastErr != nil && time.Now().After(deadline) {
+			break
+		}
+		mbeanBrokerName := environments.ResolveBrokerNameFromEnvs(service.Spec.Env, brokerName)
+		host := common.OrdinalFQDNS(brokerName, service.Namespace, 0)
+
+		var addresses []broker.BrokerAppAddressStatus
+		var readErr error
+		for _, queue := range queues {
+			if time.Now().After(deadline) {
+				readErr = fmt.Errorf("collection of the statistics of %d queues timed out after %v", len(queues), statisticsTimeout)
+				break
+			}
+			statistics, err := queueStatistics(reconciler.Client, mbeanBrokerName, host, queue)
+			if err != nil {
+				if err.Error() == mgmt.QUEUE_NOT_EXISTS {
+					continue
+				}
+				readErr = err
+				break
+			}
+			addresses = append(addresses, broker.BrokerAppAddressStatus{
+				Address:         queue.address,
+				Queue:           queue.queue,
+				RoutingType:     queue.routingType(),
+				MessageCount:    statistics.MessageCount,
+				ConsumerCount:   statistics.ConsumerCount,
+				DeliveringCount: statistics.DeliveringCount,
+				PersistentSize:  statistics.PersistentSize,
+			})
+		}
+		if readErr != nil {
+			lastErr = readErr
+			continue
+		}
+		return addresses, nil
+	}
+	return nil, lastErr
+}
+
+// sameQueues is true when the collected statistics are of the queues, queues not yet deployed aside
+func sameQueues(addresses []broker.BrokerAppAddressStatus, queues []appQueue) bool {
+	expected := make(map[string]bool, len(queues))
+	for _, queue := range queues {
+		expected[queue.fqqn()] = true
+	}
+	for _, address := range addresses {
+		if !expected[address.Address+FQQNSeparator+address.Queue] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	mgmt "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/artemis"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// withQueueStatistics replaces the jolokia queue statistics with fixed statistics by fully qualified queue name
+func withQueueStatistics(t *testing.T, statistics map[string]mgmt.QueueStatistics, err error) *[]string {
+	original := queueStatistics
+	t.Cleanup(func() { queueStatistics = original })
+
+	hosts := []string{}
+	queueStatistics = func(_ client.Client, _ string, host string, queue appQueue) (*mgmt.QueueStatistics, error) {
+		hosts = append(hosts, host)
+		if err != nil {
+			return nil, err
+		}
+		fixed, found := statistics[queue.fqqn()]
+		if !found {
+			return nil, errors.New(mgmt.QUEUE_NOT_EXISTS)
+		}
+		return &fixed, nil
+	}
+	return &hosts
+}
+
+func TestStatisticsInterval(t *testing.T) {
+	app := NewBrokerApp("orders", "test").Build()
+	assert.Equal(t, DefaultStatisticsInterval, statisticsInterval(app))
+
+	app.Spec.StatisticsInterval = &metav1.Duration{Duration: time.Second}
+	assert.Equal(t, MinStatisticsInterval, statisticsInterval(app))
+
+	app.Spec.StatisticsInterval = &metav1.Duration{Duration: 24 * time.Hour}
+	assert.Equal(t, MaxStatisticsInterval, statisticsInterval(app))
+
+	app.Spec.StatisticsInterval = &metav1.Duration{Duration: 5 * time.Minute}
+	assert.Equal(t, 5*time.Minute, statisticsInterval(app))
+}
+
+func TestAppStatisticsQueues(t *testing.T) {
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(NewAddressType("orders").Build()).
+		WithSharedAddresses(NewAddressType("events").WithSubscriptions("audit").Build()).
+		WithConsumerOf(
+			NewAddressRef("invoices").WithAppRef("test", "billing").Build(),
+			NewAddressRef("payments").WithSubscriptions("ledger").WithAppRef("test", "billing").Build(),
+		).
+		WithProducerOf(NewAddressRef("notifications").WithAppRef("test", "billing").Build()).
+		Build()
+
+	// invoices is consumed from billing, the app only sends to notifications
+	assert.Equal(t, []appQueue{
+		{address: "events", queue: "audit", multicast: true},
+		{address: "invoices", queue: "invoices"},
+		{address: "orders", queue: "orders"},
+		{address: "payments", queue: "ledger", multicast: true},
+	}, appStatisticsQueues(app))
+}
+
+func TestBrokerAppStatistics_Collected(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithSharedAddresses(NewAddressType("events").WithSubscriptions("audit").Build()).
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		WithDeployedCondition(metav1.ConditionTrue).
+		Build()
+	env := NewTestEnvironment(ns, service, app)
+
+	hosts := withQueueStatistics(t, map[string]mgmt.QueueStatistics{
+		"orders::orders": {MessageCount: 7, ConsumerCount: 2, DeliveringCount: 1, PersistentSize: 2048},
+	}, nil)
+
+	result, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the audit subscription is not deployed yet
+	assert.Equal(t, []v1beta2.BrokerAppAddressStatus{{
+		Address:         "orders",
+		Queue:           "orders",
+		RoutingType:     "ANYCAST",
+		MessageCount:    7,
+		ConsumerCount:   2,
+		DeliveringCount: 1,
+		PersistentSize:  2048,
+	}}, updated.Status.Addresses)
+	assert.NotNil(t, updated.Status.StatisticsTime)
+	assert.Contains(t, *hosts, "svc-ss-0.svc-hdls-svc.default.svc.cluster.local")
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RequeueAfter, DefaultStatisticsInterval)
+
+	// not collected again before the interval elapses
+	hosts = withQueueStatistics(t, nil, errors.New("unreachable"))
+
+	_, updated = reconcileBrokerApp(t, env, app.Name)
+
+	assert.Empty(t, *hosts)
+	assert.Len(t, updated.Status.Addresses, 1)
+}
+
+func TestBrokerAppStatistics_Unreachable(t *testing.T) {
+	ns := "default"
+
+	collected := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	service := NewBrokerService("svc", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		WithDeployedCondition(metav1.ConditionTrue).
+		Build()
+	app.Status.Addresses = []v1beta2.BrokerAppAddressStatus{{Address: "orders", Queue: "orders", RoutingType: "ANYCAST", MessageCount: 3}}
+	app.Status.StatisticsTime = &collected
+	env := NewTestEnvironment(ns, service, app)
+
+	withQueueStatistics(t, nil, errors.New("unreachable"))
+
+	result, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the statistics of the last collection are retained
+	if assert.Len(t, updated.Status.Addresses, 1) {
+		assert.Equal(t, int64(3), updated.Status.Addresses[0].MessageCount)
+	}
+	assert.True(t, collected.Equal(updated.Status.StatisticsTime))
+	assert.LessOrEqual(t, result.RequeueAfter, DefaultStatisticsInterval)
+}
+
+func TestBrokerAppStatistics_NotProvisioned(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, service, app)
+
+	hosts := withQueueStatistics(t, map[string]mgmt.QueueStatistics{"orders::orders": {MessageCount: 1}}, nil)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	assert.Empty(t, *hosts)
+	assert.Nil(t, updated.Status.Addresses)
+	assert.Nil(t, updated.Status.StatisticsTime)
+}
+
+func TestBrokerAppStatistics_Timeout(t *testing.T) {
+	ns := "default"
+
+	original := statisticsTimeout
+	t.Cleanup(func() { statisticsTimeout = original })
+	statisticsTimeout = 0
+
+	service := NewBrokerService("svc", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		WithDeployedCondition(metav1.ConditionTrue).
+		Build()
+	env := NewTestEnvironment(ns, service, app)
+
+	hosts := withQueueStatistics(t, map[string]mgmt.QueueStatistics{"orders::orders": {MessageCount: 1}}, nil)
+
+	result, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the collection is abandoned until the next interval
+	assert.Empty(t, *hosts)
+	assert.Nil(t, updated.Status.StatisticsTime)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+}
+
+func TestAppToServiceHandler_IgnoresStatistics(t *testing.T) {
+	oldApp := NewBrokerApp("orders", "default").WithServiceBinding("svc", "default", "orders-binding-secret", 61616).Build()
+	newApp := oldApp.DeepCopy()
+	collected := metav1.Now()
+	newApp.Status.StatisticsTime = &collected
+	newApp.Status.Addresses = []v1beta2.BrokerAppAddressStatus{{Address: "orders", Queue: "orders", MessageCount: 1}}
+	newApp.ResourceVersion = "2"
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	handler := &appToServiceHandler{}
+
+	handler.Update(context.TODO(), event.UpdateEvent{ObjectOld: oldApp, ObjectNew: newApp}, queue)
+	assert.Zero(t, queue.Len())
+
+	newApp.Labels = map[string]string{"role": "audit"}
+	handler.Update(context.TODO(), event.UpdateEvent{ObjectOld: oldApp, ObjectNew: newApp}, queue)
+	assert.Equal(t, 1, queue.Len())
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// subscriptionSettingsFor returns the settings of the subscription, nil when it has none
+func subscriptionSettingsFor(settings []broker.SubscriptionSettingsType, subscription string) *broker.SubscriptionSettingsType {
+	for index := range settings {
+		if settings[index].Subscription == subscription {
+			return &settings[index]
+		}
+	}
+	return nil
+}
+
+// checkSubscriptionSettings ensures the settings are for distinct subscriptions of the address
+func checkSubscriptionSettings(address string, subscriptions []string, settings []broker.SubscriptionSettingsType) error {
+	seen := map[string]bool{}
+	for _, setting := range settings {
+		if !slices.Contains(subscriptions, setting.Subscription) {
+			return fmt.Errorf("address '%s': subscriptionSettings for '%s' which is not one of its subscriptions", address, setting.Subscription)
+		}
+		if seen[setting.Subscription] {
+			return fmt.Errorf("address '%s': subscriptionSettings for '%s' are declared more than once", address, setting.Subscription)
+		}
+		seen[setting.Subscription] = true
+	}
+	return nil
+}
+
+// validateSubscriptionSettings ensures subscription settings only apply to the subscriptions the app declares
+// and that a subscription declared more than once has the same settings
+func (reconciler *BrokerAppInstanceReconciler) validateSubscriptionSettings() error {
+	var err error
+	declared := map[string]*broker.SubscriptionSettingsType{}
+	declare := func(address string, settings []broker.SubscriptionSettingsType) {
+		for index := range settings {
+			fqqn := address + FQQNSeparator + settings[index].Subscription
+			if previous, found := declared[fqqn]; found && err == nil && !equality.Semantic.DeepEqual(previous, &settings[index]) {
+				err = fmt.Errorf("subscription '%s' is declared with different subscriptionSettings", fqqn)
+			}
+			declared[fqqn] = &settings[index]
+		}
+	}
+	for _, addrTypes := range [][]broker.AddressType{reconciler.instance.Spec.Addresses, reconciler.instance.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			if err == nil {
+				err = checkSubscriptionSettings(addrType.Address, addrType.Subscriptions, addrType.SubscriptionSettings)
+			}
+			declare(addrType.Address, addrType.SubscriptionSettings)
+		}
+	}
+	for _, capability := range reconciler.instance.Spec.Capabilities {
+		for _, addressRef := range capability.ConsumerOf {
+			if err == nil {
+				err = checkSubscriptionSettings(addressRef.Address, addressRef.Subscriptions, addressRef.SubscriptionSettings)
+			}
+			declare(addressRef.Address, addressRef.SubscriptionSettings)
+		}
+		refLists := []capabilityVerb{{field: "producerOf", addressRefs: capability.ProducerOf}}
+		for _, verb := range append(refLists, capabilityVerbs(&capability)...) {
+			for _, addressRef := range verb.addressRefs {
+				if err == nil && len(addressRef.SubscriptionSettings) > 0 {
+					err = fmt.Errorf("address '%s': subscriptionSettings are only allowed in capabilities.consumerOf (not %s)", addressRef.Address, verb.field)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return NewValidationError(broker.ValidConditionAddressTypeError, "%v", err)
+	}
+	return nil
+}
+
+// queueAttribute is a queue attribute of subscription settings with the value that resets it,
+// attributes that are only applied when the queue is created are never reset
+type queueAttribute struct {
+	name  string
+	value func(settings *broker.SubscriptionSettingsType) *string
+	reset *string
+}
+
+func formatValue[T any](value *T) *string {
+	if value == nil {
+		return nil
+	}
+	formatted := fmt.Sprint(*value)
+	return &formatted
+}
+
+func resetValue(value string) *string {
+	return &value
+}
+
+var queueAttributes = []queueAttribute{
+	{"filterString", func(s *broker.SubscriptionSettingsType) *string { return s.FilterString }, resetValue("")},
+	{"exclusive", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.Exclusive) }, resetValue("false")},
+	{"lastValue", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.LastValue) }, nil},
+	{"lastValueKey", func(s *broker.SubscriptionSettingsType) *string { return s.LastValueKey }, nil},
+	{"nonDestructive", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.NonDestructive) }, resetValue("false")},
+	{"ringSize", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.RingSize) }, resetValue("-1")},
+	{"maxConsumers", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.MaxConsumers) }, resetValue("-1")},
+	{"consumersBeforeDispatch", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.ConsumersBeforeDispatch) }, resetValue("0")},
+	{"delayBeforeDispatch", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.DelayBeforeDispatch) }, resetValue("-1")},
+	{"groupRebalance", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.GroupRebalance) }, resetValue("false")},
+	{"groupRebalancePauseDispatch", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.GroupRebalancePauseDispatch) }, resetValue("false")},
+	{"groupBuckets", func(s *broker.SubscriptionSettingsType) *string { return formatValue(s.GroupBuckets) }, resetValue("-1")},
+	{"groupFirstKey", func(s *broker.SubscriptionSettingsType) *string { return s.GroupFirstKey }, resetValue("")},
+}
+
+// addSubscriptionSettingsProperties generates the queue attributes of a subscription. The broker retains
+// the attributes of an existing queue that are no longer configured, so the attributes previously
+// generated for the queue are reset
+func addSubscriptionSettingsProperties(props map[string]string, address string, queue string, settings *broker.SubscriptionSettingsType, previous string) {
+	prefix := fmt.Sprintf("addressConfigurations.\"%s\".queueConfigs.\"%s\".", address, queue)
+	for _, attribute := range queueAttributes {
+		var value *string
+		if settings != nil {
+			value = attribute.value(settings)
+		}
+		if value == nil && attribute.reset != nil && strings.Contains("\n"+previous, "\n"+prefix+attribute.name+"=") {
+			value = attribute.reset
+		}
+		if value != nil {
+			props[fmt.Sprintf("%s%s=%s\n", prefix, attribute.name, escapePropertyValue(*value))] = ""
+		}
+	}
+}
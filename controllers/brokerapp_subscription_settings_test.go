@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func subscriptionSettingsApp(settings ...v1beta2.SubscriptionSettingsType) *v1beta2.BrokerApp {
+	events := NewAddressType("events").WithSubscriptions("audit", "billing").Build()
+	events.SubscriptionSettings = settings
+	return NewBrokerApp("app", "test").
+		WithAddresses(events).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("audit").Build()).
+		Build()
+}
+
+func TestBrokerServiceSubscriptionSettings_Properties(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	filter := `region = 'eu\west'`
+	exclusive := true
+	lastValue := true
+	lastValueKey := "sku"
+	ringSize := int64(100)
+	app := subscriptionSettingsApp(
+		v1beta2.SubscriptionSettingsType{Subscription: "audit", FilterString: &filter, Exclusive: &exclusive, RingSize: &ringSize},
+		v1beta2.SubscriptionSettingsType{Subscription: "billing", LastValue: &lastValue, LastValueKey: &lastValueKey},
+	)
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-app-capabilities.properties"])
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".filterString=region = 'eu\\\\west'\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".exclusive=true\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".ringSize=100\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"billing\".lastValue=true\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"billing\".lastValueKey=sku\n")
+	assert.NotContains(t, props, "addressConfigurations.\"events\".queueConfigs.\"billing\".exclusive")
+}
+
+func TestBrokerServiceSubscriptionSettings_MultiLineValues(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	filter := "region = 'eu'\nsecurityRoles.\"#\".attacker.send=true\n"
+	groupFirstKey := "first\r\nsecurityRoles.\"#\".attacker.consume=true"
+	app := subscriptionSettingsApp(
+		v1beta2.SubscriptionSettingsType{Subscription: "audit", FilterString: &filter, GroupFirstKey: &groupFirstKey},
+	)
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	// a line break in a value never starts another property
+	props := string(secret.Data["test-app-capabilities.properties"])
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".filterString=region = 'eu'\\nsecurityRoles.\"#\".attacker.send=true\\n\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".groupFirstKey=first\\r\\nsecurityRoles.\"#\".attacker.consume=true\n")
+	for _, line := range strings.Split(props, "\n") {
+		assert.False(t, strings.HasPrefix(line, "securityRoles.\"#\".attacker"), line)
+	}
+}
+
+func TestBrokerServiceSubscriptionSettings_ResetOnReconcile(t *testing.T) {
+	ns := "default"
+	svcName := "svc"
+	exclusive := true
+	lastValue := true
+	app := subscriptionSettingsApp(
+		v1beta2.SubscriptionSettingsType{Subscription: "audit", Exclusive: &exclusive},
+		v1beta2.SubscriptionSettingsType{Subscription: "billing", LastValue: &lastValue},
+	)
+	app.Namespace = ns
+	app.Status.Service = &v1beta2.BrokerServiceBindingStatus{Name: svcName, Namespace: ns, Secret: "app-binding-secret", AssignedPort: 61616}
+	env := NewTestEnvironment(ns, withOperatorCA(t, ns), NewBrokerService(svcName, ns).Build(), app)
+
+	reconcileBrokerService(t, env, svcName)
+	secret, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	props := string(secret.Data[AppIdentityPrefixed(app, "capabilities.properties")])
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".exclusive=true\n")
+
+	// attributes that are no longer configured are reset, lastValue only applies when the queue is created
+	current := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: app.Name, Namespace: ns}, current))
+	current.Spec.Addresses[0].SubscriptionSettings = nil
+	assert.NoError(t, env.Client.Update(context.TODO(), current))
+
+	reconcileBrokerService(t, env, svcName)
+	secret, err = mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	props = string(secret.Data[AppIdentityPrefixed(app, "capabilities.properties")])
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".exclusive=false\n")
+	assert.NotContains(t, props, "addressConfigurations.\"events\".queueConfigs.\"billing\".lastValue")
+}
+
+func TestAddSubscriptionSettingsProperties_ResetFirstLine(t *testing.T) {
+	props := map[string]string{}
+	addSubscriptionSettingsProperties(props, "events", "audit", nil, "addressConfigurations.\"events\".queueConfigs.\"audit\".ringSize=100\n")
+	assert.Contains(t, props, "addressConfigurations.\"events\".queueConfigs.\"audit\".ringSize=-1\n")
+
+	// a matching suffix of another line is not an attribute of the queue
+	props = map[string]string{}
+	addSubscriptionSettingsProperties(props, "events", "audit", nil, "x.addressConfigurations.\"events\".queueConfigs.\"audit\".ringSize=100\n")
+	assert.Empty(t, props)
+}
+
+func TestSubscriptionSettings_Validation(t *testing.T) {
+	ns := "default"
+	exclusive := true
+	shared := false
+
+	conflicting := subscriptionSettingsApp(v1beta2.SubscriptionSettingsType{Subscription: "audit", Exclusive: &exclusive})
+	conflicting.Spec.Capabilities[0].ConsumerOf[0].SubscriptionSettings = []v1beta2.SubscriptionSettingsType{{Subscription: "audit", Exclusive: &shared}}
+
+	producer := NewBrokerApp("app", ns).WithProducerOf(NewAddressRef("events").Build()).Build()
+	producer.Spec.Capabilities[0].ProducerOf[0].SubscriptionSettings = []v1beta2.SubscriptionSettingsType{{Subscription: "audit"}}
+
+	for _, tc := range []struct {
+		name    string
+		app     *v1beta2.BrokerApp
+		message string
+	}{
+		{"unknown",
+			subscriptionSettingsApp(v1beta2.SubscriptionSettingsType{Subscription: "other"}),
+			"address 'events': subscriptionSettings for 'other' which is not one of its subscriptions"},
+		{"duplicate",
+			subscriptionSettingsApp(v1beta2.SubscriptionSettingsType{Subscription: "audit"}, v1beta2.SubscriptionSettingsType{Subscription: "audit"}),
+			"address 'events': subscriptionSettings for 'audit' are declared more than once"},
+		{"conflicting", conflicting,
+			"subscription 'events::audit' is declared with different subscriptionSettings"},
+		{"producer", producer,
+			"address 'events': subscriptionSettings are only allowed in capabilities.consumerOf (not producerOf)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.app.Namespace = ns
+			env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), tc.app)
+
+			_, updated := reconcileBrokerApp(t, env, tc.app.Name)
+			assert.Nil(t, updated.Status.Service)
+			valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+			if assert.NotNil(t, valid) {
+				assert.Equal(t, metav1.ConditionFalse, valid.Status)
+				assert.Contains(t, valid.Message, tc.message)
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,11 +29,7 @@ type TestEnvironment struct {
 // SetupBrokerAppIndexer adds the status.service field indexer to avoid duplication in tests
 func SetupBrokerAppIndexer(builder *fake.ClientBuilder) *fake.ClientBuilder {
 	return builder.WithIndex(&v1beta2.BrokerApp{}, common.AppServiceBindingField, func(obj client.Object) []string {
-		app := obj.(*v1beta2.BrokerApp)
-		if app.Status.Service != nil {
-			return []string{app.Status.Service.Key()}
-		}
-		return nil
+		return AppServiceBindingKeys(obj.(*v1beta2.BrokerApp))
 	})
 }
 
@@ -39,6 +38,8 @@ func NewTestEnvironment(namespace string, objects ...client.Object) *TestEnviron
 	scheme := runtime.NewScheme()
 	_ = v1beta2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = routev1.AddToScheme(scheme)
 
 	// Add namespace object if not already included
 	hasNamespace := false
@@ -63,8 +64,14 @@ func NewTestEnvironment(namespace string, objects ...client.Object) *TestEnviron
 		}
 	}
 
+	// the api server serves ingresses and routes
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(netv1.SchemeGroupVersion.WithKind("Ingress"), meta.RESTScopeNamespace)
+	restMapper.Add(routev1.GroupVersion.WithKind("Route"), meta.RESTScopeNamespace)
+
 	cl := SetupBrokerAppIndexer(fake.NewClientBuilder().
 		WithScheme(scheme).
+		WithRESTMapper(restMapper).
 		WithObjects(objects...).
 		WithStatusSubresource(statusObjs...)).
 		Build()
@@ -122,6 +129,44 @@ func (b *BrokerServiceBuilder) WithMemoryLimit(memory string) *BrokerServiceBuil
 	return b
 }
 
+func (b *BrokerServiceBuilder) WithResourceLimit(name corev1.ResourceName, quantity string) *BrokerServiceBuilder {
+	if b.service.Spec.Resources.Limits == nil {
+		b.service.Spec.Resources.Limits = corev1.ResourceList{}
+	}
+	b.service.Spec.Resources.Limits[name] = resource.MustParse(quantity)
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithCapacity(capacity v1beta2.BrokerServiceCapacityType) *BrokerServiceBuilder {
+	b.service.Spec.Capacity = &capacity
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithPlacementStrategy(strategy v1beta2.PlacementStrategy) *BrokerServiceBuilder {
+	b.service.Spec.PlacementStrategy = strategy
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithSchedulingPolicy(policy v1beta2.SchedulingPolicy) *BrokerServiceBuilder {
+	b.service.Spec.SchedulingPolicy = policy
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithPeers(peers int32) *BrokerServiceBuilder {
+	b.service.Spec.Peers = &peers
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithPortRange(start, end int32, exclusions ...int32) *BrokerServiceBuilder {
+	b.service.Spec.PortRange = &v1beta2.BrokerServicePortRangeType{Start: start, End: end, Exclusions: exclusions}
+	return b
+}
+
+func (b *BrokerServiceBuilder) WithExpose(mode v1beta2.BrokerServiceExposeMode, ingressDomain string) *BrokerServiceBuilder {
+	b.service.Spec.Expose = &v1beta2.BrokerServiceExposeType{Mode: mode, IngressDomain: ingressDomain}
+	return b
+}
+
 func (b *BrokerServiceBuilder) WithProvisionedApp(appIdentity string) *BrokerServiceBuilder {
 	b.service.Status.ProvisionedApps = append(b.service.Status.ProvisionedApps, appIdentity)
 	return b
@@ -174,6 +219,11 @@ func (b *BrokerAppBuilder) WithServiceSelector(selector *metav1.LabelSelector) *
 	return b
 }
 
+func (b *BrokerAppBuilder) WithServiceSelectorExpression(expression string) *BrokerAppBuilder {
+	b.app.Spec.ServiceSelectorExpression = expression
+	return b
+}
+
 func (b *BrokerAppBuilder) WithConsumerOf(addresses ...v1beta2.AddressRef) *BrokerAppBuilder {
 	if len(b.app.Spec.Capabilities) == 0 {
 		b.app.Spec.Capabilities = []v1beta2.AppCapabilityType{{}}
@@ -208,6 +258,24 @@ func (b *BrokerAppBuilder) WithMemoryRequest(memory string) *BrokerAppBuilder {
 	return b
 }
 
+func (b *BrokerAppBuilder) WithResourceRequest(name corev1.ResourceName, quantity string) *BrokerAppBuilder {
+	if b.app.Spec.Resources.Requests == nil {
+		b.app.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	b.app.Spec.Resources.Requests[name] = resource.MustParse(quantity)
+	return b
+}
+
+func (b *BrokerAppBuilder) WithMaxConnections(connections int32) *BrokerAppBuilder {
+	b.app.Spec.MaxConnections = &connections
+	return b
+}
+
+func (b *BrokerAppBuilder) WithPlacementStrategy(strategy v1beta2.PlacementStrategy) *BrokerAppBuilder {
+	b.app.Spec.PlacementStrategy = strategy
+	return b
+}
+
 func (b *BrokerAppBuilder) WithServiceBinding(name, namespace, secret string, port int32) *BrokerAppBuilder {
 	b.app.Status.Service = &v1beta2.BrokerServiceBindingStatus{
 		Name:         name,
@@ -218,6 +286,37 @@ func (b *BrokerAppBuilder) WithServiceBinding(name, namespace, secret string, po
 	return b
 }
 
+func (b *BrokerAppBuilder) WithServicePeer(peer int32) *BrokerAppBuilder {
+	b.app.Status.Service.Peer = peer
+	return b
+}
+
+// WithDeployedCondition records the Deployed condition of the last reconcile
+func (b *BrokerAppBuilder) WithDeployedCondition(status metav1.ConditionStatus) *BrokerAppBuilder {
+	meta.SetStatusCondition(&b.app.Status.Conditions, metav1.Condition{
+		Type:   v1beta2.DeployedConditionType,
+		Status: status,
+		Reason: v1beta2.ReadyConditionReason,
+	})
+	return b
+}
+
+// WithMigrationFrom records a previous binding the app is migrating messages from
+func (b *BrokerAppBuilder) WithMigrationFrom(name, namespace string, port int32, peer int32) *BrokerAppBuilder {
+	b.app.Status.Migration = &v1beta2.BrokerAppMigrationStatus{
+		From: v1beta2.BrokerServiceBindingStatus{
+			Name:         name,
+			Namespace:    namespace,
+			Secret:       BindingsSecretName(b.app.Name),
+			AssignedPort: port,
+			Peer:         peer,
+		},
+		Reason:    "test",
+		StartTime: metav1.Now(),
+	}
+	return b
+}
+
 func (b *BrokerAppBuilder) WithCapabilities(capabilities ...v1beta2.AppCapabilityType) *BrokerAppBuilder {
 	b.app.Spec.Capabilities = capabilities
 	return b
@@ -280,6 +379,11 @@ func (a *AddressTypeBuilder) WithPubSub(pubSub bool) *AddressTypeBuilder {
 	return a
 }
 
+func (a *AddressTypeBuilder) WithSettings(settings v1beta2.AddressPolicyType) *AddressTypeBuilder {
+	a.addrType.Settings = &settings
+	return a
+}
+
 func (a *AddressTypeBuilder) Build() v1beta2.AddressType {
 	return a.addrType
 }
@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-broker-arkmq-org-v1beta2-brokerapp,mutating=false,failurePolicy=fail,sideEffects=None,groups=broker.arkmq.org,resources=brokerapps,verbs=create;update,versions=v1beta2,name=vbrokerapp.broker.arkmq.org,admissionReviewVersions=v1
+
+// SetupWebhookWithManager serves the validating webhook of BrokerApps, that rejects the specs
+// the reconciler would report with a Valid=False condition
+func (r *BrokerAppReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&broker.BrokerApp{}).
+		WithValidator(&brokerAppValidator{reconciler: r}).
+		Complete()
+}
+
+type brokerAppValidator struct {
+	reconciler *BrokerAppReconciler
+}
+
+var _ admission.CustomValidator = &brokerAppValidator{}
+
+func (validator *brokerAppValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	app, ok := obj.(*broker.BrokerApp)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerApp but got a %T", obj)
+	}
+	return nil, validator.validate(app)
+}
+
+func (validator *brokerAppValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	previous, ok := oldObj.(*broker.BrokerApp)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerApp but got a %T", oldObj)
+	}
+	app, ok := newObj.(*broker.BrokerApp)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerApp but got a %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(previous.Spec, app.Spec) {
+		// metadata changes, such as removing finalizers, are not blocked by a spec that is no longer valid
+		return nil, nil
+	}
+	return nil, validator.validate(app)
+}
+
+func (validator *brokerAppValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (validator *brokerAppValidator) validate(app *broker.BrokerApp) error {
+	instance := BrokerAppInstanceReconciler{
+		BrokerAppReconciler: validator.reconciler,
+		instance:            app,
+		status:              app.Status.DeepCopy(),
+	}
+	return instance.validateSpec()
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The default wildcard syntax of the broker, words are separated by the delimiter
+const (
+	WildcardDelimiter = "."
+	WildcardAnyWord   = "*"
+	WildcardAnyWords  = "#"
+)
+
+// isWildcardAddress is true when a word of the address is a wildcard
+func isWildcardAddress(address string) bool {
+	for _, word := range strings.Split(address, WildcardDelimiter) {
+		if word == WildcardAnyWord || word == WildcardAnyWords {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatches is true when the address matches the wildcard pattern, '*' matches exactly one word
+// and '#' matches any number of words
+func wildcardMatches(pattern string, address string) bool {
+	return wordsMatch(strings.Split(pattern, WildcardDelimiter), strings.Split(address, WildcardDelimiter))
+}
+
+func wordsMatch(pattern []string, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+	switch pattern[0] {
+	case WildcardAnyWords:
+		for skip := 0; skip <= len(words); skip++ {
+			if wordsMatch(pattern[1:], words[skip:]) {
+				return true
+			}
+		}
+		return false
+	case WildcardAnyWord:
+		return len(words) > 0 && wordsMatch(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && wordsMatch(pattern[1:], words[1:])
+	}
+}
+
+// wildcardPatterns returns the wildcard addresses the app consumes from
+func wildcardPatterns(app *broker.BrokerApp) []string {
+	var patterns []string
+	seen := map[string]bool{}
+	for _, capability := range app.Spec.Capabilities {
+		for _, addressRef := range capability.ConsumerOf {
+			if isWildcardAddress(addressRef.Address) && !seen[addressRef.Address] {
+				seen[addressRef.Address] = true
+				patterns = append(patterns, addressRef.Address)
+			}
+		}
+	}
+	return patterns
+}
+
+// validateWildcardAddresses ensures wildcards are only used to subscribe, a wildcard address receives the
+// messages of the addresses it matches so it is never shared, produced to or consumed from with anycast semantics
+func (reconciler *BrokerAppInstanceReconciler) validateWildcardAddresses() error {
+	for _, addrTypes := range [][]broker.AddressType{reconciler.instance.Spec.Addresses, reconciler.instance.Spec.SharedAddresses} {
+		for _, addrType := range addrTypes {
+			if isWildcardAddress(addrType.Address) {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': wildcards are only allowed in capabilities.consumerOf", addrType.Address)
+			}
+		}
+	}
+	for _, policyAddress := range collectPolicyAddresses(reconciler.instance) {
+		if isWildcardAddress(policyAddress) {
+			return NewValidationError(broker.ValidConditionAddressTypeError,
+				"dead letter or expiry address '%s' cannot use wildcards", policyAddress)
+		}
+	}
+	for _, capability := range reconciler.instance.Spec.Capabilities {
+		for _, addressRef := range capability.ProducerOf {
+			if isWildcardAddress(addressRef.Address) {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': wildcards are only allowed in capabilities.consumerOf", addressRef.Address)
+			}
+		}
+		for _, addressRef := range capability.ConsumerOf {
+			if !isWildcardAddress(addressRef.Address) {
+				continue
+			}
+			if addressRef.AppNamespace != "" || addressRef.AppName != "" {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': a wildcard matches the shared addresses of any app, appNamespace and appName must be empty",
+					addressRef.Address)
+			}
+			if first := strings.SplitN(addressRef.Address, WildcardDelimiter, 2)[0]; first == WildcardAnyWord || first == WildcardAnyWords {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': the first word of a wildcard address must not be a wildcard", addressRef.Address)
+			}
+			if !isMulticastAddress(addressRef.PubSub, addressRef.Subscriptions) {
+				return NewValidationError(broker.ValidConditionAddressTypeError,
+					"address '%s': wildcard consumers must specify at least one subscription queue name", addressRef.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// checkWildcardClash checks that the wildcard addresses of the consumer only match, on a service, addresses
+// of the consumer or addresses shared with it with consume access, owners maps the addresses to their owner
+func (reconciler *BrokerAppInstanceReconciler) checkWildcardClash(consumer *broker.BrokerApp, owners map[string]string) error {
+	consumerKey := consumer.Namespace + "/" + consumer.Name
+	for _, pattern := range wildcardPatterns(consumer) {
+		for address, owner := range owners {
+			if owner == consumerKey || isWildcardAddress(address) || !wildcardMatches(pattern, address) {
+				continue
+			}
+			if err := reconciler.wildcardAccess(consumer, owner, address); err != nil {
+				return fmt.Errorf("wildcard address '%s' of %s matches address '%s' of %s: %v",
+					pattern, consumerKey, address, owner, err)
+			}
+		}
+	}
+	return nil
+}
+
+// wildcardAccess returns why the consumer may not receive the messages of the address of the owner
+func (reconciler *BrokerAppInstanceReconciler) wildcardAccess(consumer *broker.BrokerApp, ownerKey string, address string) error {
+	owner := reconciler.instance
+	if ownerKey != reconciler.instance.Namespace+"/"+reconciler.instance.Name {
+		namespace, name, _ := strings.Cut(ownerKey, "/")
+		owner = &broker.BrokerApp{}
+		if err := reconciler.Client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, owner); err != nil {
+			return fmt.Errorf("failed to get the owner: %v", err)
+		}
+	}
+	if !sharesAddress(owner, address) {
+		return fmt.Errorf("the address is not shared")
+	}
+	return sharedAddressAccess(consumer, owner, address, AddressPermissionConsume)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestWildcardMatches(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, address string
+		matches          bool
+	}{
+		{"mytopic.*", "mytopic.a", true},
+		{"mytopic.*", "mytopic", false},
+		{"mytopic.*", "mytopic.a.b", false},
+		{"mytopic.#", "mytopic", true},
+		{"mytopic.#", "mytopic.a.b", true},
+		{"mytopic.#.b", "mytopic.b", true},
+		{"mytopic.#.b", "mytopic.a.c", false},
+		{"*.orders", "eu.orders", true},
+		{"#", "anything.at.all", true},
+		{"mytopic", "mytopic", true},
+		{"mytopic.*", "other.a", false},
+	} {
+		assert.Equal(t, tc.matches, wildcardMatches(tc.pattern, tc.address), "%s ~ %s", tc.pattern, tc.address)
+	}
+	assert.True(t, isWildcardAddress("mytopic.#"))
+	assert.False(t, isWildcardAddress("my*topic.a#"))
+}
+
+func TestWildcardAddresses_Validation(t *testing.T) {
+	ns := "default"
+	for _, tc := range []struct {
+		name    string
+		app     *v1beta2.BrokerApp
+		message string
+	}{
+		{"producer",
+			NewBrokerApp("producer", ns).WithProducerOf(NewAddressRef("mytopic.*").Build()).Build(),
+			"address 'mytopic.*': wildcards are only allowed in capabilities.consumerOf"},
+		{"shared",
+			NewBrokerApp("shared", ns).WithSharedAddresses(NewAddressType("mytopic.#").WithPubSub(true).Build()).Build(),
+			"address 'mytopic.#': wildcards are only allowed in capabilities.consumerOf"},
+		{"anycast",
+			NewBrokerApp("anycast", ns).WithConsumerOf(NewAddressRef("mytopic.*").Build()).Build(),
+			"wildcard consumers must specify at least one subscription queue name"},
+		{"leading",
+			NewBrokerApp("leading", ns).WithConsumerOf(NewAddressRef("#").WithSubscriptions("everything").Build()).Build(),
+			"address '#': the first word of a wildcard address must not be a wildcard"},
+		{"ref",
+			NewBrokerApp("ref", ns).WithConsumerOf(NewAddressRef("mytopic.*").WithSubscriptions("sub").WithAppRef(ns, "owner").Build()).Build(),
+			"a wildcard matches the shared addresses of any app, appNamespace and appName must be empty"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), tc.app)
+
+			_, updated := reconcileBrokerApp(t, env, tc.app.Name)
+			assert.Nil(t, updated.Status.Service)
+			valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+			if assert.NotNil(t, valid) {
+				assert.Equal(t, metav1.ConditionFalse, valid.Status)
+				assert.Contains(t, valid.Message, tc.message)
+			}
+		})
+	}
+}
+
+// wildcardOwner is bound to svc with a private address and a shared address that only audit apps consume
+func wildcardOwner(ns string) *v1beta2.BrokerApp {
+	shared := NewAddressType("mytopic.shared").WithPubSub(true).Build()
+	shared.Access = &v1beta2.AddressAccessPolicyType{
+		Consume: &v1beta2.AddressAccessRuleType{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "audit"}}},
+	}
+	return NewBrokerApp("owner", ns).
+		WithAddresses(NewAddressType("private.orders").Build()).
+		WithSharedAddresses(shared).
+		WithServiceBinding("svc", ns, "owner-binding-secret", 61616).
+		Build()
+}
+
+func TestWildcardAddresses_Clash(t *testing.T) {
+	ns := "default"
+
+	for _, tc := range []struct {
+		name    string
+		pattern string
+		labels  map[string]string
+		message string
+	}{
+		{"private", "private.*", nil, "wildcard address 'private.*' of default/subscriber matches address 'private.orders' of default/owner: the address is not shared"},
+		{"denied", "mytopic.#", nil, "does not grant consume on shared address 'mytopic.shared' to app default/subscriber"},
+		{"granted", "mytopic.#", map[string]string{"role": "audit"}, ""},
+		{"disjoint", "other.*", nil, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			subscriber := NewBrokerApp("subscriber", ns).
+				WithConsumerOf(NewAddressRef(tc.pattern).WithSubscriptions("sub").Build()).
+				Build()
+			subscriber.Labels = tc.labels
+			env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), wildcardOwner(ns), subscriber)
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: subscriber.Name, Namespace: ns}}
+			_, err := env.Reconciler.Reconcile(context.TODO(), req)
+
+			updated := &v1beta2.BrokerApp{}
+			assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+			if tc.message == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, updated.Status.Service)
+				return
+			}
+			assert.Error(t, err)
+			assert.Nil(t, updated.Status.Service)
+			deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+			if assert.NotNil(t, deployed) {
+				assert.Contains(t, deployed.Message, tc.message)
+			}
+		})
+	}
+}
+
+func TestWildcardAddresses_ClashWithBoundWildcard(t *testing.T) {
+	ns := "default"
+
+	subscriber := NewBrokerApp("subscriber", ns).
+		WithConsumerOf(NewAddressRef("mytopic.*").WithSubscriptions("sub").Build()).
+		WithServiceBinding("svc", ns, "subscriber-binding-secret", 61616).
+		Build()
+	producer := NewBrokerApp("producer", ns).
+		WithProducerOf(NewAddressRef("mytopic.a").Build()).
+		Build()
+	env := NewTestEnvironment(ns, NewBrokerService("svc", ns).Build(), subscriber, producer)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: producer.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Contains(t, deployed.Message, "wildcard address 'mytopic.*' of default/subscriber matches address 'mytopic.a' of default/producer")
+	}
+}
+
+func TestBrokerServiceWildcard_Roles(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+	app := NewBrokerApp("mqtt", "test").
+		WithConsumerOf(NewAddressRef("mytopic.*").WithSubscriptions("my-client.mytopic.*").Build()).
+		Build()
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-mqtt-capabilities.properties"])
+	assert.Contains(t, props, "addressConfigurations.\"mytopic.*\".routingTypes=MULTICAST\n")
+	assert.Contains(t, props, "addressConfigurations.\"mytopic.*\".queueConfigs.\"my-client.mytopic.*\".routingType=MULTICAST\n")
+	// consume is granted on the wildcard address, the fqqn of a wildcard address is not matched by the security store
+	assert.Contains(t, props, "securityRoles.\"mytopic.*\".\"test-mqtt-consumer\".consume=true\n")
+	assert.NotContains(t, props, "securityRoles.\"mytopic.*\\:\\:my-client.mytopic.*\"")
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"reflect"
 	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 
 	v1beta2 "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	operatormetrics "github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
 )
@@ -46,6 +48,7 @@ type BrokerClusterReconciler struct {
 	Scheme        *runtime.Scheme
 	log           logr.Logger
 	isOnOpenShift bool
+	recorder      *EventRecorder
 }
 
 func NewBrokerClusterReconciler(cluster cluster.Cluster, logger logr.Logger, isOpenShift bool) *BrokerClusterReconciler {
@@ -54,6 +57,7 @@ func NewBrokerClusterReconciler(cluster cluster.Cluster, logger logr.Logger, isO
 		Client:        cluster.GetClient(),
 		Scheme:        cluster.GetScheme(),
 		log:           logger,
+		recorder:      NewEventRecorder(cluster.GetEventRecorderFor("brokercluster-controller")),
 	}
 }
 
@@ -61,7 +65,8 @@ func NewBrokerClusterReconciler(cluster cluster.Cluster, logger logr.Logger, isO
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerclusters/finalizers,verbs=update
 
-func (r *BrokerClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *BrokerClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	defer operatormetrics.ObserveReconcile("brokercluster", time.Now(), &reconcileErr)
 	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "Reconciling", "BrokerCluster")
 
 	customResource := &v1beta2.BrokerCluster{}
@@ -154,6 +159,9 @@ func (r *BrokerClusterReconciler) UpdateBrokerClusterCRStatus(desired *v1beta2.B
 		return err
 	}
 
+	r.recorder.recordStatusEvents(desired, current.Status.Conditions, desired.Status.Conditions,
+		current.Status.Version.BrokerVersion, desired.Status.Version.BrokerVersion)
+
 	if !EqualBrokerClusterCRStatus(&desired.Status, &current.Status) {
 		r.log.V(1).Info("cr.status update", "Namespace", desired.Namespace, "Name", desired.Name, "Observed status", desired.Status)
 		return resources.UpdateStatus(client, desired)
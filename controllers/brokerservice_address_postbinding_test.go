@@ -163,6 +163,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -208,6 +210,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -380,6 +384,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -429,6 +435,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -595,6 +603,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -642,6 +652,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -898,6 +910,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(app1CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app1CertName
 				candidate.Spec.CommonName = app1Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -945,6 +959,8 @@ var _ = Describe("broker-service address post-binding validation", func() {
 			InstallCert(app2CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app2CertName
 				candidate.Spec.CommonName = app2Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -0,0 +1,177 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestProcessCapabilities_AddressSettings(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+			DeadLetterAddress:    ptr.To("orders.dla"),
+			MaxDeliveryAttempts:  ptr.To(int32(5)),
+			RedeliveryDelay:      ptr.To(int64(1000)),
+			RedeliveryMultiplier: ptr.To("2"),
+			MaxRedeliveryDelay:   ptr.To(int64(60000)),
+			ExpiryAddress:        ptr.To("orders.expired"),
+			ExpiryDelay:          ptr.To(int64(300000)),
+			MaxSizeBytes:         ptr.To("10M"),
+			AddressFullPolicy:    ptr.To("PAGE"),
+		}).Build()).
+		WithConsumerOf(NewAddressRef("orders").Build()).
+		Build()
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-orders-app-capabilities.properties"])
+
+	assert.Contains(t, props, "addressSettings.\"orders\".deadLetterAddress=orders.dla\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".maxDeliveryAttempts=5\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".redeliveryDelay=1000\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".redeliveryMultiplier=2\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".maxRedeliveryDelay=60000\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".expiryAddress=orders.expired\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".expiryDelay=300000\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".maxSizeBytes=10M\n")
+	assert.Contains(t, props, "addressSettings.\"orders\".addressFullPolicy=PAGE\n")
+
+	// dead letter and expiry addresses are declared, with a queue the app consumes from
+	for _, policyAddress := range []string{"orders.dla", "orders.expired"} {
+		assert.Contains(t, props, "addressConfigurations.\""+policyAddress+"\".routingTypes=ANYCAST\n")
+		assert.Contains(t, props, "addressConfigurations.\""+policyAddress+"\".queueConfigs.\""+policyAddress+"\".routingType=ANYCAST\n")
+		assert.Contains(t, props, "securityRoles.\""+policyAddress+"\".\"test-orders-app-consumer\".consume=true\n")
+		assert.NotContains(t, props, "securityRoles.\""+policyAddress+"\".\"test-orders-app-producer\"")
+		assert.Contains(t, props, "securityRoles.\"mops.queue."+policyAddress+"\".\"test-orders-app-metrics\".view=true\n")
+	}
+}
+
+func TestProcessCapabilities_NoAddressSettings(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(NewAddressType("orders").Build()).
+		Build()
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-orders-app-capabilities.properties"])
+	assert.NotContains(t, props, "addressSettings.")
+}
+
+func TestProcessCapabilities_SharedDeadLetterAddress(t *testing.T) {
+	reconciler := BrokerServiceInstanceReconcilerForTest()
+	secret := CreateSecret("test-secret", "test")
+
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(
+			NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{DeadLetterAddress: ptr.To("dla")}).Build(),
+		).
+		WithSharedAddresses(
+			NewAddressType("events").WithPubSub(true).WithSettings(v1beta2.AddressPolicyType{DeadLetterAddress: ptr.To("dla")}).Build(),
+		).
+		Build()
+
+	assert.NoError(t, reconciler.processCapabilities(secret, app))
+
+	props := string(secret.Data["test-orders-app-capabilities.properties"])
+	assert.Contains(t, props, "addressSettings.\"orders\".deadLetterAddress=dla\n")
+	assert.Contains(t, props, "addressSettings.\"events\".deadLetterAddress=dla\n")
+	assert.Contains(t, props, "addressConfigurations.\"dla\".routingTypes=ANYCAST\n")
+	assert.Contains(t, props, "securityRoles.\"dla\".\"test-orders-app-consumer\".consume=true\n")
+}
+
+func TestCollectOwnedAddresses_PolicyAddresses(t *testing.T) {
+	app := NewBrokerApp("orders-app", "test").
+		WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+			DeadLetterAddress: ptr.To("orders.dla"),
+			ExpiryAddress:     ptr.To("orders.expired"),
+		}).Build()).
+		Build()
+
+	assert.Equal(t, map[string]bool{"orders": true, "orders.dla": true, "orders.expired": true}, collectOwnedAddresses(app))
+}
+
+func TestValidateAddressSettings(t *testing.T) {
+	validate := func(app *v1beta2.BrokerApp) error {
+		reconciler := &BrokerAppInstanceReconciler{instance: app}
+		return reconciler.validateAddressSettings()
+	}
+
+	assertInvalid := func(t *testing.T, err error, contains string) {
+		t.Helper()
+		validErr, ok := err.(*ValidationError)
+		if assert.True(t, ok, "expected ValidationError") {
+			assert.Equal(t, v1beta2.ValidConditionAddressTypeError, validErr.ConditionReason())
+			assert.Contains(t, validErr.Message, contains)
+		}
+	}
+
+	t.Run("accepts dead letter and expiry addresses", func(t *testing.T) {
+		app := NewBrokerApp("app", "test").
+			WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+				DeadLetterAddress: ptr.To("orders.dla"),
+				ExpiryAddress:     ptr.To("orders.expired"),
+			}).Build()).
+			Build()
+		assert.NoError(t, validate(app))
+	})
+
+	t.Run("rejects the address itself", func(t *testing.T) {
+		app := NewBrokerApp("app", "test").
+			WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+				DeadLetterAddress: ptr.To("orders"),
+			}).Build()).
+			Build()
+		assertInvalid(t, validate(app), "settings.deadLetterAddress cannot be the address itself")
+	})
+
+	t.Run("rejects FQQN", func(t *testing.T) {
+		app := NewBrokerApp("app", "test").
+			WithSharedAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+				ExpiryAddress: ptr.To("expired::q"),
+			}).Build()).
+			Build()
+		assertInvalid(t, validate(app), "settings.expiryAddress should not use FQQN format")
+	})
+
+	t.Run("rejects pubSub dead letter address", func(t *testing.T) {
+		app := NewBrokerApp("app", "test").
+			WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+				DeadLetterAddress: ptr.To("events"),
+			}).Build()).
+			WithConsumerOf(NewAddressRef("events").WithSubscriptions("sub").Build()).
+			Build()
+		assertInvalid(t, validate(app), "'events' is used with pubSub semantics")
+	})
+
+	t.Run("rejects max redelivery delay below redelivery delay", func(t *testing.T) {
+		app := NewBrokerApp("app", "test").
+			WithAddresses(NewAddressType("orders").WithSettings(v1beta2.AddressPolicyType{
+				RedeliveryDelay:    ptr.To(int64(5000)),
+				MaxRedeliveryDelay: ptr.To(int64(1000)),
+			}).Build()).
+			Build()
+		assertInvalid(t, validate(app), "settings.maxRedeliveryDelay 1000 is less than settings.redeliveryDelay 5000")
+	})
+}
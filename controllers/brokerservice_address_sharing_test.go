@@ -169,6 +169,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -214,6 +216,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -338,6 +342,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(appCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = appCertName
 				candidate.Spec.CommonName = appName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -469,6 +475,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(registryCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = registryCertName
 				candidate.Spec.CommonName = registryAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -508,6 +516,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -633,6 +643,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -671,6 +683,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -807,6 +821,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app1CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app1CertName
 				candidate.Spec.CommonName = app1Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -862,6 +878,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app2CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app2CertName
 				candidate.Spec.CommonName = app2Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1020,6 +1038,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1065,6 +1085,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, otherNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1193,6 +1215,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1239,6 +1263,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1370,6 +1396,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(ownerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = ownerCertName
 				candidate.Spec.CommonName = ownerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1416,6 +1444,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(consumerCertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = consumerCertName
 				candidate.Spec.CommonName = consumerAppName
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1541,6 +1571,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app1CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app1CertName
 				candidate.Spec.CommonName = app1Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1594,6 +1626,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app2CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app2CertName
 				candidate.Spec.CommonName = app2Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1724,6 +1758,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app1CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app1CertName
 				candidate.Spec.CommonName = app1Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -1777,6 +1813,8 @@ var _ = Describe("broker-service address sharing scenarios", func() {
 			InstallCert(app2CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app2CertName
 				candidate.Spec.CommonName = app2Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
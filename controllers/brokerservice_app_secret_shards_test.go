@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// appliedAppSecrets returns the external config status of a broker that applied the current app secret shards
+func appliedAppSecrets(t *testing.T, cl client.Client, ns string, peerName string) []v1beta2.ExternalConfigStatus {
+	applied := make([]v1beta2.ExternalConfigStatus, 0, AppPropertiesSecretShards)
+	for _, secretName := range AppPropertiesSecretNames(peerName) {
+		secret := &corev1.Secret{}
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret))
+		applied = append(applied, v1beta2.ExternalConfigStatus{Name: secretName, ResourceVersion: secret.ResourceVersion})
+	}
+	return applied
+}
+
+// mergedAppSecrets returns the data and provisioned apps of all app secret shards of a peer
+func mergedAppSecrets(cl client.Client, ns string, peerName string) (*corev1.Secret, error) {
+	merged := &corev1.Secret{Data: map[string][]byte{}}
+	var provisioned []string
+	for _, secretName := range AppPropertiesSecretNames(peerName) {
+		secret := &corev1.Secret{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret); err != nil {
+			return nil, err
+		}
+		for k, v := range secret.Data {
+			merged.Data[k] = v
+		}
+		if apps := secret.Annotations[common.ProvisionedAppsAnnotation]; apps != "" {
+			provisioned = append(provisioned, strings.Split(apps, ",")...)
+		}
+	}
+	sort.Strings(provisioned)
+	merged.Annotations = map[string]string{common.ProvisionedAppsAnnotation: strings.Join(provisioned, ",")}
+	return merged, nil
+}
+
+func TestAppPropertiesSecretNames(t *testing.T) {
+	names := AppPropertiesSecretNames("my-broker")
+	assert.Len(t, names, AppPropertiesSecretShards)
+	assert.Equal(t, "my-broker-app-bp", names[0])
+	assert.Equal(t, "my-broker-app-1-bp", names[1])
+	for _, name := range names {
+		assert.True(t, strings.HasSuffix(name, common.BrokerPropsSuffix), "shard %s must be loaded as broker properties", name)
+	}
+}
+
+func TestAppPropertiesShard_Stable(t *testing.T) {
+	app := NewBrokerApp("my-app", "default").Build()
+	shard := AppPropertiesShard(app)
+	assert.True(t, shard >= 0 && shard < AppPropertiesSecretShards)
+
+	// identity only, not spec
+	app.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+	assert.Equal(t, shard, AppPropertiesShard(app))
+
+	// apps spread over the shards
+	shards := map[int]bool{}
+	for i := 0; i < 64; i++ {
+		shards[AppPropertiesShard(NewBrokerApp(fmt.Sprintf("app-%d", i), "default").Build())] = true
+	}
+	assert.Greater(t, len(shards), AppPropertiesSecretShards/2)
+}
+
+func TestBrokerServiceAppSecretShards_AllMounted(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	broker := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, broker))
+	assert.Equal(t, AppPropertiesSecretNames(svcName), broker.Spec.ExtraMounts.Secrets)
+
+	// empty shards exist so the mounts resolve
+	for _, secretName := range AppPropertiesSecretNames(svcName) {
+		secret := &corev1.Secret{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret))
+	}
+}
+
+func TestBrokerServiceAppSecretShards_OnboardingTouchesOneShard(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	existing := NewBrokerApp("existing", ns).
+		WithServiceBinding(svcName, ns, "existing-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, existing)
+
+	reconcileBrokerService(t, env, svcName)
+	before := appliedAppSecrets(t, env.Client, ns, svcName)
+
+	app := NewBrokerApp("onboarded", ns).
+		WithServiceBinding(svcName, ns, "onboarded-binding-secret", 61617).
+		Build()
+	assert.NoError(t, env.Client.Create(context.TODO(), app))
+
+	reconcileBrokerService(t, env, svcName)
+	after := appliedAppSecrets(t, env.Client, ns, svcName)
+
+	shard := AppPropertiesShard(app)
+	for index := range before {
+		if index == shard {
+			assert.NotEqual(t, before[index].ResourceVersion, after[index].ResourceVersion, "shard of the app is updated")
+		} else {
+			assert.Equal(t, before[index].ResourceVersion, after[index].ResourceVersion, "shard %d is untouched", index)
+		}
+	}
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(svcName, app), Namespace: ns}, secret))
+	assert.Contains(t, strings.Split(secret.Annotations[common.ProvisionedAppsAnnotation], ","), AppIdentity(app))
+
+	// the acceptor references the shard that holds its config
+	acceptor := string(secret.Data[AppIdentityPrefixed(app, "acceptor.properties")])
+	assert.Contains(t, acceptor, fmt.Sprintf("keyStorePath=/amq/extra/secrets/%s/", secret.Name))
+	assert.Contains(t, acceptor, fmt.Sprintf("baseDir=%s%s", common.SecretPathBase, secret.Name))
+}
+
+func TestBrokerServiceAppSecretShards_AppsProvisionedWaitsForAllShards(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	objects := []client.Object{oc, service}
+	var apps []*v1beta2.BrokerApp
+	for i := 0; i < 16; i++ {
+		app := NewBrokerApp(fmt.Sprintf("app-%d", i), ns).
+			WithServiceBinding(svcName, ns, fmt.Sprintf("app-%d-binding-secret", i), int32(61616+i)).
+			Build()
+		apps = append(apps, app)
+		objects = append(objects, app)
+	}
+	env := NewTestEnvironment(ns, objects...)
+
+	reconcileBrokerService(t, env, svcName)
+
+	applied := appliedAppSecrets(t, env.Client, ns, svcName)
+	shard := AppPropertiesShard(apps[0])
+
+	setApplied := func(externalConfigs []v1beta2.ExternalConfigStatus) {
+		broker := &v1beta2.Broker{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, broker))
+		broker.Status.Conditions = []metav1.Condition{
+			{Type: v1beta2.DeployedConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ReadyConditionReason},
+			{Type: v1beta2.ReadyConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ReadyConditionReason},
+		}
+		broker.Status.ExternalConfigs = externalConfigs
+		assert.NoError(t, env.Client.Update(context.TODO(), broker))
+	}
+
+	// a single shard applied
+	setApplied([]v1beta2.ExternalConfigStatus{applied[shard]})
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.False(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.AppsProvisionedConditionType))
+	assert.Contains(t, updated.Status.ProvisionedApps, AppIdentity(apps[0]))
+	for _, app := range apps {
+		if AppPropertiesShard(app) != shard {
+			assert.NotContains(t, updated.Status.ProvisionedApps, AppIdentity(app))
+		}
+	}
+
+	// all shards applied
+	setApplied(applied)
+	reconcileBrokerService(t, env, svcName)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.AppsProvisionedConditionType))
+	assert.Len(t, updated.Status.ProvisionedApps, len(apps))
+	assert.True(t, sort.StringsAreSorted(updated.Status.ProvisionedApps))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sort"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+)
+
+// setCapacityStatus reports the capacity of each peer, the use of the port pool and the allocation
+// of each app placed on the service, with the same accounting as the placement of apps
+func (reconciler *BrokerServiceInstanceReconciler) setCapacityStatus(placed []broker.BrokerApp) {
+	service := reconciler.instance
+
+	peers := peerCapacities(service, placed)
+	capacity := make([]broker.BrokerServicePeerCapacityStatus, len(peers))
+	for index, peer := range peers {
+		capacity[index] = broker.BrokerServicePeerCapacityStatus{
+			Peer:      int32(index),
+			Apps:      int32(peer.apps),
+			Limits:    peer.limits.resourceList(),
+			Allocated: peer.used.resourceList(),
+		}
+	}
+	reconciler.status.Capacity = capacity
+
+	total := portPoolSize(service.Spec.PortRange)
+	used := int32(len(collectUsedPorts(service.Spec.PortRange, placed, nil)))
+	reconciler.status.Ports = &broker.BrokerServicePortsStatus{
+		Total: total,
+		Used:  used,
+		Free:  total - used,
+	}
+
+	var allocations []broker.BrokerServiceAppAllocationStatus
+	for index := range placed {
+		app := &placed[index]
+		allocations = append(allocations, broker.BrokerServiceAppAllocationStatus{
+			Name:      app.Name,
+			Namespace: app.Namespace,
+			Peer:      app.Status.Service.Peer,
+			Port:      app.Status.Service.AssignedPort,
+			Allocated: appCapacityDemand(app).resourceList(),
+		})
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		if allocations[i].Namespace != allocations[j].Namespace {
+			return allocations[i].Namespace < allocations[j].Namespace
+		}
+		if allocations[i].Name != allocations[j].Name {
+			return allocations[i].Name < allocations[j].Name
+		}
+		return allocations[i].Peer < allocations[j].Peer
+	})
+	reconciler.status.Allocations = allocations
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestPortPoolSize(t *testing.T) {
+	assert.Equal(t, int32(65535-61616+1), portPoolSize(nil))
+	assert.Equal(t, int32(9), portPoolSize(&v1beta2.BrokerServicePortRangeType{Start: 30000, End: 30009, Exclusions: []int32{30005, 30005, 40000}}))
+	assert.Equal(t, int32(0), portPoolSize(&v1beta2.BrokerServicePortRangeType{Start: 30009, End: 30000}))
+}
+
+func TestBrokerServiceCapacity_Status(t *testing.T) {
+	ns := "default"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService("svc", ns).
+		WithPeers(2).
+		WithMemoryLimit("1Gi").
+		WithCapacity(v1beta2.BrokerServiceCapacityType{MaxAddresses: ptr.To(int32(10))}).
+		WithPortRange(61616, 61625, 61620).
+		Build()
+	orders := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build(), NewAddressType("returns").Build()).
+		WithMemoryRequest("256Mi").
+		WithServiceBinding("svc", ns, "orders-binding-secret", 61616).
+		Build()
+	billing := NewBrokerApp("billing", ns).
+		WithAddresses(NewAddressType("invoices").Build()).
+		WithServiceBinding("svc", ns, "billing-binding-secret", 61617).
+		WithServicePeer(1).
+		Build()
+	// the messages of audit are still moving off peer 0
+	audit := NewBrokerApp("audit", ns).
+		WithAddresses(NewAddressType("audit").Build()).
+		WithMemoryRequest("128Mi").
+		WithServiceBinding("other", ns, "audit-binding-secret", 61616).
+		WithMigrationFrom("svc", ns, 61618, 0).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, orders, billing, audit)
+
+	reconcileBrokerService(t, env, service.Name)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: ns}, updated))
+
+	limits := corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+		"addresses":           resource.MustParse("10"),
+	}
+	if assert.Len(t, updated.Status.Capacity, 2) {
+		peer := updated.Status.Capacity[0]
+		assert.Equal(t, int32(2), peer.Apps)
+		assert.True(t, limits[corev1.ResourceMemory].Equal(peer.Limits[corev1.ResourceMemory]))
+		assert.True(t, limits["addresses"].Equal(peer.Limits["addresses"]))
+		allocatedMemory := peer.Allocated[corev1.ResourceMemory]
+		assert.Equal(t, "384Mi", allocatedMemory.String())
+		allocatedAddresses := peer.Allocated["addresses"]
+		assert.Equal(t, int64(3), allocatedAddresses.Value())
+
+		peer = updated.Status.Capacity[1]
+		assert.Equal(t, int32(1), peer.Apps)
+		_, hasMemory := peer.Allocated[corev1.ResourceMemory]
+		assert.False(t, hasMemory)
+	}
+
+	assert.Equal(t, &v1beta2.BrokerServicePortsStatus{Total: 9, Used: 3, Free: 6}, updated.Status.Ports)
+
+	if assert.Len(t, updated.Status.Allocations, 3) {
+		assert.Equal(t, "audit", updated.Status.Allocations[0].Name)
+		assert.Equal(t, int32(61618), updated.Status.Allocations[0].Port)
+		assert.Equal(t, "billing", updated.Status.Allocations[1].Name)
+		assert.Equal(t, int32(1), updated.Status.Allocations[1].Peer)
+		assert.Equal(t, "orders", updated.Status.Allocations[2].Name)
+		assert.Equal(t, int32(61616), updated.Status.Allocations[2].Port)
+		memory := updated.Status.Allocations[2].Allocated[corev1.ResourceMemory]
+		assert.Equal(t, "256Mi", memory.String())
+		addresses := updated.Status.Allocations[2].Allocated["addresses"]
+		assert.Equal(t, int64(2), addresses.Value())
+	}
+}
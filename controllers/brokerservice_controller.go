@@ -19,9 +19,11 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/appselector"
@@ -30,9 +32,13 @@ import (
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/secrets"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,14 +51,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// SharedJournalMountPath is where the peers of an HA service mount the shared journal claim
+	SharedJournalMountPath = "/app/journal"
+
+	DefaultJournalStorageSize = "2Gi"
+
+	// AppPropertiesSecretShards bounds the number of app properties secrets of a peer, apps are
+	// spread by a stable hash of their identity to keep each secret below the object size limit
+	AppPropertiesSecretShards = 8
+)
+
 type BrokerServiceReconciler struct {
 	*ReconcilerLoop
+	recorder *EventRecorder
 }
 
 type BrokerServiceInstanceReconciler struct {
 	*BrokerServiceReconciler
 	instance *broker.BrokerService
 	status   *broker.BrokerServiceStatus
+
+	// apps provisioned on each peer
+	validApps [][]broker.BrokerApp
+
+	// data of the deployed app secrets, the desired secrets are regenerated from empty
+	deployedAppData map[*corev1.Secret]map[string][]byte
 }
 
 func NewBrokerServiceReconciler(client client.Client, scheme *runtime.Scheme, config *rest.Config, logger logr.Logger) *BrokerServiceReconciler {
@@ -67,8 +91,11 @@ func NewBrokerServiceReconciler(client client.Client, scheme *runtime.Scheme, co
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerapps,verbs=get;list;watch
 //+kubebuilder:rbac:groups=broker.arkmq.org,namespace=arkmq-org-broker-operator,resources=brokerapps/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,namespace=arkmq-org-broker-operator,resources=ingresses,verbs=get;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=route.openshift.io,namespace=arkmq-org-broker-operator,resources=routes;routes/custom-host,verbs=get;list;watch;create;delete;update
 
-func (reconciler *BrokerServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (reconciler *BrokerServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	defer servicemetrics.ObserveReconcile("brokerservice", time.Now(), &reconcileErr)
 	reqLogger := reconciler.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name, "Reconciling", "BrokerService")
 
 	instance := &broker.BrokerService{}
@@ -88,7 +115,7 @@ func (reconciler *BrokerServiceReconciler) Reconcile(ctx context.Context, reques
 	}
 
 	processor := BrokerServiceInstanceReconciler{
-		BrokerServiceReconciler: &BrokerServiceReconciler{ReconcilerLoop: localLoop},
+		BrokerServiceReconciler: &BrokerServiceReconciler{ReconcilerLoop: localLoop, recorder: reconciler.recorder},
 		instance:                instance,
 		status:                  instance.Status.DeepCopy(),
 	}
@@ -130,18 +157,29 @@ func (reconciler *BrokerServiceReconciler) Reconcile(ctx context.Context, reques
 
 // instance specifics for a reconciler loop
 func (r *BrokerServiceReconciler) getOwned() []client.ObjectList {
-	return []client.ObjectList{
+	owned := []client.ObjectList{
 		&corev1.SecretList{},
+		&corev1.PersistentVolumeClaimList{},
 		&broker.BrokerList{},
 		&corev1.ServiceList{}}
+	if r.ingressesServed() {
+		owned = append(owned, &netv1.IngressList{})
+	}
+	if r.routesServed() {
+		owned = append(owned, &routev1.RouteList{})
+	}
+	return owned
 }
 
 func (r *BrokerServiceReconciler) getOrderedTypeList() []reflect.Type {
 	// we want to create/update in this order
 	return []reflect.Type{
 		reflect.TypeOf(corev1.Secret{}),
+		reflect.TypeOf(corev1.PersistentVolumeClaim{}),
 		reflect.TypeOf(broker.Broker{}),
-		reflect.TypeOf(corev1.Service{})}
+		reflect.TypeOf(corev1.Service{}),
+		reflect.TypeOf(netv1.Ingress{}),
+		reflect.TypeOf(routev1.Route{})}
 }
 
 func (reconciler *BrokerServiceInstanceReconciler) validateSpec() error {
@@ -159,7 +197,28 @@ func (reconciler *BrokerServiceInstanceReconciler) validateSpec() error {
 		}
 	}
 
-	return nil
+	if persistence := reconciler.instance.Spec.Persistence; persistence != nil && persistence.Storage.Size != "" {
+		if _, err := resource.ParseQuantity(persistence.Storage.Size); err != nil {
+			return NewValidationError(
+				broker.ValidConditionFailureReason,
+				".Spec.Persistence.Storage.Size quantity string is invalid, %v", err)
+		}
+	}
+
+	if portRange := reconciler.instance.Spec.PortRange; portRange != nil {
+		if portRange.Start < 1 || portRange.End > MaxValidPort || portRange.Start > portRange.End {
+			return NewValidationError(
+				broker.ValidConditionFailureReason,
+				".Spec.PortRange [%d-%d] is invalid, start must not exceed end within [1-%d]", portRange.Start, portRange.End, MaxValidPort)
+		}
+		if reserved := reservedPortsInPool(portRange); len(reserved) > 0 {
+			return NewValidationError(
+				broker.ValidConditionFailedReservedPortReason,
+				".Spec.PortRange [%d-%d] includes the reserved broker ports %v, exclude them or choose another range", portRange.Start, portRange.End, reserved)
+		}
+	}
+
+	return reconciler.validateExpose()
 }
 
 func (reconciler *BrokerServiceInstanceReconciler) processSpec() (err error) {
@@ -167,20 +226,55 @@ func (reconciler *BrokerServiceInstanceReconciler) processSpec() (err error) {
 		return err
 	}
 
-	// Process service
-	return reconciler.processService()
+	// Process a service per peer
+	for index := int32(0); index < PeerCount(reconciler.instance); index++ {
+		reconciler.processService(index)
+		reconciler.processExpose(index, reconciler.validApps[index])
+	}
+	return nil
 }
 
 func (reconciler *BrokerServiceInstanceReconciler) processBroker() (err error) {
 
+	for index := int32(0); index < PeerCount(reconciler.instance); index++ {
+		for _, brokerName := range PeerBrokerNames(reconciler.instance, index) {
+			reconciler.processPeerBroker(index, brokerName)
+		}
+
+		if isHA(reconciler.instance) {
+			reconciler.processJournalClaim(PeerName(reconciler.instance.Name, index))
+		}
+	}
+
+	return reconciler.processAppSecrets()
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processPeerBroker(index int32, brokerName string) {
+
+	peerName := PeerName(reconciler.instance.Name, index)
+
 	var desired *broker.Broker
-	obj := reconciler.CloneOfDeployed(reflect.TypeOf(broker.Broker{}), reconciler.instance.Name)
+	obj := reconciler.CloneOfDeployed(reflect.TypeOf(broker.Broker{}), brokerName)
 	if obj != nil {
 		desired = obj.(*broker.Broker)
 	} else {
-		desired = common.GenerateBroker(reconciler.instance.Name, reconciler.instance.Namespace)
+		desired = common.GenerateBroker(brokerName, reconciler.instance.Namespace)
 	}
 	desired.Spec.PersistenceEnabled = false
+	desired.Spec.Storage = broker.StorageType{}
+	desired.Spec.ExtraVolumes = nil
+	desired.Spec.ExtraVolumeMounts = nil
+	desired.Spec.BrokerProperties = nil
+
+	if persistence := reconciler.instance.Spec.Persistence; persistence != nil {
+		if persistence.HA {
+			reconciler.configureSharedJournal(desired, peerName)
+		} else {
+			desired.Spec.PersistenceEnabled = true
+			desired.Spec.Storage = persistence.Storage
+		}
+	}
+
 	desired.Spec.Labels = map[string]string{
 		// Standard Kubernetes labels
 		common.LabelAppKubernetesInstance:  reconciler.instance.Name,
@@ -188,7 +282,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processBroker() (err error) {
 		common.LabelAppKubernetesManagedBy: "arkmq-org-broker-operator",
 		// Domain-specific labels
 		common.LabelBrokerService:   reconciler.instance.Name,
-		common.LabelBrokerPeerIndex: "0",
+		common.LabelBrokerPeerIndex: fmt.Sprintf("%d", index),
 	}
 	desired.Spec.Env = reconciler.instance.Spec.Env
 	desired.Spec.Resources = reconciler.instance.Spec.Resources
@@ -197,32 +291,95 @@ func (reconciler *BrokerServiceInstanceReconciler) processBroker() (err error) {
 		desired.Spec.Image = *reconciler.instance.Spec.Image
 	}
 
-	desired.Spec.ExtraMounts.Secrets = []string{
-		reconciler.appPropertiesSecretName(),
+	// all shards are mounted up front, onboarding an app does not change the mounts
+	desired.Spec.ExtraMounts.Secrets = AppPropertiesSecretNames(peerName)
+	if brokerName != reconciler.instance.Name {
+		// the app acceptors reference the service cert, other brokers have their own operand cert
+		desired.Spec.ExtraMounts.Secrets = append(desired.Spec.ExtraMounts.Secrets, certSecretName(reconciler.instance))
 	}
 
-	err = reconciler.processAppSecrets()
-
 	reconciler.TrackDesired(desired)
-
-	return err
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) processAppSecrets() (err error) {
-	// avoid restart for app onboarding with existing mount points
-	// TODO potentially N app-secrets to overcome 1Mb size limit
-	resourceName := types.NamespacedName{
-		Namespace: reconciler.instance.Namespace,
-		Name:      reconciler.appPropertiesSecretName(),
+// configureSharedJournal points the peer journal at the shared claim, the peers compete
+// for the journal file lock and the loser waits as a backup
+func (reconciler *BrokerServiceInstanceReconciler) configureSharedJournal(desired *broker.Broker, peerName string) {
+	claimName := JournalClaimName(peerName)
+	desired.Spec.ExtraVolumes = []corev1.Volume{
+		{
+			Name: claimName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		},
+	}
+	desired.Spec.ExtraVolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      claimName,
+			MountPath: SharedJournalMountPath,
+		},
+	}
+	desired.Spec.BrokerProperties = []string{
+		"HAPolicyConfiguration=SHARED_STORE_PRIMARY",
+		fmt.Sprintf("journalDirectory=%s/journal", SharedJournalMountPath),
+		fmt.Sprintf("bindingsDirectory=%s/bindings", SharedJournalMountPath),
+		fmt.Sprintf("largeMessagesDirectory=%s/largemessages", SharedJournalMountPath),
+		fmt.Sprintf("pagingDirectory=%s/paging", SharedJournalMountPath),
 	}
+}
 
-	var desired *corev1.Secret
+func (reconciler *BrokerServiceInstanceReconciler) processJournalClaim(peerName string) {
 
-	obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Secret{}), resourceName.Name)
+	persistence := reconciler.instance.Spec.Persistence
+	size := persistence.Storage.Size
+	if size == "" {
+		size = DefaultJournalStorageSize
+	}
+
+	var desired *corev1.PersistentVolumeClaim
+	claimName := JournalClaimName(peerName)
+	obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.PersistentVolumeClaim{}), claimName)
 	if obj != nil {
-		desired = obj.(*corev1.Secret)
+		desired = obj.(*corev1.PersistentVolumeClaim)
 	} else {
-		desired = secrets.NewSecret(resourceName, nil, nil)
+		desired = &corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "PersistentVolumeClaim",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      claimName,
+				Namespace: reconciler.instance.Namespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteMany,
+				},
+			},
+		}
+		if persistence.Storage.StorageClassName != "" {
+			desired.Spec.StorageClassName = &persistence.Storage.StorageClassName
+		}
+	}
+
+	// only the requested size can change once bound, it can grow with a supporting storage class
+	desired.Spec.Resources.Requests = corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(size),
+	}
+
+	reconciler.TrackDesired(desired)
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processAppSecrets() (err error) {
+	// avoid restart for app onboarding with existing mount points
+	peerCount := PeerCount(reconciler.instance)
+	peerSecrets := make([][]*corev1.Secret, peerCount)
+	for index := range peerSecrets {
+		for _, secretName := range AppPropertiesSecretNames(PeerName(reconciler.instance.Name, int32(index))) {
+			peerSecrets[index] = append(peerSecrets[index], reconciler.cloneOfAppSecret(secretName))
+		}
 	}
 
 	// find all apps that select this service
@@ -231,14 +388,24 @@ func (reconciler *BrokerServiceInstanceReconciler) processAppSecrets() (err erro
 	if err = reconciler.Client.List(context.TODO(), apps, client.MatchingFields{common.AppServiceBindingField: key}); err != nil {
 		return err
 	}
+	// bound apps and apps with messages still moving off this service
+	reconciler.status.RemainingApps = int32(len(apps.Items))
+	reconciler.setCapacityStatus(appsPlacedOnService(apps.Items, key))
 
-	// reset data
-	desired.Data = make(map[string][]byte)
-	appIdentities := make([]string, 0, len(apps.Items))
+	appIdentities := make(map[*corev1.Secret][]string)
 	rejectedApps := make([]broker.RejectedApp, 0)
-	validApps := make([]broker.BrokerApp, 0, len(apps.Items))
+	validApps := make([][]broker.BrokerApp, peerCount)
 
+	candidates := make([]broker.BrokerApp, 0, len(apps.Items))
+	migrating := make([]broker.BrokerApp, 0)
 	for _, app := range apps.Items {
+		if migratingFrom(&app, key) != nil {
+			migrating = append(migrating, app)
+		}
+		if app.Status.Service == nil || app.Status.Service.Key() != key {
+			// only the previous binding is on this service
+			continue
+		}
 		valid, rejectionReason := reconciler.validateAppForProvisioning(&app, key)
 		if !valid {
 			// App failed validation - track it for user visibility
@@ -249,12 +416,38 @@ func (reconciler *BrokerServiceInstanceReconciler) processAppSecrets() (err erro
 			})
 			continue
 		}
+		candidates = append(candidates, app)
+	}
+
+	identityOwners := appIdentityOwners(candidates)
+	for _, app := range candidates {
+		if owner := identityOwners[AppCertDN(&app)]; owner.Name != app.Name || owner.Namespace != app.Namespace {
+			// The client cert of one app would authenticate as the other
+			rejectedApps = append(rejectedApps, broker.RejectedApp{
+				Name:      app.Name,
+				Namespace: app.Namespace,
+				Reason:    fmt.Sprintf("client cert subject %s overlaps app %s/%s", AppCertDN(&app), owner.Namespace, owner.Name),
+			})
+			continue
+		}
+
+		// No roles are granted on the shared addresses of other apps without their access
+		if accessErr := referencedAddressAccess(&app, apps.Items); accessErr != nil {
+			rejectedApps = append(rejectedApps, broker.RejectedApp{
+				Name:      app.Name,
+				Namespace: app.Namespace,
+				Reason:    accessErr.Error(),
+			})
+			continue
+		}
 
 		// Validate app name for safe file path construction
 		if err = common.ValidateResourceName(app.Name); err != nil {
 			reconciler.log.Error(err, "invalid app name", "app", app.Name)
 			break
 		}
+		peer := app.Status.Service.Peer
+		desired := peerSecrets[peer][AppPropertiesShard(&app)]
 		if err = reconciler.processCapabilities(desired, &app); err != nil {
 			reconciler.log.Error(err, "failed to process capabilities for app", "app", app.Name)
 			break
@@ -263,41 +456,171 @@ func (reconciler *BrokerServiceInstanceReconciler) processAppSecrets() (err erro
 			reconciler.log.Error(err, "failed to process acceptor for app", "app", app.Name)
 			break
 		}
-		appIdentities = append(appIdentities, AppIdentity(&app))
-		validApps = append(validApps, app)
+		if err = reconciler.processLinkBridges(desired, &app); err != nil {
+			reconciler.log.Error(err, "failed to process links for app", "app", app.Name)
+			break
+		}
+		appIdentities[desired] = append(appIdentities[desired], AppIdentity(&app))
+		validApps[peer] = append(validApps[peer], app)
+	}
+
+	// the previous binding of a migrating app is retained until its messages are moved
+	for index := 0; err == nil && index < len(migrating); index++ {
+		app := &migrating[index]
+		from := migratingFrom(app, key)
+		if from.Peer < 0 || from.Peer >= peerCount || !portInPool(reconciler.instance.Spec.PortRange, from.AssignedPort) {
+			reconciler.log.Info("Not retaining the previous binding of a migrating app, the peer or port is gone",
+				"app", appName(app),
+				"peer", from.Peer,
+				"port", from.AssignedPort)
+			continue
+		}
+		desired := peerSecrets[from.Peer][AppPropertiesShard(app)]
+		if err = reconciler.processMigrationSource(desired, app, from); err != nil {
+			reconciler.log.Error(err, "failed to process the previous binding of migrating app", "app", app.Name)
+		}
 	}
 
-	sort.Strings(appIdentities)
-	if desired.Annotations == nil {
-		desired.Annotations = make(map[string]string)
+	for _, shards := range peerSecrets {
+		for _, desired := range shards {
+			sort.Strings(appIdentities[desired])
+			if desired.Annotations == nil {
+				desired.Annotations = make(map[string]string)
+			}
+			desired.Annotations[common.ProvisionedAppsAnnotation] = strings.Join(appIdentities[desired], ",")
+
+			reconciler.TrackDesired(desired)
+		}
 	}
-	desired.Annotations[common.ProvisionedAppsAnnotation] = strings.Join(appIdentities, ",")
 
 	// Track rejected apps in status for user visibility
 	reconciler.status.RejectedApps = rejectedApps
-
-	reconciler.TrackDesired(desired)
+	reconciler.validApps = validApps
 
 	// Update prometheus config in control-plane-override secret with queue-level metrics
-	if err == nil {
-		err = reconciler.processControlPlaneOverrideSecret(validApps)
+	for index := int32(0); err == nil && index < peerCount; index++ {
+		for _, brokerName := range PeerBrokerNames(reconciler.instance, index) {
+			if err = reconciler.processControlPlaneOverrideSecret(brokerName, validApps[index]); err != nil {
+				break
+			}
+		}
 	}
 
 	return err
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) appPropertiesSecretName() string {
-	return AppPropertiesSecretName(reconciler.instance.Name)
+// appIdentityOwners returns the app that owns each client cert DN, the oldest app keeps its identity
+func appIdentityOwners(apps []broker.BrokerApp) map[string]*broker.BrokerApp {
+	owners := make(map[string]*broker.BrokerApp, len(apps))
+	for index := range apps {
+		app := &apps[index]
+		dn := AppCertDN(app)
+		owner, found := owners[dn]
+		if !found ||
+			app.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(app.CreationTimestamp.Equal(&owner.CreationTimestamp) && AppIdentity(app) < AppIdentity(owner)) {
+			owners[dn] = app
+		}
+	}
+	return owners
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) cloneOfAppSecret(name string) *corev1.Secret {
+	var desired *corev1.Secret
+
+	obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Secret{}), name)
+	if obj != nil {
+		desired = obj.(*corev1.Secret)
+	} else {
+		desired = secrets.NewSecret(types.NamespacedName{Namespace: reconciler.instance.Namespace, Name: name}, nil, nil)
+	}
+
+	// reset data, keeping the deployed data for the properties that depend on what was applied before
+	if reconciler.deployedAppData == nil {
+		reconciler.deployedAppData = make(map[*corev1.Secret]map[string][]byte)
+	}
+	reconciler.deployedAppData[desired] = desired.Data
+	desired.Data = make(map[string][]byte)
+	return desired
 }
 
+// AppPropertiesSecretName returns the name of the first app properties secret of a peer
 func AppPropertiesSecretName(name string) string {
 	return fmt.Sprintf("%s-app%s", name, common.BrokerPropsSuffix)
 }
 
+// AppPropertiesShardSecretName returns the name of an app properties secret of a peer,
+// the first shard keeps the name of the original single secret
+func AppPropertiesShardSecretName(name string, shard int) string {
+	if shard == 0 {
+		return AppPropertiesSecretName(name)
+	}
+	return fmt.Sprintf("%s-app-%d%s", name, shard, common.BrokerPropsSuffix)
+}
+
+// AppPropertiesSecretNames returns the names of all app properties secrets of a peer
+func AppPropertiesSecretNames(name string) []string {
+	names := make([]string, 0, AppPropertiesSecretShards)
+	for shard := 0; shard < AppPropertiesSecretShards; shard++ {
+		names = append(names, AppPropertiesShardSecretName(name, shard))
+	}
+	return names
+}
+
+// AppPropertiesShard returns the shard that holds the app properties, stable for the app identity
+func AppPropertiesShard(app *broker.BrokerApp) int {
+	digest := fnv.New32a()
+	digest.Write([]byte(AppIdentity(app)))
+	return int(digest.Sum32() % AppPropertiesSecretShards)
+}
+
+// AppPropertiesSecretNameForApp returns the name of the app properties secret of a peer that holds the app
+func AppPropertiesSecretNameForApp(name string, app *broker.BrokerApp) string {
+	return AppPropertiesShardSecretName(name, AppPropertiesShard(app))
+}
+
 func PropertiesSecretName(name string) string {
 	return fmt.Sprintf("%s%s", name, common.BrokerPropsSuffix)
 }
 
+// isHA is true when the service is backed by a live/backup peer pair
+func isHA(service *broker.BrokerService) bool {
+	return service.Spec.Persistence != nil && service.Spec.Persistence.HA
+}
+
+// PeerCount returns the number of peers that back a service
+func PeerCount(service *broker.BrokerService) int32 {
+	if service.Spec.Peers == nil || *service.Spec.Peers < 1 {
+		return 1
+	}
+	return *service.Spec.Peers
+}
+
+// PeerName returns the name of the primary Broker and the Service of a peer, the first peer uses the service name
+func PeerName(serviceName string, index int32) string {
+	if index == 0 {
+		return serviceName
+	}
+	return fmt.Sprintf("%s-peer-%d", serviceName, index)
+}
+
+// PeerBrokerNames returns the names of the Brokers that back a peer, with ha the first is the primary
+func PeerBrokerNames(service *broker.BrokerService, index int32) []string {
+	peerName := PeerName(service.Name, index)
+	if isHA(service) {
+		return []string{peerName, BackupBrokerName(peerName)}
+	}
+	return []string{peerName}
+}
+
+func BackupBrokerName(name string) string {
+	return fmt.Sprintf("%s-backup", name)
+}
+
+func JournalClaimName(name string) string {
+	return fmt.Sprintf("%s-journal", name)
+}
+
 func certSecretName(cr *broker.BrokerService) string {
 	return fmt.Sprintf("%s-%s", cr.Name, common.DefaultOperandCertSecretName)
 }
@@ -313,6 +636,7 @@ func (reconciler *BrokerServiceInstanceReconciler) setValidCondition(err error)
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = validErr.ConditionReason()
 		condition.Message = validErr.Error()
+		reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonValidationFailed, "%s", validErr.Error())
 	}
 
 	meta.SetStatusCondition(&reconciler.status.Conditions, condition)
@@ -352,53 +676,54 @@ func (reconciler *BrokerServiceInstanceReconciler) processStatus(reconcilerError
 		}
 		appsProvisionedCondition.Reason = broker.AppsProvisionedConditionNotReadyReason
 	} else {
-		obj := reconciler.CloneOfDeployed(reflect.TypeOf(broker.Broker{}), reconciler.instance.Name)
-		if obj != nil {
-			deployed := obj.(*broker.Broker)
-			brokerDeployed := meta.FindStatusCondition(deployed.Status.Conditions, broker.DeployedConditionType)
-
-			if brokerDeployed != nil {
-				if brokerDeployed.Status == metav1.ConditionTrue {
-					// Broker is deployed
-					deployedCondition.Status = metav1.ConditionTrue
-					deployedCondition.Reason = broker.ReadyConditionReason
-				} else {
-					deployedCondition.Message = fmt.Sprintf("not ready broker status %v", deployed.Status)
+		provisionedApps := make(map[string]string)
+		peersDeployed, peersSynced := true, true
+		for index := int32(0); index < PeerCount(reconciler.instance); index++ {
+			peerDeployed, notReadyMessage := reconciler.peerDeployed(index)
+			if !peerDeployed {
+				peersDeployed = false
+				if notReadyMessage != "" {
+					deployedCondition.Message = notReadyMessage
 				}
 			}
 
-			brokerReady := meta.FindStatusCondition(deployed.Status.Conditions, broker.ReadyConditionType)
-			if brokerReady != nil && brokerReady.Status == metav1.ConditionTrue {
-
-				appPropsSecretName := AppPropertiesSecretName(reconciler.instance.Name)
-				var appliedSecretVersion string
-				for _, ec := range deployed.Status.ExternalConfigs {
-					if ec.Name == appPropsSecretName {
-						appliedSecretVersion = ec.ResourceVersion
-						break
-					}
-				}
-				if appliedSecretVersion != "" {
-					secret := &corev1.Secret{}
-					secretKey := types.NamespacedName{Name: appPropsSecretName, Namespace: reconciler.instance.Namespace}
-					if getErr := reconciler.Client.Get(context.TODO(), secretKey, secret); getErr == nil {
-						if secret.ResourceVersion == appliedSecretVersion {
-							appsProvisionedCondition.Status = metav1.ConditionTrue
-							appsProvisionedCondition.Reason = broker.AppsProvisionedConditionSyncedReason
-							if applied, ok := secret.Annotations[common.ProvisionedAppsAnnotation]; ok && applied != "" {
-								reconciler.status.ProvisionedApps = strings.Split(applied, ",")
-							} else {
-								reconciler.status.ProvisionedApps = nil
-							}
-						}
-					}
-				}
+			applied, peerSynced := reconciler.peerProvisionedApps(index)
+			if !peerSynced {
+				peersSynced = false
 			}
+			for _, appIdentity := range applied {
+				provisionedApps[appIdentity] = ""
+			}
+		}
+
+		if peersDeployed {
+			// Brokers are deployed
+			deployedCondition.Status = metav1.ConditionTrue
+			deployedCondition.Reason = broker.ReadyConditionReason
+			deployedCondition.Message = ""
+		}
+
+		if peersSynced {
+			appsProvisionedCondition.Status = metav1.ConditionTrue
+			appsProvisionedCondition.Reason = broker.AppsProvisionedConditionSyncedReason
+		}
+
+		if len(provisionedApps) > 0 {
+			reconciler.status.ProvisionedApps = sortedKeys(provisionedApps)
+		} else {
+			reconciler.status.ProvisionedApps = nil
 		}
 	}
 	meta.SetStatusCondition(&reconciler.status.Conditions, deployedCondition)
 	meta.SetStatusCondition(&reconciler.status.Conditions, appsProvisionedCondition)
 
+	reconciler.status.LoadBalancers = reconciler.loadBalancerStatus()
+
+	reconciler.setCordonedCondition()
+
+	// the cert secret is not watched, check it again until it covers the app hosts
+	retry = !reconciler.setExposedCondition()
+
 	common.SetReadyCondition(&reconciler.status.Conditions)
 
 	if !reflect.DeepEqual(reconciler.instance.Status, *reconciler.status) {
@@ -411,10 +736,119 @@ func (reconciler *BrokerServiceInstanceReconciler) processStatus(reconcilerError
 		reconciler.instance.Namespace,
 		len(reconciler.status.ProvisionedApps),
 	)
+	servicemetrics.UpdateServiceCapacityMetrics(
+		reconciler.instance.Name,
+		reconciler.instance.Namespace,
+		reconciler.status,
+	)
 
 	return err, retry
 }
 
+// setCordonedCondition reports a service out of rotation and the apps that remain on it,
+// the condition is removed when the service is schedulable
+func (reconciler *BrokerServiceInstanceReconciler) setCordonedCondition() {
+	remaining := reconciler.status.RemainingApps
+	condition := metav1.Condition{
+		Type:   broker.CordonedConditionType,
+		Status: metav1.ConditionTrue,
+	}
+	switch reconciler.instance.Spec.SchedulingPolicy {
+	case broker.SchedulingPolicies.Cordoned:
+		condition.Reason = broker.CordonedConditionCordonedReason
+		condition.Message = fmt.Sprintf("no new apps are bound, %d apps remain", remaining)
+	case broker.SchedulingPolicies.Draining:
+		if remaining > 0 {
+			condition.Reason = broker.CordonedConditionDrainingReason
+			condition.Message = fmt.Sprintf("rebinding apps to other services, %d apps remain", remaining)
+		} else {
+			condition.Reason = broker.CordonedConditionDrainedReason
+			condition.Message = "no apps remain"
+		}
+	default:
+		meta.RemoveStatusCondition(&reconciler.status.Conditions, broker.CordonedConditionType)
+		return
+	}
+	meta.SetStatusCondition(&reconciler.status.Conditions, condition)
+}
+
+// peerDeployed is true when a broker of the peer is deployed, with ha only the live broker
+// is ready, the backup is deployed and waits on the journal lock
+func (reconciler *BrokerServiceInstanceReconciler) peerDeployed(index int32) (deployed bool, notReadyMessage string) {
+	for _, brokerName := range PeerBrokerNames(reconciler.instance, index) {
+		obj := reconciler.CloneOfDeployed(reflect.TypeOf(broker.Broker{}), brokerName)
+		if obj == nil {
+			continue
+		}
+		peerBroker := obj.(*broker.Broker)
+		brokerDeployed := meta.FindStatusCondition(peerBroker.Status.Conditions, broker.DeployedConditionType)
+		if brokerDeployed != nil {
+			if brokerDeployed.Status == metav1.ConditionTrue {
+				return true, ""
+			}
+			notReadyMessage = fmt.Sprintf("not ready broker status %v", peerBroker.Status)
+		}
+	}
+	return false, notReadyMessage
+}
+
+// peerProvisionedApps returns the apps applied to the peer, synced once a ready broker of the
+// peer has applied the current version of each app secret shard. Until then, previously
+// provisioned apps that remain on an unsynced shard are retained
+func (reconciler *BrokerServiceInstanceReconciler) peerProvisionedApps(index int32) (applied []string, synced bool) {
+	var readyBrokers []*broker.Broker
+	for _, brokerName := range PeerBrokerNames(reconciler.instance, index) {
+		obj := reconciler.CloneOfDeployed(reflect.TypeOf(broker.Broker{}), brokerName)
+		if obj == nil {
+			continue
+		}
+		peerBroker := obj.(*broker.Broker)
+		if meta.IsStatusConditionTrue(peerBroker.Status.Conditions, broker.ReadyConditionType) {
+			readyBrokers = append(readyBrokers, peerBroker)
+		}
+	}
+
+	synced = true
+	for _, secretName := range AppPropertiesSecretNames(PeerName(reconciler.instance.Name, index)) {
+		shardApplied, shardSynced := reconciler.shardProvisionedApps(secretName, readyBrokers)
+		applied = append(applied, shardApplied...)
+		if !shardSynced {
+			synced = false
+		}
+	}
+	return applied, synced
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) shardProvisionedApps(secretName string, readyBrokers []*broker.Broker) (applied []string, synced bool) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: secretName, Namespace: reconciler.instance.Namespace}
+	if getErr := reconciler.Client.Get(context.TODO(), secretKey, secret); getErr != nil {
+		return nil, false
+	}
+	var desired []string
+	if apps, ok := secret.Annotations[common.ProvisionedAppsAnnotation]; ok && apps != "" {
+		desired = strings.Split(apps, ",")
+	}
+
+	for _, readyBroker := range readyBrokers {
+		for _, ec := range readyBroker.Status.ExternalConfigs {
+			if ec.Name == secretName && ec.ResourceVersion == secret.ResourceVersion {
+				return desired, true
+			}
+		}
+	}
+
+	for _, appIdentity := range desired {
+		for _, provisioned := range reconciler.instance.Status.ProvisionedApps {
+			if appIdentity == provisioned {
+				applied = append(applied, appIdentity)
+				break
+			}
+		}
+	}
+	return applied, false
+}
+
 // appName returns the formatted name of an app for logging (namespace/name).
 func appName(app *broker.BrokerApp) string {
 	return app.Namespace + "/" + app.Name
@@ -477,14 +911,43 @@ func (reconciler *BrokerServiceInstanceReconciler) validateAppForProvisioning(ap
 		return false, "does not match appSelectorExpression"
 	}
 
+	// Service matches the app's CEL selector expression
+	if matches, err := appselector.MatchesService(app, reconciler.instance, reconciler.Client); !matches || err != nil {
+		reconciler.log.Info("Rejecting app whose serviceSelectorExpression does not match the service (status.serviceBinding manually set?)",
+			"app", appName(app),
+			"service", serviceName(reconciler.instance),
+			"expression", app.Spec.ServiceSelectorExpression,
+			"error", err)
+		return false, "service does not match serviceSelectorExpression"
+	}
+
+	// App is placed on an existing peer, the app is reassigned when peers are removed
+	if peer := app.Status.Service.Peer; peer < 0 || peer >= PeerCount(reconciler.instance) {
+		reconciler.log.Info("Rejecting app placed on a peer that does not exist",
+			"app", appName(app),
+			"service", serviceName(reconciler.instance),
+			"peer", peer)
+		return false, fmt.Sprintf("peer %d does not exist", peer)
+	}
+
+	// App port is in the port pool, the app is reassigned a port when the range changes
+	if port := app.Status.Service.AssignedPort; port != UnassignedPort && !portInPool(reconciler.instance.Spec.PortRange, port) {
+		reconciler.log.Info("Rejecting app with a port outside the port range",
+			"app", appName(app),
+			"service", serviceName(reconciler.instance),
+			"port", port)
+		return false, fmt.Sprintf("port %d is outside the port range", port)
+	}
+
 	return true, ""
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) processService() error {
+func (reconciler *BrokerServiceInstanceReconciler) processService(index int32) {
 
 	var desired *corev1.Service
 
-	obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Service{}), reconciler.instance.Name)
+	peerName := PeerName(reconciler.instance.Name, index)
+	obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Service{}), peerName)
 	if obj != nil {
 		desired = obj.(*corev1.Service)
 	} else {
@@ -494,7 +957,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processService() error {
 				Kind:       "Service",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      reconciler.instance.Name,
+				Name:      peerName,
 				Namespace: reconciler.instance.Namespace,
 			},
 			Spec: corev1.ServiceSpec{
@@ -504,10 +967,16 @@ func (reconciler *BrokerServiceInstanceReconciler) processService() error {
 	}
 
 	desired.Spec.Selector = map[string]string{
-		common.LabelBrokerService: reconciler.instance.Name,
+		common.LabelBrokerService:   reconciler.instance.Name,
+		common.LabelBrokerPeerIndex: fmt.Sprintf("%d", index),
+	}
+
+	// ingresses and routes target the app acceptors by port name
+	desired.Spec.Ports = nil
+	if expose := reconciler.instance.Spec.Expose; expose != nil && expose.Mode != broker.BrokerServiceExposeModes.LoadBalancer {
+		desired.Spec.Ports = appServicePorts(nil, reconciler.validApps[index])
 	}
 	reconciler.TrackDesired(desired)
-	return nil
 }
 
 // appToServiceHandler handles BrokerApp events and enqueues the affected BrokerService(s).
@@ -515,8 +984,8 @@ func (reconciler *BrokerServiceInstanceReconciler) processService() error {
 type appToServiceHandler struct{}
 
 func (h *appToServiceHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
-	if req := h.getServiceRequest(evt.Object); req != nil {
-		q.Add(*req)
+	for _, req := range h.getServiceRequests(evt.Object) {
+		q.Add(req)
 	}
 }
 
@@ -524,6 +993,10 @@ func (h *appToServiceHandler) Update(ctx context.Context, evt event.UpdateEvent,
 	oldApp := evt.ObjectOld.(*broker.BrokerApp)
 	newApp := evt.ObjectNew.(*broker.BrokerApp)
 
+	if statisticsOnlyUpdate(oldApp, newApp) {
+		return
+	}
+
 	oldService := oldApp.Status.Service
 	newService := newApp.Status.Service
 
@@ -546,6 +1019,32 @@ func (h *appToServiceHandler) Update(ctx context.Context, evt event.UpdateEvent,
 			},
 		})
 	}
+
+	// Enqueue the service of a previous binding, retained while migrating and retired once complete
+	for _, migration := range []*broker.BrokerAppMigrationStatus{oldApp.Status.Migration, newApp.Status.Migration} {
+		if migration != nil {
+			q.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: migration.From.Namespace,
+					Name:      migration.From.Name,
+				},
+			})
+		}
+	}
+}
+
+// statisticsOnlyUpdate is true when the update only records the queue statistics of the app,
+// which the service does not depend on
+func statisticsOnlyUpdate(oldApp, newApp *broker.BrokerApp) bool {
+	withoutStatistics := func(app *broker.BrokerApp) *broker.BrokerApp {
+		clone := app.DeepCopy()
+		clone.ResourceVersion = ""
+		clone.ManagedFields = nil
+		clone.Status.Addresses = nil
+		clone.Status.StatisticsTime = nil
+		return clone
+	}
+	return equality.Semantic.DeepEqual(withoutStatistics(oldApp), withoutStatistics(newApp))
 }
 
 func sameService(a, b *broker.BrokerServiceBindingStatus) bool {
@@ -556,40 +1055,47 @@ func sameService(a, b *broker.BrokerServiceBindingStatus) bool {
 }
 
 func (h *appToServiceHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
-	if req := h.getServiceRequest(evt.Object); req != nil {
-		q.Add(*req)
+	for _, req := range h.getServiceRequests(evt.Object) {
+		q.Add(req)
 	}
 }
 
 func (h *appToServiceHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
-	if req := h.getServiceRequest(evt.Object); req != nil {
-		q.Add(*req)
+	for _, req := range h.getServiceRequests(evt.Object) {
+		q.Add(req)
 	}
 }
 
-func (h *appToServiceHandler) getServiceRequest(obj client.Object) *reconcile.Request {
+func (h *appToServiceHandler) getServiceRequests(obj client.Object) []reconcile.Request {
 	app := obj.(*broker.BrokerApp)
+	var requests []reconcile.Request
 	if app.Status.Service != nil {
-		return &reconcile.Request{
+		requests = append(requests, reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: app.Status.Service.Namespace,
 				Name:      app.Status.Service.Name,
 			},
-		}
+		})
 	}
-	return nil
+	if app.Status.Migration != nil {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: app.Status.Migration.From.Namespace,
+				Name:      app.Status.Migration.From.Name,
+			},
+		})
+	}
+	return requests
 }
 
 func (r *BrokerServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = NewEventRecorder(mgr.GetEventRecorderFor("brokerservice-controller"))
+
 	// Note: Namespace informer is set up in main.go for CEL evaluation
 
 	// Index BrokerApp by status.service for efficient lookup
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &broker.BrokerApp{}, common.AppServiceBindingField, func(rawObj client.Object) []string {
-		app := rawObj.(*broker.BrokerApp)
-		if app.Status.Service != nil {
-			return []string{app.Status.Service.Key()}
-		}
-		return nil
+		return AppServiceBindingKeys(rawObj.(*broker.BrokerApp))
 	}); err != nil {
 		return err
 	}
@@ -597,10 +1103,53 @@ func (r *BrokerServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&broker.BrokerService{}).
 		Owns(&broker.Broker{}).
+		// load balancer addresses are reported in the status
+		Owns(&corev1.Service{}).
 		Watches(&broker.BrokerApp{}, &appToServiceHandler{}).
 		Complete(r)
 }
 
+// addAddressPolicyProperties adds the addressSettings of an address that declares a delivery policy
+func addAddressPolicyProperties(props map[string]string, addrType *broker.AddressType) {
+	settings := addrType.Settings
+	if settings == nil {
+		return
+	}
+
+	escapedAddressName := escapeForProperties(addrType.Address)
+	add := func(name string, value string) {
+		props[fmt.Sprintf("addressSettings.\"%s\".%s=%s\n", escapedAddressName, name, strings.ReplaceAll(value, `\`, `\\`))] = ""
+	}
+
+	if settings.DeadLetterAddress != nil {
+		add("deadLetterAddress", *settings.DeadLetterAddress)
+	}
+	if settings.MaxDeliveryAttempts != nil {
+		add("maxDeliveryAttempts", fmt.Sprintf("%d", *settings.MaxDeliveryAttempts))
+	}
+	if settings.RedeliveryDelay != nil {
+		add("redeliveryDelay", fmt.Sprintf("%d", *settings.RedeliveryDelay))
+	}
+	if settings.RedeliveryMultiplier != nil {
+		add("redeliveryMultiplier", *settings.RedeliveryMultiplier)
+	}
+	if settings.MaxRedeliveryDelay != nil {
+		add("maxRedeliveryDelay", fmt.Sprintf("%d", *settings.MaxRedeliveryDelay))
+	}
+	if settings.ExpiryAddress != nil {
+		add("expiryAddress", *settings.ExpiryAddress)
+	}
+	if settings.ExpiryDelay != nil {
+		add("expiryDelay", fmt.Sprintf("%d", *settings.ExpiryDelay))
+	}
+	if settings.MaxSizeBytes != nil {
+		add("maxSizeBytes", *settings.MaxSizeBytes)
+	}
+	if settings.AddressFullPolicy != nil {
+		add("addressFullPolicy", *settings.AddressFullPolicy)
+	}
+}
+
 type AddressConfig struct {
 	senderRoles     map[string]string
 	consumerRoles   map[string]string
@@ -612,6 +1161,9 @@ type AddressConfig struct {
 	isOwned bool
 
 	isMulticast bool
+
+	// subscriptionSettings are the queue attributes of a subscription (FQQN) entry
+	subscriptionSettings *broker.SubscriptionSettingsType
 }
 
 type AddressTracker struct {
@@ -653,12 +1205,33 @@ func (t *AddressTracker) trackAddressType(addrType *broker.AddressType) *Address
 		for _, subName := range addrType.Subscriptions {
 			fqqnEntry := t.track(&broker.AddressRef{Address: addrType.Address + FQQNSeparator + subName})
 			fqqnEntry.isMulticast = true
+			fqqnEntry.subscriptionSettings = subscriptionSettingsFor(addrType.SubscriptionSettings, subName)
 		}
 	}
 	return addressConfig
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *corev1.Secret, app *broker.BrokerApp) (err error) {
+// counts returns the number of addresses owned and queues declared by the tracked app,
+// the queues of anycast addresses referenced from other apps are declared by their owner
+func (t *AddressTracker) counts() (addresses int64, queues int64) {
+	for name, entry := range t.names {
+		if strings.Contains(name, FQQNSeparator) {
+			// subscription queues are declared by the subscriber
+			queues++
+			continue
+		}
+		if entry.isOwned {
+			addresses++
+			if !entry.isMulticast {
+				queues++
+			}
+		}
+	}
+	return addresses, queues
+}
+
+// newAppAddressTracker tracks the addresses and queues of an app with the roles its capabilities grant
+func newAppAddressTracker(app *broker.BrokerApp) (*AddressTracker, error) {
 	addressTracker := newAddressTracker()
 
 	role := AppIdentity(app)
@@ -674,6 +1247,13 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 		addressTracker.trackAddressType(&addrType)
 	}
 
+	// Track dead letter and expiry addresses (owned by this app), the app consumes
+	// the messages the broker moves to them
+	for _, policyAddress := range collectPolicyAddresses(app) {
+		entry := addressTracker.track(&broker.AddressRef{Address: policyAddress})
+		entry.consumerRoles[role] = role
+	}
+
 	// Then, process capabilities to find inline addresses and capture roles
 	for _, capability := range app.Spec.Capabilities {
 
@@ -702,7 +1282,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 
 				// Validate idempotency: check if address was already marked as MULTICAST
 				if entry.isOwned && entry.isMulticast {
-					return fmt.Errorf(
+					return nil, fmt.Errorf(
 						"address '%s' is referenced with both pubSub and non pubSub semantics. "+
 							"This creates a conflict. Use consistent semantics for the same address",
 						addressRef.Address)
@@ -713,7 +1293,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 
 				// Validate idempotency: check if address was already used with ANYCAST
 				if entry.isOwned && len(entry.consumerRoles) > 0 && !entry.isMulticast {
-					return fmt.Errorf(
+					return nil, fmt.Errorf(
 						"address '%s' is referenced with both pubSub and non pubSub semantics. "+
 							"This creates a conflict. Use consistent semantics for the same address",
 						addressRef.Address)
@@ -731,13 +1311,33 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 					})
 					queueEntry.subscriberRoles[role] = role
 					queueEntry.isMulticast = true
+
+					// a subscription declared more than once must have the same queue attributes
+					if settings := subscriptionSettingsFor(addressRef.SubscriptionSettings, queueName); settings != nil {
+						if queueEntry.subscriptionSettings != nil && !equality.Semantic.DeepEqual(queueEntry.subscriptionSettings, settings) {
+							return nil, fmt.Errorf("subscription '%s' is declared with different subscriptionSettings", fqqn)
+						}
+						queueEntry.subscriptionSettings = settings
+					}
 				}
 			}
 		}
 	}
 
+	return addressTracker, nil
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *corev1.Secret, app *broker.BrokerApp) (err error) {
+	addressTracker, err := newAppAddressTracker(app)
+	if err != nil {
+		return err
+	}
+
 	props := map[string]string{} // need to dedup
 
+	// the previously generated queue attributes that are no longer configured are reset
+	previous := string(reconciler.deployedAppData[secret][AppIdentityPrefixed(app, "capabilities.properties")])
+
 	// Track all queue names for metrics generation
 	queueNamesForMetrics := make(map[string]bool)
 
@@ -777,6 +1377,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 		if isFQQN {
 			props[fmt.Sprintf("addressConfigurations.\"%s\".queueConfigs.\"%s\".routingType=MULTICAST\n", address, queueName)] = ""
 			props[fmt.Sprintf("addressConfigurations.\"%s\".queueConfigs.\"%s\".address=%s\n", address, queueName, address)] = ""
+			addSubscriptionSettingsProperties(props, address, queueName, addr.subscriptionSettings, previous)
 			queueNamesForMetrics[queueName] = true
 		}
 
@@ -790,18 +1391,57 @@ func (reconciler *BrokerServiceInstanceReconciler) processCapabilities(secret *c
 			props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".consume=true\n", escapedAddressName, consumerRole(role))] = ""
 		}
 
-		// 2026-05-12T17:17:22.906184695Z Thread-0 (activemq-brokerservice-mqtt119a) INFO AMQ601264: User test-mqtt-app(test-mqtt-app-consumer,test-mqtt-app-producer)@10.244.12.28:45426 gets security check failure, reason = AMQ229213:
-		// User: test-mqtt-app does not have permission='CONSUME' for queue my-client.mytopic.* on address mytopic.*
-		// securityRoles."(mytopic.*\:\:my-client.mytopic.*)"."test-mqtt-app-consumer".consume=true
-
 		for _, role := range addr.subscriberRoles {
-			// but security store does not support literal match markers!
-			// https://issues.apache.org/jira/browse/ARTEMIS-6057
-			//			props[fmt.Sprintf("securityRoles.\"(%s)\".\"%s\".consume=true\n", escapedAddressName, consumerRole(role))] = ""
+			if isFQQN && isWildcardAddress(fqqn[0]) {
+				// the security store does not support literal match markers to match the fqqn of a wildcard
+				// address, https://issues.apache.org/jira/browse/ARTEMIS-6057, consume is granted on the wildcard
+				// address, clash detection ensures it only matches addresses the app may consume from
+				props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".consume=true\n", address, consumerRole(role))] = ""
+				continue
+			}
 			props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".consume=true\n", escapedAddressName, consumerRole(role))] = ""
 		}
 	}
 
+	// The brokers of the apps that share the addresses the app consumes from other services send with the link cert,
+	// the addresses are declared here on behalf of those apps
+	for _, link := range app.Status.Links {
+		if link.Direction != broker.LinkDirections.Inbound {
+			continue
+		}
+		address := escapeForProperties(extractBaseAddress(link.Address))
+		if strings.Contains(link.Address, FQQNSeparator) {
+			props[fmt.Sprintf("addressConfigurations.\"%s\".routingTypes=MULTICAST\n", address)] = ""
+		} else {
+			props[fmt.Sprintf("addressConfigurations.\"%s\".routingTypes=ANYCAST\n", address)] = ""
+		}
+		props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".send=true\n", address, linkRole(AppIdentity(app)))] = ""
+	}
+
+	// Generate the roles of browserOf, requestReplyOn and managerOf
+	repliers, err := reconciler.requestRepliers(app)
+	if err != nil {
+		return err
+	}
+	addCapabilityVerbProperties(props, app, repliers)
+
+	// Generate the diverts of the routes
+	addRouteProperties(props, app)
+
+	// Generate the delivery policy of declared addresses
+	for _, addrTypes := range [][]broker.AddressType{app.Spec.Addresses, app.Spec.SharedAddresses} {
+		for i := range addrTypes {
+			addAddressPolicyProperties(props, &addrTypes[i])
+		}
+	}
+
+	// The previous binding of a migrating app sends the messages of its queues
+	if app.Status.Migration != nil {
+		for _, queue := range appMigrationQueues(app) {
+			props[fmt.Sprintf("securityRoles.\"%s\".\"%s\".send=true\n", escapeForProperties(queue.address), migrationRole(AppIdentity(app)))] = ""
+		}
+	}
+
 	// Generate metrics roles for all queues
 	for queueName := range queueNamesForMetrics {
 		for _, rbacRole := range []string{"metrics", metricsRole(AppIdentity(app))} {
@@ -845,25 +1485,17 @@ func (reconciler *BrokerServiceInstanceReconciler) processAcceptor(serverConfigP
 
 	realmName := jaasConfigRealmName(app)
 
-	// process authN cert login module params
-
-	/* TODO: pull down full DN from app cert
-
-	var appCert *tls.Certificate
-	if appCert, err = common.ExtractCertFromSecret(...); err != nil {
-		return nil, err
+	// process authN cert login module params, the users file matches the full DN with an anchored pattern
+	usersBuf := NewPropsWithHeader()
+	fmt.Fprintf(usersBuf, "%s=%s\n", namespacedName, escapePropertyValue(CertSubjectPattern(AppCertSubject(app))))
+	if app.Status.Migration != nil {
+		// the previous binding moves its messages with the migration cert
+		fmt.Fprintf(usersBuf, "%s=%s\n", migrationRole(namespacedName), escapePropertyValue(CertSubjectPattern(MigrationCertSubject(app))))
 	}
-
-	var appCertSubject *pkix.Name
-	if operatorCertSubject, err = common.ExtractCertSubject(appCert); err != nil {
-		return nil, err
+	if hasInboundLinks(app) {
+		// the brokers of the apps that share the addresses the app consumes from other services
+		fmt.Fprintf(usersBuf, "%s=%s\n", linkRole(namespacedName), escapePropertyValue(CertSubjectPattern(LinkCertSubject(app))))
 	}
-	*/
-	usersBuf := NewPropsWithHeader()
-	// Escape app name for safe use in regex pattern to prevent regex injection
-	// The namespacedName format is namespace-name which is already validated
-	escapedAppName := common.EscapeForRegex(app.Name)
-	fmt.Fprintf(usersBuf, "%s=/.*%s.*/\n", namespacedName, escapedAppName)
 
 	certUsersCfgKey := UnderscoreAppIdentityPrefixed(app, common.GetCertUsersKey(realmName))
 	serverConfigPropertiesSecret.Data[certUsersCfgKey] = usersBuf.Bytes()
@@ -877,6 +1509,22 @@ func (reconciler *BrokerServiceInstanceReconciler) processAcceptor(serverConfigP
 		if len(capability.ProducerOf) > 0 {
 			dedupMap[fmt.Sprintf("%s=%s\n", producerRole(namespacedName), namespacedName)] = ""
 		}
+		if len(capability.BrowserOf) > 0 {
+			dedupMap[fmt.Sprintf("%s=%s\n", browserRole(namespacedName), namespacedName)] = ""
+		}
+		if len(capability.RequestReplyOn) > 0 {
+			dedupMap[fmt.Sprintf("%s=%s\n", requesterRole(namespacedName), namespacedName)] = ""
+		}
+		if len(capability.ManagerOf) > 0 {
+			dedupMap[fmt.Sprintf("%s=%s\n", managerRole(namespacedName), namespacedName)] = ""
+		}
+	}
+
+	if app.Status.Migration != nil {
+		dedupMap[fmt.Sprintf("%s=%s\n", migrationRole(namespacedName), migrationRole(namespacedName))] = ""
+	}
+	if hasInboundLinks(app) {
+		dedupMap[fmt.Sprintf("%s=%s\n", linkRole(namespacedName), linkRole(namespacedName))] = ""
 	}
 
 	rolesBuf := NewPropsWithHeader()
@@ -914,8 +1562,12 @@ func (reconciler *BrokerServiceInstanceReconciler) processAcceptor(serverConfigP
 	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.needClientAuth=true\n", name)
 	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.saslMechanisms=EXTERNAL\n", name)
 
+	if app.Spec.MaxConnections != nil {
+		fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.connectionsAllowed=%d\n", name, *app.Spec.MaxConnections)
+	}
+
 	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.keyStoreType=PEMCFG\n", name)
-	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.keyStorePath=/amq/extra/secrets/%s/%s\n", name, AppPropertiesSecretName(reconciler.instance.Name), pemCfgkey)
+	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.keyStorePath=/amq/extra/secrets/%s/%s\n", name, serverConfigPropertiesSecret.Name, pemCfgkey)
 	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.trustStoreType=PEMCA\n", name)
 	fmt.Fprintf(buf, "acceptorConfigurations.\"%s\".params.trustStorePath=%s\n", name, trustStorePath)
 
@@ -924,7 +1576,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processAcceptor(serverConfigP
 	fmt.Fprintf(buf, "jaasConfigs.\"%s\".modules.cert.controlFlag=required\n", realmName)
 	fmt.Fprintf(buf, "jaasConfigs.\"%s\".modules.cert.params.\"org.apache.activemq.jaas.textfiledn.role\"=%s\n", realmName, certRolesCfgKey)
 	fmt.Fprintf(buf, "jaasConfigs.\"%s\".modules.cert.params.\"org.apache.activemq.jaas.textfiledn.user\"=%s\n", realmName, certUsersCfgKey)
-	fmt.Fprintf(buf, "jaasConfigs.\"%s\".modules.cert.params.baseDir=%s%s\n", realmName, common.SecretPathBase, AppPropertiesSecretName(reconciler.instance.Name))
+	fmt.Fprintf(buf, "jaasConfigs.\"%s\".modules.cert.params.baseDir=%s%s\n", realmName, common.SecretPathBase, serverConfigPropertiesSecret.Name)
 
 	serverConfigPropertiesSecret.Data[acceptorCfgKey] = buf.Bytes()
 
@@ -971,6 +1623,11 @@ func escapeForProperties(s string) string {
 	return s
 }
 
+// escapePropertyValue escapes a free text value so it stays on its own properties line
+func escapePropertyValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
 func producerRole(prefix string) string {
 	return fmt.Sprintf("%s-producer", prefix)
 }
@@ -1003,11 +1660,11 @@ func DashPrefixValue(prefix, value string) string {
 	return fmt.Sprintf("%s-%s", prefix, value)
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) controlPlaneOverrideSecretName() string {
-	return reconciler.instance.Name + "-control-plane-override"
+func controlPlaneOverrideSecretName(brokerName string) string {
+	return brokerName + "-control-plane-override"
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) processControlPlaneOverrideSecret(validApps []broker.BrokerApp) error {
+func (reconciler *BrokerServiceInstanceReconciler) processControlPlaneOverrideSecret(brokerName string, validApps []broker.BrokerApp) error {
 	// Collect all unique ConsumerOf and ProducerOf addresses from validated apps only
 	appQueues := make(map[string]bool)
 	for _, app := range validApps {
@@ -1044,7 +1701,7 @@ func (reconciler *BrokerServiceInstanceReconciler) processControlPlaneOverrideSe
 	// Get or create the control-plane-override secret
 	resourceName := types.NamespacedName{
 		Namespace: reconciler.instance.Namespace,
-		Name:      reconciler.controlPlaneOverrideSecretName(),
+		Name:      controlPlaneOverrideSecretName(brokerName),
 	}
 
 	var desired *corev1.Secret
@@ -1060,14 +1717,16 @@ func (reconciler *BrokerServiceInstanceReconciler) processControlPlaneOverrideSe
 	}
 
 	// Generate prometheus exporter yaml with queue-level metrics
-	prometheusConfig := reconciler.generatePrometheusConfig(appQueues)
+	prometheusConfig := reconciler.generatePrometheusConfig(brokerName, appQueues)
 	desired.Data[PrometheusConfigFileName] = prometheusConfig
 
 	reconciler.TrackDesired(desired)
 	return nil
 }
 
-func (reconciler *BrokerServiceInstanceReconciler) generatePrometheusConfig(appQueues map[string]bool) []byte {
+// generatePrometheusConfig generates the metrics config of a broker of the service, each peer and backup
+// mounts its own properties secret and registers its mbeans under its own name
+func (reconciler *BrokerServiceInstanceReconciler) generatePrometheusConfig(brokerName string, appQueues map[string]bool) []byte {
 	buf := NewPropsWithHeader() // yaml
 
 	// HTTP server config with mTLS
@@ -1081,7 +1740,7 @@ func (reconciler *BrokerServiceInstanceReconciler) generatePrometheusConfig(appQ
 	}
 
 	// Broker reconciler creates broker properties secret with "-props" suffix
-	brokerPropsSecretName := reconciler.instance.Name + "-props"
+	brokerPropsSecretName := brokerName + "-props"
 	mountPathRoot := fmt.Sprintf("%s%s", common.SecretPathBase, brokerPropsSecretName)
 
 	fmt.Fprintf(buf, "httpServer:\n")
@@ -1108,8 +1767,6 @@ func (reconciler *BrokerServiceInstanceReconciler) generatePrometheusConfig(appQ
 	fmt.Fprintf(buf, "includeObjectNames:\n")
 	fmt.Fprintf(buf, "  - \"org.apache.activemq.artemis:broker=*,component=addresses,address=*,subcomponent=queues,routing-type=*,queue=*\"\n")
 
-	brokerName := reconciler.instance.Name // Use service name as broker name for restricted mode
-
 	// Add queue-level attributes for specific queues with exact ObjectNames (include quotes) for canonocial string match, this restricts the attribute load
 	if len(appQueues) > 0 {
 		fmt.Fprintf(buf, "includeObjectNameAttributes:\n")
@@ -128,7 +128,7 @@ func TestBrokerServiceReconcileWithAppMove(t *testing.T) {
 
 	// Check S1 secret has app config
 	secretS1 := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretName(s1Name), Namespace: ns}, secretS1)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(s1Name, app), Namespace: ns}, secretS1)
 	assert.NoError(t, err)
 	// Check for some key related to the app
 	assert.True(t, hasKeyContaining(secretS1.Data, appName), "S1 secret should contain app config")
@@ -148,7 +148,7 @@ func TestBrokerServiceReconcileWithAppMove(t *testing.T) {
 	_, err = r.Reconcile(context.TODO(), reqS1)
 	assert.NoError(t, err)
 
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretName(s1Name), Namespace: ns}, secretS1)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(s1Name, app), Namespace: ns}, secretS1)
 	assert.NoError(t, err)
 	assert.False(t, hasKeyContaining(secretS1.Data, appName), "S1 secret should NOT contain app config after move")
 
@@ -158,7 +158,7 @@ func TestBrokerServiceReconcileWithAppMove(t *testing.T) {
 	assert.NoError(t, err)
 
 	secretS2 := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretName(s2Name), Namespace: ns}, secretS2)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(s2Name, app), Namespace: ns}, secretS2)
 	assert.NoError(t, err)
 	assert.True(t, hasKeyContaining(secretS2.Data, appName), "S2 secret should contain app config after move")
 }
@@ -516,7 +516,7 @@ func TestBrokerServiceReconcileStatusAppliedApps(t *testing.T) {
 	assert.Empty(t, updatedSvc.Status.ProvisionedApps)
 
 	// 2. Get generated Secret and its ResourceVersion
-	secretName := AppPropertiesSecretName(svcName)
+	secretName := AppPropertiesSecretNameForApp(svcName, app)
 	secret := &corev1.Secret{}
 	err = cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret)
 	assert.NoError(t, err)
@@ -536,12 +536,7 @@ func TestBrokerServiceReconcileStatusAppliedApps(t *testing.T) {
 			Reason: "Ready",
 		},
 	}
-	brokerCR.Status.ExternalConfigs = []v1beta2.ExternalConfigStatus{
-		{
-			Name:            secretName,
-			ResourceVersion: secret.ResourceVersion,
-		},
-	}
+	brokerCR.Status.ExternalConfigs = appliedAppSecrets(t, cl, ns, svcName)
 	err = cl.Status().Update(context.TODO(), brokerCR)
 	assert.NoError(t, err)
 
@@ -638,12 +633,8 @@ func TestBrokerServiceReconcileStatusAppliedAppsIncremental(t *testing.T) {
 	_, err := r.Reconcile(context.TODO(), req)
 	assert.NoError(t, err)
 
-	// Get generated Secret (v1)
-	secretName := AppPropertiesSecretName(svcName)
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret)
-	assert.NoError(t, err)
-	secretV1 := secret.ResourceVersion
+	// Get generated Secrets (v1)
+	secretsV1 := appliedAppSecrets(t, cl, ns, svcName)
 
 	// Update Broker Status to point to Secret v1
 	brokerCR := &v1beta2.Broker{}
@@ -656,12 +647,7 @@ func TestBrokerServiceReconcileStatusAppliedAppsIncremental(t *testing.T) {
 			Reason: "Ready",
 		},
 	}
-	brokerCR.Status.ExternalConfigs = []v1beta2.ExternalConfigStatus{
-		{
-			Name:            secretName,
-			ResourceVersion: secretV1,
-		},
-	}
+	brokerCR.Status.ExternalConfigs = secretsV1
 	err = cl.Status().Update(context.TODO(), brokerCR)
 	assert.NoError(t, err)
 
@@ -694,15 +680,13 @@ func TestBrokerServiceReconcileStatusAppliedAppsIncremental(t *testing.T) {
 	err = cl.Create(context.TODO(), app2)
 	assert.NoError(t, err)
 
-	// Reconcile to pick up App2. This updates the Secret to v2.
+	// Reconcile to pick up App2. This updates the Secret shard of App2 to v2.
 	_, err = r.Reconcile(context.TODO(), req)
 	assert.NoError(t, err)
 
 	// Verify Secret is updated
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret)
-	assert.NoError(t, err)
-	assert.NotEqual(t, secretV1, secret.ResourceVersion)
-	secretV2 := secret.ResourceVersion
+	secretsV2 := appliedAppSecrets(t, cl, ns, svcName)
+	assert.NotEqual(t, secretsV1, secretsV2)
 
 	// Verify AppliedApps STILL has App1 (and not App2 yet, because Broker Status still points to v1)
 	// IMPORTANT: It should NOT be empty.
@@ -713,12 +697,7 @@ func TestBrokerServiceReconcileStatusAppliedAppsIncremental(t *testing.T) {
 	// 3. Update Broker Status to point to Secret v2
 	err = cl.Get(context.TODO(), req.NamespacedName, brokerCR)
 	assert.NoError(t, err)
-	brokerCR.Status.ExternalConfigs = []v1beta2.ExternalConfigStatus{
-		{
-			Name:            secretName,
-			ResourceVersion: secretV2,
-		},
-	}
+	brokerCR.Status.ExternalConfigs = secretsV2
 	err = cl.Status().Update(context.TODO(), brokerCR)
 	assert.NoError(t, err)
 
@@ -789,12 +768,8 @@ func TestBrokerServiceReconcileAppsProvisionedCondition(t *testing.T) {
 	assert.Equal(t, metav1.ConditionFalse, cond.Status)
 	assert.Equal(t, v1beta2.AppsProvisionedConditionWaitingReason, cond.Reason)
 
-	// 2. Get generated Secret and its ResourceVersion
-	secretName := AppPropertiesSecretName(svcName)
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret)
-	assert.NoError(t, err)
-	assert.NotEmpty(t, secret.ResourceVersion)
+	// 2. Get generated Secrets and their ResourceVersion
+	currentSecrets := appliedAppSecrets(t, cl, ns, svcName)
 
 	// 3. Update Broker status to simulate broker picking up the config
 	brokerCR := &v1beta2.Broker{}
@@ -808,25 +783,16 @@ func TestBrokerServiceReconcileAppsProvisionedCondition(t *testing.T) {
 			Reason: "Ready",
 		},
 	}
-	brokerCR.Status.ExternalConfigs = []v1beta2.ExternalConfigStatus{
-		{
-			Name:            secretName,
-			ResourceVersion: secret.ResourceVersion,
-		},
-	}
+	brokerCR.Status.ExternalConfigs = appliedAppSecrets(t, cl, ns, svcName)
 	err = cl.Status().Update(context.TODO(), brokerCR)
 	assert.NoError(t, err)
 
-	currentSecretResourceVersion := secret.ResourceVersion
-
 	// 4. Second Reconcile: Should update AppsProvisioned
 	_, err = r.Reconcile(context.TODO(), req)
 	assert.NoError(t, err)
 
 	// verify no resource version change, still no apps
-	err = cl.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: ns}, secret)
-	assert.NoError(t, err)
-	assert.Equal(t, currentSecretResourceVersion, secret.ResourceVersion)
+	assert.Equal(t, currentSecrets, appliedAppSecrets(t, cl, ns, svcName))
 
 	// Verify AppsProvisioned condition is True
 	err = cl.Get(context.TODO(), req.NamespacedName, updatedSvc)
@@ -1472,3 +1438,74 @@ func TestBrokerServiceConditionTransitionsOnRecovery(t *testing.T) {
 	assert.Equal(t, metav1.ConditionTrue, deployedCond.Status)
 	assert.Equal(t, v1beta2.ReadyConditionReason, deployedCond.Reason)
 }
+
+func TestBrokerServicePortRange_RejectsAppOutsideRange(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).WithPortRange(30000, 30010).Build()
+	inRange := NewBrokerApp("in-range", ns).
+		WithServiceBinding(svcName, ns, "in-range-binding-secret", 30000).
+		Build()
+	outOfRange := NewBrokerApp("out-of-range", ns).
+		WithServiceBinding(svcName, ns, "out-of-range-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, inRange, outOfRange)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Len(t, updated.Status.RejectedApps, 1)
+	assert.Equal(t, "out-of-range", updated.Status.RejectedApps[0].Name)
+	assert.Equal(t, "port 61616 is outside the port range", updated.Status.RejectedApps[0].Reason)
+}
+
+func TestBrokerServicePortRange_Invalid(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPortRange(30010, 30000).Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	assert.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Contains(t, valid.Message, "PortRange")
+}
+
+func TestBrokerServicePortRange_ReservedPorts(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPortRange(8000, 8999, 8161).Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	if assert.NotNil(t, valid) {
+		assert.Equal(t, metav1.ConditionFalse, valid.Status)
+		assert.Equal(t, v1beta2.ValidConditionFailedReservedPortReason, valid.Reason)
+		assert.Contains(t, valid.Message, "reserved broker ports [8778 8888]")
+	}
+
+	// a range that excludes the reserved ports is valid
+	updated.Spec.PortRange.Exclusions = []int32{8161, 8778, 8888}
+	assert.NoError(t, env.Client.Update(context.TODO(), updated))
+
+	reconcileBrokerService(t, env, svcName)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	valid = meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	if assert.NotNil(t, valid) {
+		assert.Equal(t, metav1.ConditionTrue, valid.Status)
+	}
+}
@@ -363,7 +363,7 @@ var _ = Describe("broker-service edge cases and concurrent operations", func() {
 			}, existingClusterTimeout, existingClusterInterval).Should(Succeed())
 
 			By("verifying app is in secret with escaped keys")
-			secretName := AppPropertiesSecretName(serviceName)
+			secretName := AppPropertiesSecretNameForApp(serviceName, &app)
 			secret := &corev1.Secret{}
 			secretKey := types.NamespacedName{Name: secretName, Namespace: defaultNamespace}
 			Eventually(func(g Gomega) {
@@ -0,0 +1,338 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/ingresses"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/resources/routes"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ExternalTLSPort is the port of the ingress controller or router that passes TLS through to the app acceptors
+const ExternalTLSPort = 443
+
+// AppPortName returns the name of the Service port of an app acceptor
+func AppPortName(port int32) string {
+	return fmt.Sprintf("app-%d", port)
+}
+
+// AppExternalHost returns the SNI host of an app exposed with an ingress or route
+func AppExternalHost(service *broker.BrokerService, app *broker.BrokerApp) string {
+	return fmt.Sprintf("%s.%s", AppIdentity(app), service.Spec.Expose.IngressDomain)
+}
+
+// ExposedAppResourceName returns the name of the Ingress or Route of an app
+func ExposedAppResourceName(service *broker.BrokerService, app *broker.BrokerApp) string {
+	return fmt.Sprintf("%s-%s", service.Name, AppIdentity(app))
+}
+
+// LoadBalancerServiceName returns the name of the LoadBalancer Service of a peer
+func LoadBalancerServiceName(peerName string) string {
+	return fmt.Sprintf("%s-lb", peerName)
+}
+
+// AppExternalEndpoint returns the external host and port of the acceptor of an app bound to the service,
+// false when the service is not exposed or the load balancer of the peer has no address yet
+func AppExternalEndpoint(service *broker.BrokerService, app *broker.BrokerApp, binding *broker.BrokerServiceBindingStatus) (host string, port int32, ok bool) {
+	if service.Spec.Expose == nil || binding == nil {
+		return "", 0, false
+	}
+	if service.Spec.Expose.Mode == broker.BrokerServiceExposeModes.LoadBalancer {
+		for _, loadBalancer := range service.Status.LoadBalancers {
+			if loadBalancer.Peer == binding.Peer && loadBalancer.Host != "" {
+				return loadBalancer.Host, binding.AssignedPort, true
+			}
+		}
+		return "", 0, false
+	}
+	return AppExternalHost(service, app), ExternalTLSPort, true
+}
+
+// ingressesServed is true when the api server serves ingresses
+func (r *BrokerServiceReconciler) ingressesServed() bool {
+	_, err := r.Client.RESTMapper().RESTMapping(schema.GroupKind{Group: netv1.GroupName, Kind: "Ingress"}, netv1.SchemeGroupVersion.Version)
+	return err == nil
+}
+
+// routesServed is true when the api server serves OpenShift routes
+func (r *BrokerServiceReconciler) routesServed() bool {
+	_, err := r.Client.RESTMapper().RESTMapping(schema.GroupKind{Group: routev1.GroupName, Kind: "Route"}, routev1.GroupVersion.Version)
+	return err == nil
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) validateExpose() error {
+	expose := reconciler.instance.Spec.Expose
+	if expose == nil {
+		return nil
+	}
+
+	switch expose.Mode {
+	case broker.BrokerServiceExposeModes.Ingress, broker.BrokerServiceExposeModes.Route:
+		if expose.IngressDomain == "" {
+			return NewValidationError(
+				broker.ValidConditionFailedInvalidIngressSettings,
+				".Spec.Expose.IngressDomain is required for the %s mode", expose.Mode)
+		}
+		if expose.Mode == broker.BrokerServiceExposeModes.Ingress && !reconciler.ingressesServed() {
+			return NewValidationError(
+				broker.ValidConditionFailedInvalidExposeMode,
+				".Spec.Expose.Mode ingress requires ingresses, not served by the api server")
+		}
+		if expose.Mode == broker.BrokerServiceExposeModes.Route && !reconciler.routesServed() {
+			return NewValidationError(
+				broker.ValidConditionFailedInvalidExposeMode,
+				".Spec.Expose.Mode route requires OpenShift routes, use the ingress or loadBalancer mode")
+		}
+	case broker.BrokerServiceExposeModes.LoadBalancer:
+	default:
+		return NewValidationError(
+			broker.ValidConditionFailedInvalidExposeMode,
+			".Spec.Expose.Mode %s is invalid, must be one of ingress, route or loadBalancer", expose.Mode)
+	}
+	return nil
+}
+
+// appServicePorts returns a port per app acceptor, retaining the allocated node ports of the deployed ports
+func appServicePorts(deployed []corev1.ServicePort, apps []broker.BrokerApp) []corev1.ServicePort {
+	nodePorts := make(map[int32]int32, len(deployed))
+	for _, port := range deployed {
+		nodePorts[port.Port] = port.NodePort
+	}
+
+	var ports []corev1.ServicePort
+	for _, app := range apps {
+		port := app.Status.Service.AssignedPort
+		ports = append(ports, corev1.ServicePort{
+			Name:       AppPortName(port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
+			NodePort:   nodePorts[port],
+		})
+	}
+	return ports
+}
+
+// processExpose tracks the resources that expose the acceptors of the apps provisioned on a peer,
+// resources of apps that are no longer provisioned are not tracked and get removed
+func (reconciler *BrokerServiceInstanceReconciler) processExpose(index int32, apps []broker.BrokerApp) {
+	expose := reconciler.instance.Spec.Expose
+	if expose == nil {
+		return
+	}
+
+	peerName := PeerName(reconciler.instance.Name, index)
+	switch expose.Mode {
+	case broker.BrokerServiceExposeModes.LoadBalancer:
+		reconciler.processLoadBalancer(peerName, index, apps)
+	case broker.BrokerServiceExposeModes.Ingress:
+		for i := range apps {
+			reconciler.processAppIngress(peerName, &apps[i])
+		}
+	case broker.BrokerServiceExposeModes.Route:
+		for i := range apps {
+			reconciler.processAppRoute(peerName, &apps[i])
+		}
+	}
+}
+
+// setExposedCondition reports whether the service cert is valid for the SNI hosts of the apps exposed
+// with an ingress or route, clients fail the TLS handshake on a host the cert does not cover. The
+// condition is removed when no app is exposed by host or the cert secret can't be read, false when the
+// cert does not cover a host
+func (reconciler *BrokerServiceInstanceReconciler) setExposedCondition() bool {
+	expose := reconciler.instance.Spec.Expose
+	var hosts []string
+	if expose != nil && expose.IngressDomain != "" &&
+		(expose.Mode == broker.BrokerServiceExposeModes.Ingress || expose.Mode == broker.BrokerServiceExposeModes.Route) {
+		for _, apps := range reconciler.validApps {
+			for i := range apps {
+				hosts = append(hosts, AppExternalHost(reconciler.instance, &apps[i]))
+			}
+		}
+	}
+	var cert *x509.Certificate
+	if len(hosts) > 0 {
+		cert = reconciler.serviceCert()
+	}
+	if cert == nil {
+		meta.RemoveStatusCondition(&reconciler.status.Conditions, broker.ExposedConditionType)
+		return true
+	}
+
+	var invalid []string
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			invalid = append(invalid, host)
+		}
+	}
+	sort.Strings(invalid)
+
+	condition := metav1.Condition{
+		Type:   broker.ExposedConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: broker.ExposedConditionCertValidReason,
+	}
+	if len(invalid) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = broker.ExposedConditionCertInvalidReason
+		condition.Message = fmt.Sprintf("cert secret %s DNS names %v don't cover the app hosts %s, add a *.%s DNS name",
+			certSecretName(reconciler.instance), cert.DNSNames, strings.Join(invalid, ", "), expose.IngressDomain)
+		reconciler.recorder.Eventf(reconciler.instance, corev1.EventTypeWarning, EventReasonExposeCertNotValid, "%s", condition.Message)
+	}
+	meta.SetStatusCondition(&reconciler.status.Conditions, condition)
+	return len(invalid) == 0
+}
+
+// serviceCert returns the leaf cert of the service cert secret, nil when it is absent or not a PEM cert
+func (reconciler *BrokerServiceInstanceReconciler) serviceCert() *x509.Certificate {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: certSecretName(reconciler.instance), Namespace: reconciler.instance.Namespace}
+	if err := reconciler.Client.Get(context.TODO(), secretKey, secret); err != nil {
+		return nil
+	}
+	block, _ := pem.Decode(secret.Data["tls.crt"])
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) exposeLabels() map[string]string {
+	return map[string]string{
+		common.LabelAppKubernetesInstance:  reconciler.instance.Name,
+		common.LabelAppKubernetesComponent: "broker-service",
+		common.LabelAppKubernetesManagedBy: "arkmq-org-broker-operator",
+		common.LabelBrokerService:          reconciler.instance.Name,
+	}
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processAppIngress(peerName string, app *broker.BrokerApp) {
+	name := ExposedAppResourceName(reconciler.instance, app)
+
+	var existing *netv1.Ingress
+	if obj := reconciler.CloneOfDeployed(reflect.TypeOf(netv1.Ingress{}), name); obj != nil {
+		existing = obj.(*netv1.Ingress)
+	}
+
+	namespacedName := types.NamespacedName{Name: name, Namespace: reconciler.instance.Namespace}
+	desired := ingresses.NewIngressForCRWithSSL(existing, namespacedName, reconciler.exposeLabels(), peerName,
+		AppPortName(app.Status.Service.AssignedPort), true, "", AppExternalHost(reconciler.instance, app), false)
+	desired.Name = name
+
+	reconciler.TrackDesired(desired)
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processAppRoute(peerName string, app *broker.BrokerApp) {
+	name := ExposedAppResourceName(reconciler.instance, app)
+
+	var existing *routev1.Route
+	if obj := reconciler.CloneOfDeployed(reflect.TypeOf(routev1.Route{}), name); obj != nil {
+		existing = obj.(*routev1.Route)
+	}
+
+	namespacedName := types.NamespacedName{Name: name, Namespace: reconciler.instance.Namespace}
+	desired := routes.NewRouteDefinitionForCR(existing, namespacedName, reconciler.exposeLabels(), peerName,
+		AppPortName(app.Status.Service.AssignedPort), true, "", AppExternalHost(reconciler.instance, app))
+	desired.Name = name
+
+	reconciler.TrackDesired(desired)
+}
+
+func (reconciler *BrokerServiceInstanceReconciler) processLoadBalancer(peerName string, index int32, apps []broker.BrokerApp) {
+	name := LoadBalancerServiceName(peerName)
+
+	var desired *corev1.Service
+	if obj := reconciler.CloneOfDeployed(reflect.TypeOf(corev1.Service{}), name); obj != nil {
+		desired = obj.(*corev1.Service)
+	} else if len(apps) == 0 {
+		// a LoadBalancer Service needs a port
+		return
+	} else {
+		desired = &corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Service",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: reconciler.instance.Namespace,
+			},
+		}
+	}
+
+	desired.Labels = reconciler.exposeLabels()
+	desired.Spec.Type = corev1.ServiceTypeLoadBalancer
+	desired.Spec.Selector = map[string]string{
+		common.LabelBrokerService:   reconciler.instance.Name,
+		common.LabelBrokerPeerIndex: fmt.Sprintf("%d", index),
+	}
+	if len(apps) > 0 {
+		// the deployed ports are kept when the last app leaves so the external address is retained
+		desired.Spec.Ports = appServicePorts(desired.Spec.Ports, apps)
+	}
+
+	reconciler.TrackDesired(desired)
+}
+
+// loadBalancerStatus returns the external address of the deployed load balancer of each peer
+func (reconciler *BrokerServiceInstanceReconciler) loadBalancerStatus() []broker.BrokerServiceLoadBalancerStatus {
+	expose := reconciler.instance.Spec.Expose
+	if expose == nil || expose.Mode != broker.BrokerServiceExposeModes.LoadBalancer {
+		return nil
+	}
+
+	var loadBalancers []broker.BrokerServiceLoadBalancerStatus
+	for index := int32(0); index < PeerCount(reconciler.instance); index++ {
+		obj := reconciler.GetFromDeployed(reflect.TypeOf(corev1.Service{}), LoadBalancerServiceName(PeerName(reconciler.instance.Name, index)))
+		if obj == nil {
+			continue
+		}
+		for _, ingress := range obj.(*corev1.Service).Status.LoadBalancer.Ingress {
+			host := ingress.Hostname
+			if host == "" {
+				host = ingress.IP
+			}
+			if host != "" {
+				loadBalancers = append(loadBalancers, broker.BrokerServiceLoadBalancerStatus{Peer: index, Host: host})
+				break
+			}
+		}
+	}
+	return loadBalancers
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestBrokerServiceExpose_NotExposed(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, svcName)
+
+	peerService := &corev1.Service{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, peerService))
+	assert.Equal(t, corev1.ClusterIPNone, peerService.Spec.ClusterIP)
+	assert.Empty(t, peerService.Spec.Ports)
+
+	ingresses := &netv1.IngressList{}
+	assert.NoError(t, env.Client.List(context.TODO(), ingresses))
+	assert.Empty(t, ingresses.Items)
+}
+
+func TestBrokerServiceExpose_Ingress(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.Ingress, "apps.example.com").
+		Build()
+	orders := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	billing := NewBrokerApp("billing", ns).
+		WithServiceBinding(svcName, ns, "billing-binding-secret", 61617).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, orders, billing)
+
+	reconcileBrokerService(t, env, svcName)
+
+	// the peer Service names the app ports for the ingress backends
+	peerService := &corev1.Service{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, peerService))
+	assert.Equal(t, corev1.ClusterIPNone, peerService.Spec.ClusterIP)
+	portNames := []string{}
+	for _, port := range peerService.Spec.Ports {
+		portNames = append(portNames, port.Name)
+	}
+	assert.ElementsMatch(t, []string{"app-61616", "app-61617"}, portNames)
+
+	ingress := &netv1.Ingress{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: "my-broker-default-orders", Namespace: ns}, ingress))
+	assert.Equal(t, "default-orders.apps.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "true", ingress.Annotations["nginx.ingress.kubernetes.io/ssl-passthrough"])
+	assert.Equal(t, []string{"default-orders.apps.example.com"}, ingress.Spec.TLS[0].Hosts)
+	backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+	assert.Equal(t, svcName, backend.Name)
+	assert.Equal(t, "app-61616", backend.Port.Name)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: "my-broker-default-billing", Namespace: ns}, ingress))
+	assert.Equal(t, "default-billing.apps.example.com", ingress.Spec.Rules[0].Host)
+
+	// the ingress of an app that is gone is removed
+	assert.NoError(t, env.Client.Delete(context.TODO(), billing))
+	reconcileBrokerService(t, env, svcName)
+
+	err := env.Client.Get(context.TODO(), types.NamespacedName{Name: "my-broker-default-billing", Namespace: ns}, ingress)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestBrokerServiceExpose_Route(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).
+		WithPeers(2).
+		WithExpose(v1beta2.BrokerServiceExposeModes.Route, "apps.example.com").
+		Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	app.Status.Service.Peer = 1
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, svcName)
+
+	route := &routev1.Route{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: "my-broker-default-orders", Namespace: ns}, route))
+	assert.Equal(t, "default-orders.apps.example.com", route.Spec.Host)
+	assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination)
+	assert.Equal(t, PeerName(svcName, 1), route.Spec.To.Name)
+	assert.Equal(t, "app-61616", route.Spec.Port.TargetPort.String())
+}
+
+func TestBrokerServiceExpose_LoadBalancer(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.LoadBalancer, "").
+		Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, svcName)
+
+	loadBalancerKey := types.NamespacedName{Name: LoadBalancerServiceName(svcName), Namespace: ns}
+	loadBalancer := &corev1.Service{}
+	assert.NoError(t, env.Client.Get(context.TODO(), loadBalancerKey, loadBalancer))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, loadBalancer.Spec.Type)
+	assert.Len(t, loadBalancer.Spec.Ports, 1)
+	assert.Equal(t, int32(61616), loadBalancer.Spec.Ports[0].Port)
+
+	// the address of the load balancer is reported once assigned
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Empty(t, updated.Status.LoadBalancers)
+
+	loadBalancer.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+	assert.NoError(t, env.Client.Status().Update(context.TODO(), loadBalancer))
+
+	reconcileBrokerService(t, env, svcName)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Equal(t, []v1beta2.BrokerServiceLoadBalancerStatus{{Peer: 0, Host: "203.0.113.10"}}, updated.Status.LoadBalancers)
+
+	// the load balancer and its address are kept when the peer has no apps
+	assert.NoError(t, env.Client.Delete(context.TODO(), app))
+	reconcileBrokerService(t, env, svcName)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), loadBalancerKey, loadBalancer))
+	assert.Len(t, loadBalancer.Spec.Ports, 1)
+	assert.Equal(t, int32(61616), loadBalancer.Spec.Ports[0].Port)
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Equal(t, []v1beta2.BrokerServiceLoadBalancerStatus{{Peer: 0, Host: "203.0.113.10"}}, updated.Status.LoadBalancers)
+}
+
+func TestBrokerServiceExpose_IngressDomainRequired(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.Ingress, "").
+		Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	validCondition := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	assert.NotNil(t, validCondition)
+	assert.Equal(t, metav1.ConditionFalse, validCondition.Status)
+	assert.Equal(t, v1beta2.ValidConditionFailedInvalidIngressSettings, validCondition.Reason)
+}
+
+func TestBrokerServiceExpose_CertNotValidForAppHosts(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	issuer, err := certutil.NewIssuerFromSecret(withOperatorIssuer(t, ns))
+	assert.NoError(t, err)
+	service := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.Ingress, "apps.example.com").
+		Build()
+	app := NewBrokerApp("orders", ns).
+		WithServiceBinding(svcName, ns, "orders-binding-secret", 61616).
+		Build()
+	certPem, keyPem, err := issuer.IssueServingCert([]string{"my-broker.default.svc"}, time.Now(), time.Hour)
+	assert.NoError(t, err)
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: certSecretName(service), Namespace: ns},
+		Data:       map[string][]byte{"tls.crt": certPem, "tls.key": keyPem},
+	}
+	env := NewTestEnvironment(ns, oc, service, app, certSecret)
+
+	events := record.NewFakeRecorder(10)
+	r := NewBrokerServiceReconciler(env.Client, env.Scheme, nil, logr.New(log.NullLogSink{}))
+	r.recorder = NewEventRecorder(events)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svcName, Namespace: ns}}
+	result, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	// the cert secret is not watched, the service checks it again
+	assert.NotZero(t, result.RequeueAfter)
+
+	// the exposure is reported broken, clients can't verify the app host
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	exposed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ExposedConditionType)
+	assert.NotNil(t, exposed)
+	assert.Equal(t, metav1.ConditionFalse, exposed.Status)
+	assert.Equal(t, v1beta2.ExposedConditionCertInvalidReason, exposed.Reason)
+	assert.Equal(t, "cert secret my-broker-"+common.DefaultOperandCertSecretName+" DNS names [my-broker.default.svc] don't cover the app hosts default-orders.apps.example.com, add a *.apps.example.com DNS name", exposed.Message)
+	assert.False(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.ReadyConditionType))
+	assert.Equal(t, []string{"Warning ExposeCertNotValidForHosts " + exposed.Message}, recordedEvents(events))
+
+	// a wildcard DNS name of the ingress domain covers the app hosts
+	certPem, keyPem, err = issuer.IssueServingCert([]string{"my-broker.default.svc", "*.apps.example.com"}, time.Now(), time.Hour)
+	assert.NoError(t, err)
+	certSecret.Data = map[string][]byte{"tls.crt": certPem, "tls.key": keyPem}
+	assert.NoError(t, env.Client.Update(context.TODO(), certSecret))
+
+	result, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	exposed = meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ExposedConditionType)
+	assert.NotNil(t, exposed)
+	assert.Equal(t, metav1.ConditionTrue, exposed.Status)
+	assert.Equal(t, v1beta2.ExposedConditionCertValidReason, exposed.Reason)
+	assert.Empty(t, recordedEvents(events))
+}
+
+func TestBrokerAppBindingSecret_ExternalEndpoint(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	svc := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.Ingress, "apps.example.com").
+		Build()
+	app := NewBrokerApp("orders", ns).Build()
+	env := NewTestEnvironment(ns, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}, bindingSecret))
+	assert.Equal(t, "default-orders.apps.example.com", string(bindingSecret.Data["external-host"]))
+	assert.Equal(t, "443", string(bindingSecret.Data["external-port"]))
+	assert.Equal(t, "amqps://default-orders.apps.example.com:443", string(bindingSecret.Data["external-uri"]))
+
+	// in cluster access is unchanged
+	assert.Equal(t, "61616", string(bindingSecret.Data["port"]))
+}
+
+func TestBrokerAppBindingSecret_LoadBalancerEndpoint(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+
+	svc := NewBrokerService(svcName, ns).
+		WithExpose(v1beta2.BrokerServiceExposeModes.LoadBalancer, "").
+		Build()
+	app := NewBrokerApp("orders", ns).Build()
+	env := NewTestEnvironment(ns, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// no address yet
+	bindingSecretKey := types.NamespacedName{Name: BindingsSecretName(app.Name), Namespace: ns}
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	assert.NotContains(t, bindingSecret.Data, "external-host")
+
+	current := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, current))
+	current.Status.LoadBalancers = []v1beta2.BrokerServiceLoadBalancerStatus{{Peer: 0, Host: "lb.example.com"}}
+	assert.NoError(t, env.Client.Status().Update(context.TODO(), current))
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), bindingSecretKey, bindingSecret))
+	assert.Equal(t, "lb.example.com", string(bindingSecret.Data["external-host"]))
+	assert.Equal(t, "61616", string(bindingSecret.Data["external-port"]))
+	assert.Equal(t, "amqps://lb.example.com:61616", string(bindingSecret.Data["external-uri"]))
+}
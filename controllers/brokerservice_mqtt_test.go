@@ -186,9 +186,10 @@ var _ = Describe("broker-service", func() {
 								{
 									Address:       "mytopic",
 									Subscriptions: []string{"my-client.mytopic"},
-
-									// no support in the broker for liternal matches yet in security settings
-									// {Address: "mytopic.*::my-client.mytopic.*"},
+								},
+								{
+									Address:       "mytopic.*",
+									Subscriptions: []string{"my-client.mytopic.*"},
 								},
 							},
 						},
@@ -289,11 +289,10 @@ var _ = Describe("broker-service multi-app scenarios", func() {
 
 			app1ConfigKey := AppIdentityPrefixed(&app1, "capabilities.properties")
 			app2ConfigKey := AppIdentityPrefixed(&app2, "capabilities.properties")
-			secretName := AppPropertiesSecretName(serviceName)
-			secret := &corev1.Secret{}
-			secretKey := types.NamespacedName{Name: secretName, Namespace: defaultNamespace}
 			Eventually(func(g Gomega) {
-				g.Expect(k8sClient.Get(ctx, secretKey, secret)).Should(Succeed())
+				// the apps may land in different shards
+				secret, err := mergedAppSecrets(k8sClient, defaultNamespace, serviceName)
+				g.Expect(err).Should(Succeed())
 				// Check for app-specific keys in the secret
 				hasApp1Config := false
 				hasApp2Config := false
@@ -619,6 +618,8 @@ var _ = Describe("broker-service multi-app scenarios", func() {
 			InstallCert(app1CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app1CertName
 				candidate.Spec.CommonName = app1Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -691,6 +692,8 @@ var _ = Describe("broker-service multi-app scenarios", func() {
 			InstallCert(app2CertName, otherNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app2CertName
 				candidate.Spec.CommonName = app2Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
@@ -786,6 +789,8 @@ var _ = Describe("broker-service multi-app scenarios", func() {
 			InstallCert(app3CertName, defaultNamespace, func(candidate *cmv1.Certificate) {
 				candidate.Spec.SecretName = app3CertName
 				candidate.Spec.CommonName = app3Name
+				candidate.Spec.Subject.Organizations = nil
+				candidate.Spec.Subject.OrganizationalUnits = []string{defaultNamespace}
 				candidate.Spec.IssuerRef = cmmetav1.ObjectReference{
 					Name: caIssuer.Name,
 					Kind: "ClusterIssuer",
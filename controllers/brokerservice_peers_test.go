@@ -0,0 +1,286 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func withOperatorCA(t *testing.T, ns string) *corev1.Secret {
+	common.SetOperatorCASecretName("op_ca")
+	t.Cleanup(common.UnsetOperatorCASecretName)
+
+	common.SetOperatorNameSpace(ns)
+	t.Cleanup(common.UnsetOperatorNameSpace)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "op_ca",
+			Namespace: ns,
+		},
+		Data: map[string][]byte{"ca.pem": []byte("bla")},
+	}
+}
+
+func TestBrokerServicePeers_BrokersAndServices(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPeers(3).Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	for index := int32(0); index < 3; index++ {
+		peerName := PeerName(svcName, index)
+
+		peer := &v1beta2.Broker{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: peerName, Namespace: ns}, peer))
+		assert.Equal(t, svcName, peer.Spec.Labels[common.LabelBrokerService])
+		assert.Equal(t, fmt.Sprintf("%d", index), peer.Spec.Labels[common.LabelBrokerPeerIndex])
+		assert.Contains(t, peer.Spec.ExtraMounts.Secrets, AppPropertiesSecretName(peerName))
+
+		peerService := &corev1.Service{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: peerName, Namespace: ns}, peerService))
+		assert.Equal(t, map[string]string{
+			common.LabelBrokerService:   svcName,
+			common.LabelBrokerPeerIndex: fmt.Sprintf("%d", index),
+		}, peerService.Spec.Selector)
+
+		appSecret := &corev1.Secret{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretName(peerName), Namespace: ns}, appSecret))
+	}
+
+	assert.Equal(t, svcName, PeerName(svcName, 0))
+	assert.Equal(t, svcName+"-peer-2", PeerName(svcName, 2))
+}
+
+func TestBrokerServicePeers_AppSecretPerPeer(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).WithPeers(2).Build()
+	appA := NewBrokerApp("app-a", ns).
+		WithServiceBinding(svcName, ns, "app-a-binding-secret", 61616).
+		Build()
+	appB := NewBrokerApp("app-b", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithConsumerOf(NewAddressRef("orders").Build()).
+		WithServiceBinding(svcName, ns, "app-b-binding-secret", 61617).
+		WithServicePeer(1).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, appA, appB)
+
+	reconcileBrokerService(t, env, svcName)
+
+	peer0Secret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(svcName, appA), Namespace: ns}, peer0Secret))
+	assert.Equal(t, AppIdentity(appA), peer0Secret.Annotations[common.ProvisionedAppsAnnotation])
+
+	peer1Name := PeerName(svcName, 1)
+	peer1Secret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: AppPropertiesSecretNameForApp(peer1Name, appB), Namespace: ns}, peer1Secret))
+	assert.Equal(t, AppIdentity(appB), peer1Secret.Annotations[common.ProvisionedAppsAnnotation])
+
+	// the acceptor references the secret of its own peer
+	acceptor := string(peer1Secret.Data[AppIdentityPrefixed(appB, "acceptor.properties")])
+	assert.Contains(t, acceptor, fmt.Sprintf("keyStorePath=/amq/extra/secrets/%s/", AppPropertiesSecretNameForApp(peer1Name, appB)))
+	peer0Secrets, err := mergedAppSecrets(env.Client, ns, svcName)
+	assert.NoError(t, err)
+	assert.NotContains(t, peer0Secrets.Data, AppIdentityPrefixed(appB, "acceptor.properties"))
+
+	// each peer broker has its own metrics config
+	override := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: controlPlaneOverrideSecretName(peer1Name), Namespace: ns}, override))
+	metrics := string(override.Data[PrometheusConfigFileName])
+	assert.Contains(t, metrics, fmt.Sprintf("filename: /amq/extra/secrets/%s-props/_cert.pemcfg\n", peer1Name))
+	assert.Contains(t, metrics, fmt.Sprintf("org.apache.activemq.artemis:broker=\"%s\",component=addresses,address=\"orders\"", peer1Name))
+	assert.NotContains(t, metrics, fmt.Sprintf("/amq/extra/secrets/%s-props/", svcName))
+}
+
+func TestBrokerServicePeers_RejectsAppOnRemovedPeer(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).WithPeers(2).Build()
+	app := NewBrokerApp("app-a", ns).
+		WithServiceBinding(svcName, ns, "app-a-binding-secret", 61616).
+		WithServicePeer(2).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.Len(t, updated.Status.RejectedApps, 1)
+	assert.Equal(t, "peer 2 does not exist", updated.Status.RejectedApps[0].Reason)
+}
+
+func TestBrokerServicePeers_AppsProvisionedWaitsForAllPeers(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService(svcName, ns).WithPeers(2).Build()
+	appA := NewBrokerApp("app-a", ns).
+		WithServiceBinding(svcName, ns, "app-a-binding-secret", 61616).
+		Build()
+	appB := NewBrokerApp("app-b", ns).
+		WithServiceBinding(svcName, ns, "app-b-binding-secret", 61617).
+		WithServicePeer(1).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, appA, appB)
+
+	reconcileBrokerService(t, env, svcName)
+
+	applySecret := func(index int32) {
+		peerName := PeerName(svcName, index)
+
+		peer := &v1beta2.Broker{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: peerName, Namespace: ns}, peer))
+		peer.Status.Conditions = []metav1.Condition{
+			{Type: v1beta2.DeployedConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ReadyConditionReason},
+			{Type: v1beta2.ReadyConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ReadyConditionReason},
+		}
+		peer.Status.ExternalConfigs = appliedAppSecrets(t, env.Client, ns, peerName)
+		assert.NoError(t, env.Client.Update(context.TODO(), peer))
+	}
+
+	applySecret(0)
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.False(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.DeployedConditionType))
+	assert.False(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.AppsProvisionedConditionType))
+	assert.Equal(t, []string{AppIdentity(appA)}, updated.Status.ProvisionedApps)
+
+	applySecret(1)
+	reconcileBrokerService(t, env, svcName)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.DeployedConditionType))
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.AppsProvisionedConditionType))
+	assert.Equal(t, []string{AppIdentity(appA), AppIdentity(appB)}, updated.Status.ProvisionedApps)
+}
+
+func TestBrokerAppPeers_PlacedOnPeerWithMostMemory(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPeers(2).WithMemoryLimit("1Gi").Build()
+	existing := NewBrokerApp("existing", ns).
+		WithMemoryRequest("512Mi").
+		WithServiceBinding(svcName, ns, "existing-binding-secret", 61616).
+		Build()
+	app := NewBrokerApp("new-app", ns).WithMemoryRequest("768Mi").Build()
+
+	env := NewTestEnvironment(ns, service, existing, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.NotNil(t, updated.Status.Service)
+	assert.Equal(t, int32(1), updated.Status.Service.Peer)
+	assert.Equal(t, int32(61617), updated.Status.Service.AssignedPort)
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: updated.Status.Service.Secret, Namespace: ns}, bindingSecret))
+	assert.Equal(t, fmt.Sprintf("%s.%s.svc.%s", PeerName(svcName, 1), ns, common.GetClusterDomain()), string(bindingSecret.Data["host"]))
+}
+
+func TestBrokerAppPeers_NoPeerWithCapacity(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPeers(2).WithMemoryLimit("1Gi").Build()
+	peer0 := NewBrokerApp("peer0", ns).
+		WithMemoryRequest("512Mi").
+		WithServiceBinding(svcName, ns, "peer0-binding-secret", 61616).
+		Build()
+	peer1 := NewBrokerApp("peer1", ns).
+		WithMemoryRequest("512Mi").
+		WithServiceBinding(svcName, ns, "peer1-binding-secret", 61617).
+		WithServicePeer(1).
+		Build()
+	app := NewBrokerApp("new-app", ns).WithMemoryRequest("768Mi").Build()
+
+	env := NewTestEnvironment(ns, service, peer0, peer1, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+}
+
+func TestBrokerAppPeers_AddressRefPinsPeer(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).WithPeers(2).WithMemoryLimit("1Gi").Build()
+	// the owner is on the fuller peer
+	owner := NewBrokerApp("owner", ns).
+		WithMemoryRequest("512Mi").
+		WithSharedAddresses(NewAddressType("orders").Build()).
+		WithProducerOf(NewAddressRef("orders").Build()).
+		WithServiceBinding(svcName, ns, "owner-binding-secret", 61616).
+		WithServicePeer(1).
+		Build()
+	consumer := NewBrokerApp("consumer", ns).
+		WithConsumerOf(NewAddressRef("orders").WithAppRef(ns, "owner").Build()).
+		Build()
+
+	env := NewTestEnvironment(ns, service, owner, consumer)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: consumer.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.NotNil(t, updated.Status.Service)
+	assert.Equal(t, int32(1), updated.Status.Service.Peer)
+
+	// moving to another peer is corrected on the next reconcile
+	updated.Status.Service.Peer = 0
+	assert.NoError(t, env.Client.Status().Update(context.TODO(), updated))
+
+	_, err = env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Equal(t, int32(1), updated.Status.Service.Peer)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func reconcileBrokerService(t *testing.T, env *TestEnvironment, name string) {
+	r := NewBrokerServiceReconciler(env.Client, env.Scheme, nil, logr.New(log.NullLogSink{}))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: env.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+}
+
+func TestBrokerServicePersistence_NotConfigured(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	broker := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, broker))
+	assert.False(t, broker.Spec.PersistenceEnabled)
+	assert.Empty(t, broker.Spec.BrokerProperties)
+
+	backup := &v1beta2.Broker{}
+	err := env.Client.Get(context.TODO(), types.NamespacedName{Name: BackupBrokerName(svcName), Namespace: ns}, backup)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestBrokerServicePersistence_Journal(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{
+		Storage: v1beta2.StorageType{Size: "5Gi", StorageClassName: "fast"},
+	}
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	broker := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, broker))
+	assert.True(t, broker.Spec.PersistenceEnabled)
+	assert.Equal(t, "5Gi", broker.Spec.Storage.Size)
+	assert.Equal(t, "fast", broker.Spec.Storage.StorageClassName)
+	assert.Empty(t, broker.Spec.ExtraVolumes)
+
+	// no shared claim without ha, the broker owns its claim
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := env.Client.Get(context.TODO(), types.NamespacedName{Name: JournalClaimName(svcName), Namespace: ns}, pvc)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestBrokerServicePersistence_HAPeers(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{
+		Storage: v1beta2.StorageType{StorageClassName: "nfs"},
+		HA:      true,
+	}
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: JournalClaimName(svcName), Namespace: ns}, pvc))
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse(DefaultJournalStorageSize), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, "nfs", *pvc.Spec.StorageClassName)
+
+	for _, peerName := range []string{svcName, BackupBrokerName(svcName)} {
+		peer := &v1beta2.Broker{}
+		assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: peerName, Namespace: ns}, peer))
+
+		assert.False(t, peer.Spec.PersistenceEnabled, "journal is on the shared claim")
+		assert.Equal(t, svcName, peer.Spec.Labels[common.LabelBrokerService])
+		assert.Contains(t, peer.Spec.BrokerProperties, "HAPolicyConfiguration=SHARED_STORE_PRIMARY")
+		assert.Contains(t, peer.Spec.BrokerProperties, fmt.Sprintf("journalDirectory=%s/journal", SharedJournalMountPath))
+
+		assert.Len(t, peer.Spec.ExtraVolumes, 1)
+		assert.Equal(t, JournalClaimName(svcName), peer.Spec.ExtraVolumes[0].PersistentVolumeClaim.ClaimName)
+		assert.Len(t, peer.Spec.ExtraVolumeMounts, 1)
+		assert.Equal(t, SharedJournalMountPath, peer.Spec.ExtraVolumeMounts[0].MountPath)
+		assert.Contains(t, peer.Spec.ExtraMounts.Secrets, AppPropertiesSecretName(svcName))
+	}
+
+	backup := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BackupBrokerName(svcName), Namespace: ns}, backup))
+	assert.Contains(t, backup.Spec.ExtraMounts.Secrets, certSecretName(service))
+
+	// the backup exposes its metrics with its own cert and broker name
+	override := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: controlPlaneOverrideSecretName(BackupBrokerName(svcName)), Namespace: ns}, override))
+	assert.Contains(t, string(override.Data[PrometheusConfigFileName]), fmt.Sprintf("filename: /amq/extra/secrets/%s-props/_cert.pemcfg\n", BackupBrokerName(svcName)))
+}
+
+func TestBrokerServicePersistence_HADisabledRemovesBackup(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{HA: true}
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	current := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, current))
+	current.Spec.Persistence = nil
+	assert.NoError(t, env.Client.Update(context.TODO(), current))
+
+	reconcileBrokerService(t, env, svcName)
+
+	backup := &v1beta2.Broker{}
+	err := env.Client.Get(context.TODO(), types.NamespacedName{Name: BackupBrokerName(svcName), Namespace: ns}, backup)
+	assert.True(t, errors.IsNotFound(err))
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = env.Client.Get(context.TODO(), types.NamespacedName{Name: JournalClaimName(svcName), Namespace: ns}, pvc)
+	assert.True(t, errors.IsNotFound(err))
+
+	primary := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, primary))
+	assert.Empty(t, primary.Spec.BrokerProperties)
+	assert.Empty(t, primary.Spec.ExtraVolumes)
+}
+
+func TestBrokerServicePersistence_InvalidSize(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{
+		Storage: v1beta2.StorageType{Size: "lots"},
+	}
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	valid := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.ValidConditionType)
+	assert.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Contains(t, valid.Message, "Persistence.Storage.Size")
+}
+
+func TestBrokerServicePersistence_HADeployedWithBackupWaiting(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker"
+
+	service := NewBrokerService(svcName, ns).Build()
+	service.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{HA: true}
+	env := NewTestEnvironment(ns, service)
+
+	reconcileBrokerService(t, env, svcName)
+
+	// the backup won the journal lock, the primary waits
+	backup := &v1beta2.Broker{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: BackupBrokerName(svcName), Namespace: ns}, backup))
+	backup.Status.Conditions = []metav1.Condition{
+		{Type: v1beta2.DeployedConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ReadyConditionReason},
+	}
+	assert.NoError(t, env.Client.Update(context.TODO(), backup))
+
+	reconcileBrokerService(t, env, svcName)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: svcName, Namespace: ns}, updated))
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta2.DeployedConditionType))
+}
+
+func TestBrokerAppBindingSecret_HAPeers(t *testing.T) {
+	ns := "default"
+	svcName := "my-broker-service"
+	appName := "my-app"
+
+	svc := NewBrokerService(svcName, ns).Build()
+	svc.Spec.Persistence = &v1beta2.BrokerServicePersistenceType{HA: true}
+	app := NewBrokerApp(appName, ns).Build()
+
+	env := NewTestEnvironment(ns, svc, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: appName, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	updatedApp := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updatedApp))
+	assert.NotNil(t, updatedApp.Status.Service)
+	port := updatedApp.Status.Service.AssignedPort
+
+	bindingSecret := &corev1.Secret{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: updatedApp.Status.Service.Secret, Namespace: ns}, bindingSecret))
+
+	primaryHost := common.OrdinalFQDNS(svcName, ns, 0)
+	backupHost := common.OrdinalFQDNS(BackupBrokerName(svcName), ns, 0)
+
+	assert.Equal(t, fmt.Sprintf("%s.%s.svc.%s", svcName, ns, common.GetClusterDomain()), string(bindingSecret.Data["host"]))
+	assert.Equal(t, fmt.Sprintf("%s:%d,%s:%d", primaryHost, port, backupHost, port), string(bindingSecret.Data["peers"]))
+	assert.Equal(t, fmt.Sprintf("failover:(amqps://%s:%d,amqps://%s:%d)", primaryHost, port, backupHost, port), string(bindingSecret.Data["failover-uri"]))
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestBrokerAppScheduling_CordonedNoNewBindings(t *testing.T) {
+	ns := "default"
+
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	open := NewBrokerService("open", ns).Build()
+	bound := NewBrokerApp("bound", ns).
+		WithAddresses(NewAddressType("bound").Build()).
+		WithServiceBinding("cordoned", ns, "bound-binding-secret", 61616).
+		Build()
+	fresh := NewBrokerApp("fresh", ns).WithAddresses(NewAddressType("fresh").Build()).Build()
+	env := NewTestEnvironment(ns, cordoned, open, bound, fresh)
+
+	_, updated := reconcileBrokerApp(t, env, fresh.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "open", updated.Status.Service.Name)
+	}
+
+	// the apps already bound stay
+	_, updated = reconcileBrokerApp(t, env, bound.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "cordoned", updated.Status.Service.Name)
+	}
+	assert.Nil(t, updated.Status.Migration)
+}
+
+func TestBrokerAppScheduling_CordonedOnly(t *testing.T) {
+	ns := "default"
+
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	app := NewBrokerApp("fresh", ns).WithAddresses(NewAddressType("fresh").Build()).Build()
+	env := NewTestEnvironment(ns, cordoned, app)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: app.Name, Namespace: ns}}
+	_, err := env.Reconciler.Reconcile(context.TODO(), req)
+	assert.Error(t, err)
+
+	updated := &v1beta2.BrokerApp{}
+	assert.NoError(t, env.Client.Get(context.TODO(), req.NamespacedName, updated))
+	assert.Nil(t, updated.Status.Service)
+	deployed := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.DeployedConditionType)
+	if assert.NotNil(t, deployed) {
+		assert.Equal(t, metav1.ConditionFalse, deployed.Status)
+		assert.Contains(t, deployed.Message, "services are cordoned")
+	}
+}
+
+func TestBrokerAppScheduling_DrainingRebinds(t *testing.T) {
+	ns := "default"
+
+	draining := NewBrokerService("draining", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Draining).Build()
+	open := NewBrokerService("open", ns).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("draining", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, draining, open, app)
+
+	withQueueMessageCounts(t, map[string]int64{"orders::orders": 2}, nil)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "open", updated.Status.Service.Name)
+	}
+	if assert.NotNil(t, updated.Status.Migration) {
+		assert.Equal(t, "draining", updated.Status.Migration.From.Name)
+		assert.Equal(t, "the service is draining", updated.Status.Migration.Reason)
+	}
+}
+
+func TestBrokerAppScheduling_DrainingWithoutCapacity(t *testing.T) {
+	ns := "default"
+
+	draining := NewBrokerService("draining", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Draining).Build()
+	full := NewBrokerService("full", ns).WithPortRange(61616, 61616).Build()
+	other := NewBrokerApp("other", ns).
+		WithAddresses(NewAddressType("other").Build()).
+		WithServiceBinding("full", ns, "other-binding-secret", 61616).
+		Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("draining", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, draining, full, other, app)
+
+	result, updated := reconcileBrokerApp(t, env, app.Name)
+
+	// the binding is kept until another service has capacity
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, "draining", updated.Status.Service.Name)
+		assert.Equal(t, int32(61616), updated.Status.Service.AssignedPort)
+	}
+	assert.Nil(t, updated.Status.Migration)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RequeueAfter, DrainRetryInterval)
+}
+
+func TestBrokerServiceScheduling_Condition(t *testing.T) {
+	ns := "default"
+
+	oc := withOperatorCA(t, ns)
+	service := NewBrokerService("retiring", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	app := NewBrokerApp("orders", ns).
+		WithAddresses(NewAddressType("orders").Build()).
+		WithServiceBinding("retiring", ns, "orders-binding-secret", 61616).
+		Build()
+	env := NewTestEnvironment(ns, oc, service, app)
+
+	reconcileBrokerService(t, env, service.Name)
+
+	updated := &v1beta2.BrokerService{}
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: ns}, updated))
+	assert.Equal(t, int32(1), updated.Status.RemainingApps)
+	cordoned := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.CordonedConditionType)
+	if assert.NotNil(t, cordoned) {
+		assert.Equal(t, metav1.ConditionTrue, cordoned.Status)
+		assert.Equal(t, v1beta2.CordonedConditionCordonedReason, cordoned.Reason)
+		assert.Equal(t, "no new apps are bound, 1 apps remain", cordoned.Message)
+	}
+
+	updated.Spec.SchedulingPolicy = v1beta2.SchedulingPolicies.Draining
+	assert.NoError(t, env.Client.Update(context.TODO(), updated))
+	reconcileBrokerService(t, env, service.Name)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: ns}, updated))
+	cordoned = meta.FindStatusCondition(updated.Status.Conditions, v1beta2.CordonedConditionType)
+	if assert.NotNil(t, cordoned) {
+		assert.Equal(t, v1beta2.CordonedConditionDrainingReason, cordoned.Reason)
+		assert.Equal(t, "rebinding apps to other services, 1 apps remain", cordoned.Message)
+	}
+
+	// the last app moved off, the service can be retired
+	assert.NoError(t, env.Client.Delete(context.TODO(), app))
+	reconcileBrokerService(t, env, service.Name)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: ns}, updated))
+	assert.Equal(t, int32(0), updated.Status.RemainingApps)
+	cordoned = meta.FindStatusCondition(updated.Status.Conditions, v1beta2.CordonedConditionType)
+	if assert.NotNil(t, cordoned) {
+		assert.Equal(t, v1beta2.CordonedConditionDrainedReason, cordoned.Reason)
+	}
+
+	// back in rotation
+	updated.Spec.SchedulingPolicy = v1beta2.SchedulingPolicies.Schedulable
+	assert.NoError(t, env.Client.Update(context.TODO(), updated))
+	reconcileBrokerService(t, env, service.Name)
+
+	assert.NoError(t, env.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: ns}, updated))
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, v1beta2.CordonedConditionType))
+}
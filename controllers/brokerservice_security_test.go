@@ -105,11 +105,7 @@ func TestBrokerServiceRejectsManuallyAnnotatedApp(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify that the Secret was created (it should exist even with no apps)
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{
-		Name:      svc.Name + "-app-bp",
-		Namespace: svcNs,
-	}, secret)
+	secret, err := mergedAppSecrets(cl, svcNs, svc.Name)
 	assert.NoError(t, err, "App properties secret should be created")
 
 	// Secret should exist but should be EMPTY (no apps provisioned)
@@ -251,11 +247,7 @@ func TestBrokerServiceAllowsMatchingApp(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify that the Secret was created with the legitimate app
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{
-		Name:      svc.Name + "-app-bp",
-		Namespace: svcNs,
-	}, secret)
+	secret, err := mergedAppSecrets(cl, svcNs, svc.Name)
 	assert.NoError(t, err, "App properties secret should be created")
 
 	if err == nil {
@@ -371,11 +363,7 @@ func TestBrokerServiceRejectsLabelMismatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify that the Secret exists but app is NOT provisioned
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{
-		Name:      svc.Name + "-app-bp",
-		Namespace: svcNs,
-	}, secret)
+	secret, err := mergedAppSecrets(cl, svcNs, svc.Name)
 	assert.NoError(t, err, "App properties secret should be created")
 
 	if err == nil {
@@ -523,11 +511,7 @@ func TestBrokerServiceMixedApps(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify that the Secret only includes matching apps
-	secret := &corev1.Secret{}
-	err = cl.Get(context.TODO(), types.NamespacedName{
-		Name:      svc.Name + "-app-bp",
-		Namespace: svcNs,
-	}, secret)
+	secret, err := mergedAppSecrets(cl, svcNs, svc.Name)
 	assert.NoError(t, err, "App properties secret should be created")
 
 	if err == nil {
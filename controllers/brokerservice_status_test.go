@@ -466,7 +466,7 @@ var _ = Describe("broker-service status conditions", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(k8sClient.Get(ctx, brokerKey, brokerCrd)).Should(Succeed())
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == AppPropertiesSecretNameForApp(brokerCrd.Name, &app) {
 						initialConfigRV = externalConfig.ResourceVersion
 					}
 				}
@@ -492,7 +492,7 @@ var _ = Describe("broker-service status conditions", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(k8sClient.Get(ctx, brokerKey, brokerCrd)).Should(Succeed())
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == AppPropertiesSecretNameForApp(brokerCrd.Name, &app) {
 						updatedConfigRV = externalConfig.ResourceVersion
 					}
 				}
@@ -504,7 +504,7 @@ var _ = Describe("broker-service status conditions", func() {
 			}, existingClusterTimeout, existingClusterInterval).Should(Succeed())
 
 			By("verifying updated configuration is in secret")
-			secretName := AppPropertiesSecretName(serviceName)
+			secretName := AppPropertiesSecretNameForApp(serviceName, createdApp)
 			secret := &corev1.Secret{}
 			secretKey := types.NamespacedName{Name: secretName, Namespace: defaultNamespace}
 			Eventually(func(g Gomega) {
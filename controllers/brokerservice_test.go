@@ -234,6 +234,10 @@ var _ = Describe("broker-service-poc", func() {
 			brokerKey := types.NamespacedName{Name: crd.Name, Namespace: crd.Namespace}
 			brokerCrd := &broker.Broker{}
 
+			appName := "first-app" // a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+			// the app config lands in a single shard, identified by the app
+			appPropsSecretName := AppPropertiesSecretNameForApp(crd.Name, &broker.BrokerApp{ObjectMeta: metav1.ObjectMeta{Name: appName, Namespace: defaultNamespace}})
+
 			var appPropsRv = ""
 			Eventually(func(g Gomega) {
 				g.Expect(k8sClient.Get(ctx, brokerKey, brokerCrd)).Should(Succeed())
@@ -246,7 +250,7 @@ var _ = Describe("broker-service-poc", func() {
 				g.Expect(condition).NotTo(BeNil())
 
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == appPropsSecretName {
 						appPropsRv = externalConfig.ResourceVersion
 					}
 				}
@@ -255,7 +259,6 @@ var _ = Describe("broker-service-poc", func() {
 			}, existingClusterTimeout, existingClusterInterval).Should(Succeed())
 
 			By("deploying a matching app")
-			appName := "first-app" // a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
 			app := broker.BrokerApp{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "BrokerApp",
@@ -340,7 +343,7 @@ var _ = Describe("broker-service-poc", func() {
 				}
 
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == appPropsSecretName {
 						appPropsRvUpdated = externalConfig.ResourceVersion
 					}
 				}
@@ -569,7 +572,7 @@ var _ = Describe("broker-service-poc", func() {
 				}
 
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == appPropsSecretName {
 						appPropsRvUpdated = externalConfig.ResourceVersion
 					}
 				}
@@ -592,7 +595,7 @@ var _ = Describe("broker-service-poc", func() {
 				}
 
 				for _, externalConfig := range brokerCrd.Status.ExternalConfigs {
-					if externalConfig.Name == AppPropertiesSecretName(brokerCrd.Name) {
+					if externalConfig.Name == appPropsSecretName {
 						appPropsRvUpdated = externalConfig.ResourceVersion
 					}
 				}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-broker-arkmq-org-v1beta2-brokerservice,mutating=true,failurePolicy=fail,sideEffects=None,groups=broker.arkmq.org,resources=brokerservices,verbs=create;update,versions=v1beta2,name=mbrokerservice.broker.arkmq.org,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-broker-arkmq-org-v1beta2-brokerservice,mutating=false,failurePolicy=fail,sideEffects=None,groups=broker.arkmq.org,resources=brokerservices,verbs=create;update,versions=v1beta2,name=vbrokerservice.broker.arkmq.org,admissionReviewVersions=v1
+
+// SetupWebhookWithManager serves the defaulting and validating webhooks of BrokerServices, the
+// validating webhook rejects the specs the reconciler would report with a Valid=False condition
+func (r *BrokerServiceReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&broker.BrokerService{}).
+		WithDefaulter(&brokerServiceWebhook{reconciler: r}).
+		WithValidator(&brokerServiceWebhook{reconciler: r}).
+		Complete()
+}
+
+type brokerServiceWebhook struct {
+	reconciler *BrokerServiceReconciler
+}
+
+var _ admission.CustomDefaulter = &brokerServiceWebhook{}
+var _ admission.CustomValidator = &brokerServiceWebhook{}
+
+// Default sets the defaults the reconciler applies to an unset peers, placementStrategy and schedulingPolicy
+func (webhook *brokerServiceWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	service, ok := obj.(*broker.BrokerService)
+	if !ok {
+		return fmt.Errorf("expected a BrokerService but got a %T", obj)
+	}
+	if service.Spec.Peers == nil {
+		service.Spec.Peers = ptr.To(PeerCount(service))
+	}
+	if service.Spec.PlacementStrategy == "" {
+		service.Spec.PlacementStrategy = broker.PlacementStrategies.Spread
+	}
+	if service.Spec.SchedulingPolicy == "" {
+		service.Spec.SchedulingPolicy = broker.SchedulingPolicies.Schedulable
+	}
+	return nil
+}
+
+func (webhook *brokerServiceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	service, ok := obj.(*broker.BrokerService)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerService but got a %T", obj)
+	}
+	return nil, webhook.validate(service)
+}
+
+func (webhook *brokerServiceWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	previous, ok := oldObj.(*broker.BrokerService)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerService but got a %T", oldObj)
+	}
+	service, ok := newObj.(*broker.BrokerService)
+	if !ok {
+		return nil, fmt.Errorf("expected a BrokerService but got a %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(previous.Spec, service.Spec) {
+		return nil, nil
+	}
+	return nil, webhook.validate(service)
+}
+
+func (webhook *brokerServiceWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (webhook *brokerServiceWebhook) validate(service *broker.BrokerService) error {
+	instance := BrokerServiceInstanceReconciler{
+		BrokerServiceReconciler: webhook.reconciler,
+		instance:                service,
+		status:                  service.Status.DeepCopy(),
+	}
+	return instance.validateSpec()
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Event reasons of the lifecycle transitions of the custom resources
+const (
+	EventReasonBound                            = "Bound"
+	EventReasonUnbound                          = "Unbound"
+	EventReasonRebound                          = "Rebound"
+	EventReasonRejected                         = "Rejected"
+	EventReasonValidationFailed                 = "ValidationFailed"
+	EventReasonBrokerPropertiesApplied          = "BrokerPropertiesApplied"
+	EventReasonBrokerPropertiesAppliedWithError = "BrokerPropertiesAppliedWithError"
+	EventReasonScaleDownStarted                 = "ScaleDownStarted"
+	EventReasonScaleDownFinished                = "ScaleDownFinished"
+	EventReasonVersionUpgrade                   = "VersionUpgrade"
+	EventReasonMigrationWaiting                 = "MigrationWaiting"
+	EventReasonExposeCertNotValid               = "ExposeCertNotValidForHosts"
+)
+
+//+kubebuilder:rbac:groups="",namespace=arkmq-org-broker-operator,resources=events,verbs=create;patch
+
+// EventDeduplicationWindow is how long an event is not recorded again on an object while it is the last one
+const EventDeduplicationWindow = time.Hour
+
+type eventKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type recordedEvent struct {
+	eventtype string
+	reason    string
+	message   string
+	time      time.Time
+}
+
+// EventRecorder records the events of a controller, dropping an event identical to the last one
+// recorded on the same object within the deduplication window so that steady state reconciles,
+// that fail again the same way, don't repeat it
+type EventRecorder struct {
+	recorder record.EventRecorder
+
+	mutex    sync.Mutex
+	recorded map[eventKey]recordedEvent
+}
+
+func NewEventRecorder(recorder record.EventRecorder) *EventRecorder {
+	return &EventRecorder{recorder: recorder, recorded: map[eventKey]recordedEvent{}}
+}
+
+// Eventf records an event on the object unless it duplicates the last one recorded on the object,
+// a nil recorder records nothing
+func (events *EventRecorder) Eventf(object rtclient.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if events == nil || events.recorder == nil {
+		return
+	}
+	message := fmt.Sprintf(messageFmt, args...)
+	key := eventKey{
+		kind:      fmt.Sprintf("%T", object),
+		namespace: object.GetNamespace(),
+		name:      object.GetName(),
+	}
+	now := time.Now()
+
+	events.mutex.Lock()
+	for recordedKey, recorded := range events.recorded {
+		if now.Sub(recorded.time) >= EventDeduplicationWindow {
+			delete(events.recorded, recordedKey)
+		}
+	}
+	if last, found := events.recorded[key]; found && last.eventtype == eventtype && last.reason == reason && last.message == message {
+		events.mutex.Unlock()
+		return
+	}
+	events.recorded[key] = recordedEvent{eventtype: eventtype, reason: reason, message: message, time: now}
+	events.mutex.Unlock()
+
+	events.recorder.Event(object, eventtype, reason, message)
+}
+
+// recordStatusEvents records the transitions of the broker properties, scale down, validation and broker
+// version from the current status of a broker resource to its desired status
+func (events *EventRecorder) recordStatusEvents(object rtclient.Object, current, desired []metav1.Condition, currentVersion, desiredVersion string) {
+	if events == nil {
+		return
+	}
+
+	if valid := meta.FindStatusCondition(desired, v1beta2.ValidConditionType); valid != nil && valid.Status == metav1.ConditionFalse {
+		if previous := meta.FindStatusCondition(current, v1beta2.ValidConditionType); previous == nil || previous.Status != valid.Status || previous.Message != valid.Message {
+			events.Eventf(object, corev1.EventTypeWarning, EventReasonValidationFailed, "%s", valid.Message)
+		}
+	}
+
+	if applied := meta.FindStatusCondition(desired, v1beta2.ConfigAppliedConditionType); applied != nil {
+		previous := meta.FindStatusCondition(current, v1beta2.ConfigAppliedConditionType)
+		changed := previous == nil || previous.Reason != applied.Reason || previous.Message != applied.Message
+		if changed && applied.Status == metav1.ConditionTrue {
+			events.Eventf(object, corev1.EventTypeNormal, EventReasonBrokerPropertiesApplied, "broker properties applied")
+		} else if changed && applied.Reason == v1beta2.ConfigAppliedConditionSynchedWithErrorReason {
+			events.Eventf(object, corev1.EventTypeWarning, EventReasonBrokerPropertiesAppliedWithError, "%s", applied.Message)
+		}
+	}
+
+	scalingDown := meta.FindStatusCondition(desired, v1beta2.ScaleDownPendingConditionType)
+	wasScalingDown := meta.FindStatusCondition(current, v1beta2.ScaleDownPendingConditionType)
+	if scalingDown != nil && wasScalingDown == nil {
+		events.Eventf(object, corev1.EventTypeNormal, EventReasonScaleDownStarted, "scale down started: %s", scalingDown.Message)
+	} else if scalingDown == nil && wasScalingDown != nil {
+		events.Eventf(object, corev1.EventTypeNormal, EventReasonScaleDownFinished, "scale down finished")
+	}
+
+	if currentVersion != "" && desiredVersion != "" && currentVersion != desiredVersion {
+		events.Eventf(object, corev1.EventTypeNormal, EventReasonVersionUpgrade, "broker version changed from %s to %s", currentVersion, desiredVersion)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// recordedEvents drains the events recorded so far
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestEventRecorder_Deduplicates(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	events := NewEventRecorder(fake)
+	app := NewBrokerApp("orders", "test").Build()
+
+	events.Eventf(app, "Warning", EventReasonRejected, "no capacity")
+	events.Eventf(app, "Warning", EventReasonRejected, "no capacity")
+	assert.Equal(t, []string{"Warning Rejected no capacity"}, recordedEvents(fake))
+
+	// another object, or another message, is recorded
+	events.Eventf(NewBrokerApp("billing", "test").Build(), "Warning", EventReasonRejected, "no capacity")
+	events.Eventf(app, "Warning", EventReasonRejected, "no capacity on svc")
+	assert.Len(t, recordedEvents(fake), 2)
+
+	// the last event of the object changed
+	events.Eventf(app, "Normal", EventReasonBound, "bound")
+	events.Eventf(app, "Warning", EventReasonRejected, "no capacity on svc")
+	assert.Len(t, recordedEvents(fake), 2)
+
+	var none *EventRecorder
+	none.Eventf(app, "Normal", EventReasonBound, "bound")
+}
+
+func TestEventRecorder_StatusEvents(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	events := NewEventRecorder(fake)
+	cr := &v1beta2.Broker{ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "test"}}
+
+	current := []metav1.Condition{
+		{Type: v1beta2.ConfigAppliedConditionType, Status: metav1.ConditionFalse, Reason: v1beta2.ConfigAppliedConditionOutOfSyncReason},
+	}
+	desired := []metav1.Condition{
+		{Type: v1beta2.ConfigAppliedConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ConfigAppliedConditionSynchedReason},
+		{Type: v1beta2.ScaleDownPendingConditionType, Status: metav1.ConditionTrue, Reason: v1beta2.ScaleDownPendingConditionPendingEmptyReason, Message: "draining broker-ss-1"},
+	}
+	events.recordStatusEvents(cr, current, desired, "2.40.0", "2.41.0")
+	assert.Equal(t, []string{
+		"Normal BrokerPropertiesApplied broker properties applied",
+		"Normal ScaleDownStarted scale down started: draining broker-ss-1",
+		"Normal VersionUpgrade broker version changed from 2.40.0 to 2.41.0",
+	}, recordedEvents(fake))
+
+	// steady state
+	events.recordStatusEvents(cr, desired, desired, "2.41.0", "2.41.0")
+	assert.Empty(t, recordedEvents(fake))
+
+	current, desired = desired, []metav1.Condition{
+		{Type: v1beta2.ValidConditionType, Status: metav1.ConditionFalse, Reason: v1beta2.ValidConditionFailureReason, Message: "invalid spec"},
+		{Type: v1beta2.ConfigAppliedConditionType, Status: metav1.ConditionFalse, Reason: v1beta2.ConfigAppliedConditionSynchedWithErrorReason, Message: "bad property"},
+	}
+	events.recordStatusEvents(cr, current, desired, "2.41.0", "2.41.0")
+	assert.Equal(t, []string{
+		"Warning ValidationFailed invalid spec",
+		"Warning BrokerPropertiesAppliedWithError bad property",
+		"Normal ScaleDownFinished scale down finished",
+	}, recordedEvents(fake))
+}
+
+func TestBrokerAppEvents_BindAndReject(t *testing.T) {
+	ns := "default"
+
+	service := NewBrokerService("svc", ns).Build()
+	cordoned := NewBrokerService("cordoned", ns).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	app := NewBrokerApp("orders", ns).WithAddresses(NewAddressType("orders").Build()).Build()
+	env := NewTestEnvironment(ns, service, cordoned, app)
+	fake := record.NewFakeRecorder(10)
+	env.Reconciler.recorder = NewEventRecorder(fake)
+
+	_, updated := reconcileBrokerApp(t, env, app.Name)
+	if assert.NotNil(t, updated.Status.Service) {
+		assert.Equal(t, []string{"Normal Bound bound to service default:svc peer 0 on port 61616"}, recordedEvents(fake))
+	}
+
+	// steady state
+	reconcileBrokerApp(t, env, app.Name)
+	assert.Empty(t, recordedEvents(fake))
+
+	small := NewBrokerService("small", ns).WithMemoryLimit("64Mi").Build()
+	fresh := NewBrokerApp("fresh", ns).WithAddresses(NewAddressType("fresh").Build()).WithMemoryRequest("128Mi").Build()
+	env = NewTestEnvironment(ns, cordoned, small, fresh)
+	env.Reconciler.recorder = NewEventRecorder(fake)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: fresh.Name, Namespace: ns}}
+	for range 2 {
+		_, err := env.Reconciler.Reconcile(context.TODO(), req)
+		assert.Error(t, err)
+	}
+	// a single line, the rejection of each service is in status.placement
+	assert.Equal(t, []string{
+		"Warning Rejected no service can take the app, 2 of 2 services rejected (capacity: 1, cordoned: 1), see status.placement",
+	}, recordedEvents(fake))
+}
@@ -34,26 +34,94 @@ const (
 	MaxValidPort = 65535
 )
 
-// assignNextAvailablePort finds the next available port starting from DefaultStartPort
-func assignNextAvailablePort(usedPorts map[int32]bool) (int32, error) {
-	for port := int32(DefaultStartPort); port <= MaxValidPort; port++ {
-		if !usedPorts[port] {
+// reservedBrokerPorts are the ports of the broker containers that are never assigned to an app acceptor,
+// the console acceptor, jolokia and the prometheus metrics
+var reservedBrokerPorts = []int32{8161, 8778, 8888}
+
+// reservedPortsInPool returns the reserved broker ports the pool would assign
+func reservedPortsInPool(portRange *broker.BrokerServicePortRangeType) []int32 {
+	var reserved []int32
+	for _, port := range reservedBrokerPorts {
+		if portInPool(portRange, port) {
+			reserved = append(reserved, port)
+		}
+	}
+	return reserved
+}
+
+// portPoolBounds returns the first and last port of the pool, the default pool when no range is configured
+func portPoolBounds(portRange *broker.BrokerServicePortRangeType) (start int32, end int32) {
+	if portRange == nil {
+		return DefaultStartPort, MaxValidPort
+	}
+	return portRange.Start, portRange.End
+}
+
+// portInPool reports whether the port may be assigned from the pool
+func portInPool(portRange *broker.BrokerServicePortRangeType, port int32) bool {
+	start, end := portPoolBounds(portRange)
+	if port < start || port > end {
+		return false
+	}
+	if portRange != nil {
+		for _, excluded := range portRange.Exclusions {
+			if port == excluded {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// portPoolSize returns the number of ports that may be assigned from the pool
+func portPoolSize(portRange *broker.BrokerServicePortRangeType) int32 {
+	start, end := portPoolBounds(portRange)
+	if end > MaxValidPort {
+		end = MaxValidPort
+	}
+	if end < start {
+		return 0
+	}
+	size := end - start + 1
+	if portRange != nil {
+		excluded := make(map[int32]bool, len(portRange.Exclusions))
+		for _, port := range portRange.Exclusions {
+			if port >= start && port <= end && !excluded[port] {
+				excluded[port] = true
+				size--
+			}
+		}
+	}
+	return size
+}
+
+// assignNextAvailablePort finds the next available port of the pool, starting from the first port
+func assignNextAvailablePort(portRange *broker.BrokerServicePortRangeType, usedPorts map[int32]bool) (int32, error) {
+	start, end := portPoolBounds(portRange)
+	for port := start; port <= end && port <= MaxValidPort; port++ {
+		if !usedPorts[port] && portInPool(portRange, port) {
 			return port, nil
 		}
 	}
+	if portRange != nil && len(portRange.Exclusions) > 0 {
+		return 0, fmt.Errorf("all ports exhausted: range [%d-%d] excluding %v fully allocated",
+			start, end, portRange.Exclusions)
+	}
 	return 0, fmt.Errorf("all ports exhausted: range [%d-%d] fully allocated",
-		DefaultStartPort, MaxValidPort)
+		start, end)
 }
 
-// collectUsedPorts gathers ports already assigned on this service
-func collectUsedPorts(apps []broker.BrokerApp, excludeApp *broker.BrokerApp) map[int32]bool {
+// collectUsedPorts gathers pool ports already assigned on this service, assignments
+// outside the pool do not take ports from it
+func collectUsedPorts(portRange *broker.BrokerServicePortRangeType, apps []broker.BrokerApp, excludeApp *broker.BrokerApp) map[int32]bool {
 	used := make(map[int32]bool)
 	for _, app := range apps {
 		// Skip the app we're assigning (if reassigning)
 		if excludeApp != nil && app.Namespace == excludeApp.Namespace && app.Name == excludeApp.Name {
 			continue
 		}
-		if app.Status.Service != nil && app.Status.Service.AssignedPort != UnassignedPort {
+		if app.Status.Service != nil && app.Status.Service.AssignedPort != UnassignedPort &&
+			portInPool(portRange, app.Status.Service.AssignedPort) {
 			used[app.Status.Service.AssignedPort] = true
 		}
 	}
@@ -27,7 +27,7 @@ import (
 func TestAssignNextAvailablePort_FirstPort(t *testing.T) {
 	usedPorts := make(map[int32]bool)
 
-	port, err := assignNextAvailablePort(usedPorts)
+	port, err := assignNextAvailablePort(nil, usedPorts)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int32(61616), port)
@@ -39,7 +39,7 @@ func TestAssignNextAvailablePort_MiddlePort(t *testing.T) {
 		61617: true,
 	}
 
-	port, err := assignNextAvailablePort(usedPorts)
+	port, err := assignNextAvailablePort(nil, usedPorts)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int32(61618), port)
@@ -52,7 +52,7 @@ func TestAssignNextAvailablePort_LastPort(t *testing.T) {
 		usedPorts[port] = true
 	}
 
-	port, err := assignNextAvailablePort(usedPorts)
+	port, err := assignNextAvailablePort(nil, usedPorts)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int32(65535), port)
@@ -65,7 +65,7 @@ func TestAssignNextAvailablePort_Exhausted(t *testing.T) {
 		usedPorts[port] = true
 	}
 
-	port, err := assignNextAvailablePort(usedPorts)
+	port, err := assignNextAvailablePort(nil, usedPorts)
 
 	assert.Error(t, err)
 	assert.Equal(t, int32(0), port)
@@ -78,7 +78,7 @@ func TestAssignNextAvailablePort_Exhausted(t *testing.T) {
 func TestCollectUsedPorts_NoApps(t *testing.T) {
 	apps := []v1beta2.BrokerApp{}
 
-	used := collectUsedPorts(apps, nil)
+	used := collectUsedPorts(nil, apps, nil)
 
 	assert.Empty(t, used)
 }
@@ -95,7 +95,7 @@ func TestCollectUsedPorts_SingleApp(t *testing.T) {
 		},
 	}
 
-	used := collectUsedPorts(apps, nil)
+	used := collectUsedPorts(nil, apps, nil)
 
 	assert.Len(t, used, 1)
 	assert.True(t, used[61616])
@@ -120,7 +120,7 @@ func TestCollectUsedPorts_MultipleApps(t *testing.T) {
 		},
 	}
 
-	used := collectUsedPorts(apps, nil)
+	used := collectUsedPorts(nil, apps, nil)
 
 	assert.Len(t, used, 2)
 	assert.True(t, used[61616])
@@ -156,7 +156,7 @@ func TestCollectUsedPorts_ExcludeApp(t *testing.T) {
 		},
 	}
 
-	used := collectUsedPorts(apps, excludeApp)
+	used := collectUsedPorts(nil, apps, excludeApp)
 
 	// Should only have one port (61617), excluding the app's port (61616)
 	assert.Len(t, used, 1)
@@ -181,7 +181,7 @@ func TestCollectUsedPorts_AppsWithNoService(t *testing.T) {
 		},
 	}
 
-	used := collectUsedPorts(apps, nil)
+	used := collectUsedPorts(nil, apps, nil)
 
 	// Should only count the app with a service binding
 	assert.Len(t, used, 1)
@@ -207,10 +207,77 @@ func TestCollectUsedPorts_AppsWithZeroPort(t *testing.T) {
 		},
 	}
 
-	used := collectUsedPorts(apps, nil)
+	used := collectUsedPorts(nil, apps, nil)
 
 	// Should only count the app with a real port assignment
 	assert.Len(t, used, 1)
 	assert.True(t, used[61616])
 	assert.False(t, used[0]) // Zero port should not be counted
 }
+
+func TestAssignNextAvailablePort_PortRange(t *testing.T) {
+	portRange := &v1beta2.BrokerServicePortRangeType{Start: 30000, End: 30010, Exclusions: []int32{30000, 30002}}
+	usedPorts := map[int32]bool{30001: true}
+
+	port, err := assignNextAvailablePort(portRange, usedPorts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(30003), port)
+}
+
+func TestAssignNextAvailablePort_PortRangeExhausted(t *testing.T) {
+	portRange := &v1beta2.BrokerServicePortRangeType{Start: 30000, End: 30002, Exclusions: []int32{30001}}
+	usedPorts := map[int32]bool{30000: true, 30002: true}
+
+	port, err := assignNextAvailablePort(portRange, usedPorts)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), port)
+	assert.Contains(t, err.Error(), "[30000-30002]")
+	assert.Contains(t, err.Error(), "30001")
+}
+
+// TestCollectUsedPorts_OutsidePortRange tests that assignments outside the pool are not counted
+func TestCollectUsedPorts_OutsidePortRange(t *testing.T) {
+	portRange := &v1beta2.BrokerServicePortRangeType{Start: 30000, End: 30010, Exclusions: []int32{30005}}
+	apps := []v1beta2.BrokerApp{
+		{
+			Status: v1beta2.BrokerAppStatus{
+				Service: &v1beta2.BrokerServiceBindingStatus{
+					AssignedPort: 61616, // assigned before the range was configured
+				},
+			},
+		},
+		{
+			Status: v1beta2.BrokerAppStatus{
+				Service: &v1beta2.BrokerServiceBindingStatus{
+					AssignedPort: 30005, // excluded after assignment
+				},
+			},
+		},
+		{
+			Status: v1beta2.BrokerAppStatus{
+				Service: &v1beta2.BrokerServiceBindingStatus{
+					AssignedPort: 30001,
+				},
+			},
+		},
+	}
+
+	used := collectUsedPorts(portRange, apps, nil)
+
+	assert.Len(t, used, 1)
+	assert.True(t, used[30001])
+}
+
+func TestPortInPool(t *testing.T) {
+	assert.True(t, portInPool(nil, DefaultStartPort))
+	assert.False(t, portInPool(nil, DefaultStartPort-1))
+
+	portRange := &v1beta2.BrokerServicePortRangeType{Start: 30000, End: 30010, Exclusions: []int32{30005}}
+	assert.True(t, portInPool(portRange, 30000))
+	assert.True(t, portInPool(portRange, 30010))
+	assert.False(t, portInPool(portRange, 30005))
+	assert.False(t, portInPool(portRange, 30011))
+	assert.False(t, portInPool(portRange, DefaultStartPort))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestBrokerAppWebhook_Validate(t *testing.T) {
+	ns := "default"
+	env := NewTestEnvironment(ns)
+	validator := &brokerAppValidator{reconciler: env.Reconciler}
+
+	invalid := NewBrokerApp("invalid", ns).
+		WithConsumerOf(v1beta2.AddressRef{Address: "events", PubSub: ptr.To(true), Subscriptions: []string{}}).
+		Build()
+	_, err := validator.ValidateCreate(context.TODO(), invalid)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pubSub consumers must specify at least one subscription")
+	}
+
+	valid := NewBrokerApp("valid", ns).
+		WithConsumerOf(NewAddressRef("events").WithSubscriptions("audit").Build()).
+		Build()
+	_, err = validator.ValidateCreate(context.TODO(), valid)
+	assert.NoError(t, err)
+
+	// a metadata only update of an app that is no longer valid is allowed
+	updated := invalid.DeepCopy()
+	updated.Finalizers = nil
+	updated.Labels = map[string]string{"team": "a"}
+	_, err = validator.ValidateUpdate(context.TODO(), invalid, updated)
+	assert.NoError(t, err)
+
+	_, err = validator.ValidateUpdate(context.TODO(), valid, invalid)
+	assert.Error(t, err)
+
+	_, err = validator.ValidateCreate(context.TODO(), NewBrokerService("svc", ns).Build())
+	assert.Error(t, err)
+}
+
+func TestBrokerServiceWebhook_DefaultAndValidate(t *testing.T) {
+	ns := "default"
+	webhook := &brokerServiceWebhook{reconciler: NewBrokerServiceReconciler(nil, nil, nil, logr.New(log.NullLogSink{}))}
+
+	service := &v1beta2.BrokerService{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: ns}}
+	assert.NoError(t, webhook.Default(context.TODO(), service))
+	assert.Equal(t, ptr.To(PeerCount(service)), service.Spec.Peers)
+	assert.Equal(t, v1beta2.PlacementStrategies.Spread, service.Spec.PlacementStrategy)
+	assert.Equal(t, v1beta2.SchedulingPolicies.Schedulable, service.Spec.SchedulingPolicy)
+
+	// set values are kept
+	cordoned := NewBrokerService("cordoned", ns).WithPeers(3).WithSchedulingPolicy(v1beta2.SchedulingPolicies.Cordoned).Build()
+	assert.NoError(t, webhook.Default(context.TODO(), cordoned))
+	assert.Equal(t, ptr.To(int32(3)), cordoned.Spec.Peers)
+	assert.Equal(t, v1beta2.SchedulingPolicies.Cordoned, cordoned.Spec.SchedulingPolicy)
+
+	_, err := webhook.ValidateCreate(context.TODO(), service)
+	assert.NoError(t, err)
+
+	invalid := service.DeepCopy()
+	invalid.Spec.AppSelectorExpression = `app.metadata.namespace ==`
+	_, err = webhook.ValidateUpdate(context.TODO(), service, invalid)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid appSelectorExpression")
+	}
+}
+
+func TestBrokerWebhook_Validate(t *testing.T) {
+	validator := &brokerValidator{}
+	cr := &v1beta2.Broker{ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "default"}}
+
+	_, err := validator.ValidateCreate(context.TODO(), cr)
+	assert.NoError(t, err)
+
+	duplicate := cr.DeepCopy()
+	duplicate.Spec.BrokerProperties = []string{"globalMaxSize=1G", "globalMaxSize=2G"}
+	_, err = validator.ValidateUpdate(context.TODO(), cr, duplicate)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "duplicate key for globalMaxSize")
+	}
+
+	reserved := cr.DeepCopy()
+	reserved.Spec.Labels = map[string]string{"application": "mine"}
+	_, err = validator.ValidateCreate(context.TODO(), reserved)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is a reserved label")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"fmt"
 	goruntime "runtime"
@@ -54,6 +56,7 @@ import (
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/log"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/sdkk8sutil"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/webhookcert"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/version"
 
 	brokerv1alpha1 "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1alpha1"
@@ -74,6 +77,18 @@ var (
 	metricsPort int32 = 8383
 )
 
+// the webhook server serves the admission webhooks of the v1beta2 CRDs when ENABLE_WEBHOOKS is true,
+// the names match the resources of config/webhook
+const (
+	webhookPort                        = 9443
+	webhookServiceName                 = "arkmq-org-broker-webhook-service"
+	webhookSecretName                  = "arkmq-org-broker-webhook-server-cert"
+	validatingWebhookConfigurationName = "arkmq-org-broker-validating-webhook-configuration"
+	mutatingWebhookConfigurationName   = "arkmq-org-broker-mutating-webhook-configuration"
+)
+
+var webhookCertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+
 var (
 	//hard coded because the sdk version pkg is moved in internal package
 	sdkVersion = "1.28.0"
@@ -195,6 +210,14 @@ func main() {
 		Logger:                 setupLog,
 	}
 
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") == "true"
+	if enableWebhooks {
+		mgrOptions.WebhookServer = webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		})
+	}
+
 	mgrOptions.Client.WarningHandler.SuppressWarnings = true
 	rest.SetDefaultWarningHandler(&TraceLogWarnings{Log: ctrl.Log})
 
@@ -364,6 +387,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enableWebhooks {
+		certBootstrap := &webhookcert.Bootstrap{
+			Client:                             clnt,
+			Log:                                ctrl.Log.WithName("webhookcert"),
+			Namespace:                          oprNamespace,
+			SecretName:                         webhookSecretName,
+			ServiceName:                        webhookServiceName,
+			CertDir:                            webhookCertDir,
+			ValidatingWebhookConfigurationName: validatingWebhookConfigurationName,
+			MutatingWebhookConfigurationName:   mutatingWebhookConfigurationName,
+		}
+		if err = certBootstrap.Ensure(ctx); err != nil {
+			setupLog.Error(err, "unable to bootstrap the webhook serving cert")
+			os.Exit(1)
+		}
+		if err = mgr.Add(certBootstrap); err != nil {
+			setupLog.Error(err, "unable to refresh the webhook serving cert")
+			os.Exit(1)
+		}
+
+		if err = brokerCRReconciler.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Broker")
+			os.Exit(1)
+		}
+		if err = serviceReconciler.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BrokerService")
+			os.Exit(1)
+		}
+		if err = appReconciler.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BrokerApp")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
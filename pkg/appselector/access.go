@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appselector
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+)
+
+var (
+	// accessEnv is the CEL environment of the access expressions of shared addresses.
+	// It defines the variables app, the referencing BrokerApp, and owner, the BrokerApp sharing the address,
+	// with the helper functions of the library.
+	accessEnv *cel.Env
+
+	// accessProgramCache caches the compiled access programs by expression string, apart from
+	// celProgramCache as the same expression compiles differently in each environment
+	accessProgramCache *lru.Cache[string, cel.Program]
+)
+
+func init() {
+	var err error
+	accessEnv, err = cel.NewEnv(append([]cel.EnvOption{
+		cel.Variable("app", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("owner", cel.MapType(cel.StringType, cel.DynType)),
+	}, library()...)...)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create CEL access environment: %v", err))
+	}
+
+	accessProgramCache, err = lru.New[string, cel.Program](1000)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create CEL access program cache: %v", err))
+	}
+}
+
+// ValidateAccessExpression validates the access expression of a shared address without evaluating it.
+func ValidateAccessExpression(expression string) error {
+	_, err := getOrCompileAccessProgram(expression)
+	return err
+}
+
+// MatchesAccess checks if the app referencing a shared address of the owner matches an access expression.
+// Returns (matches, error). On error, matches will be false (fail-safe).
+func MatchesAccess(expression string, app *broker.BrokerApp, owner *broker.BrokerApp) (bool, error) {
+	if app == nil || owner == nil {
+		return false, fmt.Errorf("app and owner must not be nil")
+	}
+
+	program, err := getOrCompileAccessProgram(expression)
+	if err != nil {
+		return false, err
+	}
+
+	appMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert BrokerApp to unstructured: %w", err)
+	}
+	ownerMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(owner)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert BrokerApp to unstructured: %w", err)
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		"app":   appMap,
+		"owner": ownerMap,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL expression: %w", err)
+	}
+
+	result, ok := out.(celtypes.Bool)
+	if !ok {
+		return false, fmt.Errorf("CEL expression returned non-boolean type: %v", out.Type())
+	}
+	return bool(result), nil
+}
+
+func getOrCompileAccessProgram(expression string) (cel.Program, error) {
+	if program, found := accessProgramCache.Get(expression); found {
+		return program, nil
+	}
+
+	ast, issues := accessEnv.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("CEL expression must return boolean, got %v", ast.OutputType())
+	}
+
+	program, err := accessEnv.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL program: %w", err)
+	}
+	accessProgramCache.Add(expression, program)
+	return program, nil
+}
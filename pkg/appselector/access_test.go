@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appselector
+
+import (
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Shared Address Access Expressions", func() {
+	var app, owner *v1beta2.BrokerApp
+
+	BeforeEach(func() {
+		app = &v1beta2.BrokerApp{ObjectMeta: v1.ObjectMeta{Name: "audit", Namespace: "team-a", Labels: map[string]string{"team": "payments"}}}
+		owner = &v1beta2.BrokerApp{ObjectMeta: v1.ObjectMeta{Name: "orders", Namespace: "team-b", Labels: map[string]string{"team": "payments"}}}
+	})
+
+	It("evaluates with the app and the owner", func() {
+		matches, err := MatchesAccess(`label(app, "team") == label(owner, "team") && app.metadata.namespace.glob("team-*")`, app, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeTrue())
+
+		app.Labels["team"] = "billing"
+		matches, err = MatchesAccess(`label(app, "team") == label(owner, "team")`, app, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeFalse())
+	})
+
+	It("rejects the variables of appSelectorExpression", func() {
+		Expect(ValidateAccessExpression(`service.metadata.name == "svc"`)).NotTo(Succeed())
+		Expect(ValidateAccessExpression(`owner.metadata.name`)).NotTo(Succeed())
+		Expect(ValidateExpression(`owner.metadata.name == "orders"`)).NotTo(Succeed())
+	})
+
+	It("caches apart from appSelectorExpression", func() {
+		accessProgramCache.Purge()
+		celProgramCache.Purge()
+		expression := `app.metadata.namespace == "team-a"`
+
+		matches, err := MatchesAccess(expression, app, owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeTrue())
+		Expect(accessProgramCache.Contains(expression)).To(BeTrue())
+		Expect(celProgramCache.Contains(expression)).To(BeFalse())
+	})
+})
@@ -41,7 +41,8 @@ const (
 
 var (
 	// celEnv is the CEL environment, created once at package initialization.
-	// It defines the available variables (app, service, appNamespace, serviceNamespace) for CEL expressions.
+	// It defines the available variables (app, service, appNamespace, serviceNamespace, load) and the
+	// helper functions of the library for CEL expressions.
 	celEnv *cel.Env
 
 	// celProgramCache caches compiled CEL programs with metadata by expression string.
@@ -64,12 +65,13 @@ type celProgramMetadata struct {
 // init initializes the CEL environment and program cache once at package load time.
 func init() {
 	var err error
-	celEnv, err = cel.NewEnv(
+	celEnv, err = cel.NewEnv(append([]cel.EnvOption{
 		cel.Variable("app", cel.MapType(cel.StringType, cel.DynType)),
 		cel.Variable("service", cel.MapType(cel.StringType, cel.DynType)),
 		cel.Variable("appNamespace", cel.MapType(cel.StringType, cel.DynType)),
 		cel.Variable("serviceNamespace", cel.MapType(cel.StringType, cel.DynType)),
-	)
+		cel.Variable("load", cel.MapType(cel.StringType, cel.DynType)),
+	}, library()...)...)
 	if err != nil {
 		// This should never happen with valid variable declarations
 		panic(fmt.Sprintf("failed to create CEL environment: %v", err))
@@ -124,6 +126,17 @@ func Matches(app *broker.BrokerApp, service *broker.BrokerService, client client
 	return evaluateExpression(expression, app, service, client)
 }
 
+// MatchesService checks if a service matches an app's serviceSelectorExpression.
+// An empty expression matches every service.
+// Returns (matches, error). On error, matches will be false (fail-safe).
+func MatchesService(app *broker.BrokerApp, service *broker.BrokerService, client client.Client) (bool, error) {
+	if app.Spec.ServiceSelectorExpression == "" {
+		return true, nil
+	}
+
+	return evaluateExpression(app.Spec.ServiceSelectorExpression, app, service, client)
+}
+
 // analyzeVariableUsage walks the CEL AST to determine which variables are referenced.
 // This allows us to skip fetching namespace objects when they're not used in the expression.
 func analyzeVariableUsage(ast *cel.Ast) (usesAppNamespace, usesServiceNamespace bool) {
@@ -245,6 +258,7 @@ func evaluateExpression(expression string, app *broker.BrokerApp, service *broke
 		"service":          serviceMap,
 		"appNamespace":     appNamespaceMap,
 		"serviceNamespace": serviceNamespaceMap,
+		"load":             serviceLoad(app, service),
 	})
 	if err != nil {
 		return false, fmt.Errorf("failed to evaluate CEL expression: %w", err)
@@ -0,0 +1,203 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appselector
+
+import (
+	"path"
+
+	"github.com/blang/semver/v4"
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	broker "github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+)
+
+// library returns the helper functions of appSelectorExpression:
+//   - label(object, key) and label(object, key, default): a label of app, service or a namespace
+//   - annotation(object, key) and annotation(object, key, default): an annotation of app, service or a namespace
+//   - name.glob(pattern): shell pattern matching, the standard name.matches(regex) does regex matching
+//   - quantity(string): the value of a resource quantity, e.g. quantity("256Mi") or quantity("500m")
+//   - compareQuantities(a, b): -1, 0 or 1 as quantity a is less than, equal to or greater than b
+//   - compareSemver(a, b): -1, 0 or 1 as version a is lower than, equal to or greater than b
+//   - semverInRange(version, range): whether the version is in the range, e.g. ">=2.40.0 <3.0.0"
+func library() []cel.EnvOption {
+	objectType := cel.MapType(cel.StringType, cel.DynType)
+	return []cel.EnvOption{
+		cel.Function("label",
+			cel.Overload("label_object_string", []*cel.Type{objectType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(object, key ref.Val) ref.Val {
+					return metadataValue(object, "labels", key, celtypes.String(""))
+				})),
+			cel.Overload("label_object_string_string", []*cel.Type{objectType, cel.StringType, cel.StringType}, cel.StringType,
+				cel.FunctionBinding(func(args ...ref.Val) ref.Val {
+					return metadataValue(args[0], "labels", args[1], args[2])
+				})),
+		),
+		cel.Function("annotation",
+			cel.Overload("annotation_object_string", []*cel.Type{objectType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(object, key ref.Val) ref.Val {
+					return metadataValue(object, "annotations", key, celtypes.String(""))
+				})),
+			cel.Overload("annotation_object_string_string", []*cel.Type{objectType, cel.StringType, cel.StringType}, cel.StringType,
+				cel.FunctionBinding(func(args ...ref.Val) ref.Val {
+					return metadataValue(args[0], "annotations", args[1], args[2])
+				})),
+		),
+		cel.Function("glob",
+			cel.MemberOverload("string_glob_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(name, pattern ref.Val) ref.Val {
+					matched, err := path.Match(string(pattern.(celtypes.String)), string(name.(celtypes.String)))
+					if err != nil {
+						return celtypes.NewErr("invalid glob pattern %q: %v", pattern, err)
+					}
+					return celtypes.Bool(matched)
+				})),
+		),
+		cel.Function("quantity",
+			cel.Overload("quantity_string", []*cel.Type{cel.StringType}, cel.DoubleType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					quantity, err := parseQuantity(value)
+					if err != nil {
+						return err
+					}
+					return celtypes.Double(quantity.AsApproximateFloat64())
+				})),
+		),
+		cel.Function("compareQuantities",
+			cel.Overload("compareQuantities_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					quantityA, err := parseQuantity(a)
+					if err != nil {
+						return err
+					}
+					quantityB, err := parseQuantity(b)
+					if err != nil {
+						return err
+					}
+					return celtypes.Int(quantityA.Cmp(quantityB))
+				})),
+		),
+		cel.Function("compareSemver",
+			cel.Overload("compareSemver_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(func(a, b ref.Val) ref.Val {
+					versionA, err := parseSemver(a)
+					if err != nil {
+						return err
+					}
+					versionB, err := parseSemver(b)
+					if err != nil {
+						return err
+					}
+					return celtypes.Int(versionA.Compare(versionB))
+				})),
+		),
+		cel.Function("semverInRange",
+			cel.Overload("semverInRange_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(value, versionRange ref.Val) ref.Val {
+					version, err := parseSemver(value)
+					if err != nil {
+						return err
+					}
+					inRange, rangeErr := semver.ParseRange(string(versionRange.(celtypes.String)))
+					if rangeErr != nil {
+						return celtypes.NewErr("invalid semver range %q: %v", versionRange, rangeErr)
+					}
+					return celtypes.Bool(inRange(version))
+				})),
+		),
+	}
+}
+
+// metadataValue returns the value of key in the labels or annotations of the object, or the default
+// when the object has none
+func metadataValue(object ref.Val, field string, key ref.Val, defaultValue ref.Val) ref.Val {
+	current := object
+	for _, name := range []ref.Val{celtypes.String("metadata"), celtypes.String(field), key} {
+		mapper, ok := current.(traits.Mapper)
+		if !ok {
+			return defaultValue
+		}
+		value, found := mapper.Find(name)
+		if !found || celtypes.IsError(value) {
+			return defaultValue
+		}
+		current = value
+	}
+	if value, ok := current.(celtypes.String); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func parseQuantity(value ref.Val) (resource.Quantity, ref.Val) {
+	quantity, err := resource.ParseQuantity(string(value.(celtypes.String)))
+	if err != nil {
+		return quantity, celtypes.NewErr("invalid quantity %q: %v", value, err)
+	}
+	return quantity, nil
+}
+
+func parseSemver(value ref.Val) (semver.Version, ref.Val) {
+	version, err := semver.ParseTolerant(string(value.(celtypes.String)))
+	if err != nil {
+		return version, celtypes.NewErr("invalid semver %q: %v", value, err)
+	}
+	return version, nil
+}
+
+// serviceLoad returns the load variable, the load of the service as last reported in its status.
+// The allocation of the app itself is not counted, so that an app keeps matching once placed:
+//   - apps: number of apps placed on the service
+//   - memory: memory allocated to the apps, in bytes
+//   - memoryLimit: memory limit of all the peers, in bytes, 0 when unbounded
+//   - memoryUtilization: memory over memoryLimit, between 0 and 1, 0 when unbounded
+func serviceLoad(app *broker.BrokerApp, service *broker.BrokerService) map[string]interface{} {
+	var apps int64
+	allocated := resource.Quantity{}
+	for _, allocation := range service.Status.Allocations {
+		if allocation.Name == app.Name && allocation.Namespace == app.Namespace {
+			continue
+		}
+		apps++
+		if memory, found := allocation.Allocated[corev1.ResourceMemory]; found {
+			allocated.Add(memory)
+		}
+	}
+
+	limit := resource.Quantity{}
+	for _, peer := range service.Status.Capacity {
+		if memory, found := peer.Limits[corev1.ResourceMemory]; found {
+			limit.Add(memory)
+		}
+	}
+
+	utilization := 0.0
+	if !limit.IsZero() {
+		utilization = allocated.AsApproximateFloat64() / limit.AsApproximateFloat64()
+	}
+
+	return map[string]interface{}{
+		"apps":              apps,
+		"memory":            allocated.Value(),
+		"memoryLimit":       limit.Value(),
+		"memoryUtilization": utilization,
+	}
+}
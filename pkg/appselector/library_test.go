@@ -0,0 +1,151 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appselector
+
+import (
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CEL Function Library", func() {
+	var (
+		app        *v1beta2.BrokerApp
+		service    *v1beta2.BrokerService
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		app = &v1beta2.BrokerApp{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        "orders",
+				Namespace:   "team-a",
+				Labels:      map[string]string{"tier": "premium"},
+				Annotations: map[string]string{"client-version": "2.41.0"},
+			},
+			Spec: v1beta2.BrokerAppSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+			},
+		}
+		service = &v1beta2.BrokerService{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "shared-broker",
+				Namespace: "shared",
+			},
+			Status: v1beta2.BrokerServiceStatus{
+				Capacity: []v1beta2.BrokerServicePeerCapacityStatus{
+					{Peer: 0, Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+					{Peer: 1, Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+				},
+				Allocations: []v1beta2.BrokerServiceAppAllocationStatus{
+					{Name: "billing", Namespace: "team-b", Allocated: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+					{Name: "audit", Namespace: "team-b", Peer: 1, Allocated: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}},
+					{Name: "orders", Namespace: "team-a", Allocated: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}},
+				},
+			},
+		}
+		fakeClient = newFakeClientForCEL("team-a", "shared")
+	})
+
+	DescribeTable("evaluates library functions",
+		func(expr string, expected bool) {
+			result, err := evaluateExpression(expr, app, service, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("label", `label(app, "tier") == "premium"`, true),
+		Entry("missing label with default", `label(app, "team", "none") == "none"`, true),
+		Entry("label of an object without labels", `label(service, "tier") == ""`, true),
+		Entry("annotation with default", `annotation(app, "client-version", "0.0.0") == "2.41.0"`, true),
+		Entry("label of a namespace not fetched", `label(appNamespace, "env", "dev") == "dev"`, true),
+		Entry("glob", `app.metadata.namespace.glob("team-*")`, true),
+		Entry("glob no match", `app.metadata.name.glob("team-?")`, false),
+		Entry("regex", `app.metadata.namespace.matches("^team-[a-z]$")`, true),
+		Entry("quantity", `quantity("1Gi") == 1073741824.0 && quantity("500m") == 0.5`, true),
+		Entry("quantity of a request", `quantity(app.spec.resources.requests.memory) <= quantity("256Mi")`, true),
+		Entry("compareQuantities", `compareQuantities("1Gi", "1024Mi") == 0 && compareQuantities("1G", "1Gi") < 0`, true),
+		Entry("compareSemver", `compareSemver(annotation(app, "client-version"), "2.40.0") > 0`, true),
+		Entry("compareSemver tolerant", `compareSemver("v2.41", "2.41.0") == 0`, true),
+		Entry("semverInRange", `semverInRange("2.41.0", ">=2.40.0 <3.0.0")`, true),
+		Entry("semverInRange out of range", `semverInRange("3.1.0", ">=2.40.0 <3.0.0")`, false),
+	)
+
+	DescribeTable("fails on invalid arguments",
+		func(expr string) {
+			Expect(ValidateExpression(expr)).To(Succeed())
+			_, err := evaluateExpression(expr, app, service, fakeClient)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("invalid quantity", `quantity("lots") > 0.0`),
+		Entry("invalid semver", `compareSemver("latest", "2.40.0") > 0`),
+		Entry("invalid semver range", `semverInRange("2.41.0", "~>2")`),
+		Entry("invalid glob", `app.metadata.name.glob("[")`),
+	)
+
+	It("rejects calls with wrong argument types", func() {
+		Expect(ValidateExpression(`label(app, 1) == ""`)).NotTo(Succeed())
+		Expect(ValidateExpression(`quantity(1) > 0.0`)).NotTo(Succeed())
+	})
+
+	It("describes the load of the service without the app", func() {
+		result, err := evaluateExpression(
+			`load.apps == 2 && load.memory == quantity("1536Mi") && load.memoryLimit == quantity("2Gi") && load.memoryUtilization == 0.75`,
+			app, service, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeTrue())
+	})
+
+	It("evaluates a small apps from teams under memory pressure policy", func() {
+		expr := `app.metadata.namespace.glob("team-*") &&
+			quantity(app.spec.resources.requests.memory) <= quantity("256Mi") &&
+			load.memoryUtilization < 0.8`
+
+		result, err := evaluateExpression(expr, app, service, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeTrue())
+
+		service.Status.Allocations[1].Allocated[corev1.ResourceMemory] = resource.MustParse("768Mi")
+		result, err = evaluateExpression(expr, app, service, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeFalse())
+	})
+
+	It("has no memory utilization without a memory limit", func() {
+		service.Status.Capacity = nil
+		result, err := evaluateExpression(`load.memoryLimit == 0 && load.memoryUtilization == 0.0`, app, service, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeTrue())
+	})
+
+	It("caches expressions using the library", func() {
+		celProgramCache.Purge()
+		expr := `label(app, "tier", "standard") == "premium"`
+		for range 2 {
+			result, err := evaluateExpression(expr, app, service, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		}
+		Expect(celProgramCache.Len()).To(Equal(1))
+		Expect(celProgramCache.Contains(expr)).To(BeTrue())
+	})
+})
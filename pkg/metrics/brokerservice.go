@@ -17,6 +17,9 @@ limitations under the License.
 package metrics
 
 import (
+	"strconv"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -31,13 +34,78 @@ var (
 		},
 		[]string{"service", "namespace"},
 	)
+
+	// ServiceCapacityLimit tracks the limit of each bounded dimension of each peer of a service
+	ServiceCapacityLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_capacity_limit",
+			Help: "Limit of a dimension on a peer of the service, bytes for memory and storage, cores for cpu",
+		},
+		[]string{"service", "namespace", "peer", "dimension"},
+	)
+
+	// ServiceCapacityAllocated tracks the amount of each dimension allocated to the apps on each peer of a service
+	ServiceCapacityAllocated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_capacity_allocated",
+			Help: "Amount of a dimension allocated to the apps on a peer of the service, bytes for memory and storage, cores for cpu",
+		},
+		[]string{"service", "namespace", "peer", "dimension"},
+	)
+
+	// ServicePortsUsed tracks the ports of the pool of a service assigned to apps
+	ServicePortsUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_ports_used",
+			Help: "Number of ports of the pool of the service assigned to apps",
+		},
+		[]string{"service", "namespace"},
+	)
+
+	// ServicePortsFree tracks the ports of the pool of a service available to apps
+	ServicePortsFree = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_ports_free",
+			Help: "Number of ports of the pool of the service available to apps",
+		},
+		[]string{"service", "namespace"},
+	)
+
+	// ServiceAppPort tracks the port assigned to each app placed on a service
+	ServiceAppPort = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_app_port",
+			Help: "Port assigned to an app placed on the service",
+		},
+		[]string{"service", "namespace", "app", "app_namespace", "peer"},
+	)
+
+	// ServiceAppAllocated tracks the amount of each dimension allocated to each app placed on a service
+	ServiceAppAllocated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "brokerservice_app_allocated",
+			Help: "Amount of a dimension allocated to an app placed on the service, bytes for memory and storage, cores for cpu",
+		},
+		[]string{"service", "namespace", "app", "app_namespace", "peer", "dimension"},
+	)
 )
 
+// serviceGauges are the gauges labeled by service and namespace
+var serviceGauges = []*prometheus.GaugeVec{
+	ServiceAppsProvisioned,
+	ServiceCapacityLimit,
+	ServiceCapacityAllocated,
+	ServicePortsUsed,
+	ServicePortsFree,
+	ServiceAppPort,
+	ServiceAppAllocated,
+}
+
 func init() {
 	// Register with controller-runtime's metrics registry
-	metrics.Registry.MustRegister(
-		ServiceAppsProvisioned,
-	)
+	for _, gauge := range serviceGauges {
+		metrics.Registry.MustRegister(gauge)
+	}
 }
 
 // UpdateServiceMetrics updates all gauge metrics for a BrokerService
@@ -47,10 +115,45 @@ func UpdateServiceMetrics(name, namespace string, appCount int) {
 	ServiceAppsProvisioned.With(labels).Set(float64(appCount))
 }
 
+// UpdateServiceCapacityMetrics updates the capacity, port and allocation gauges of a BrokerService from its status,
+// the gauges of peers and apps no longer on the service are removed
+func UpdateServiceCapacityMetrics(name, namespace string, status *v1beta2.BrokerServiceStatus) {
+	labels := prometheus.Labels{"service": name, "namespace": namespace}
+
+	for _, gauge := range []*prometheus.GaugeVec{ServiceCapacityLimit, ServiceCapacityAllocated, ServiceAppPort, ServiceAppAllocated} {
+		gauge.DeletePartialMatch(labels)
+	}
+
+	for _, peer := range status.Capacity {
+		peerIndex := strconv.Itoa(int(peer.Peer))
+		for dimension, quantity := range peer.Limits {
+			ServiceCapacityLimit.WithLabelValues(name, namespace, peerIndex, string(dimension)).Set(quantity.AsApproximateFloat64())
+		}
+		for dimension, quantity := range peer.Allocated {
+			ServiceCapacityAllocated.WithLabelValues(name, namespace, peerIndex, string(dimension)).Set(quantity.AsApproximateFloat64())
+		}
+	}
+
+	if status.Ports != nil {
+		ServicePortsUsed.With(labels).Set(float64(status.Ports.Used))
+		ServicePortsFree.With(labels).Set(float64(status.Ports.Free))
+	}
+
+	for _, allocation := range status.Allocations {
+		peerIndex := strconv.Itoa(int(allocation.Peer))
+		ServiceAppPort.WithLabelValues(name, namespace, allocation.Name, allocation.Namespace, peerIndex).Set(float64(allocation.Port))
+		for dimension, quantity := range allocation.Allocated {
+			ServiceAppAllocated.WithLabelValues(name, namespace, allocation.Name, allocation.Namespace, peerIndex, string(dimension)).Set(quantity.AsApproximateFloat64())
+		}
+	}
+}
+
 // DeleteServiceMetrics removes all metrics for a service when it's deleted
 // Note: Counters are intentionally not deleted as they represent cumulative data
 func DeleteServiceMetrics(name, namespace string) {
 	labels := prometheus.Labels{"service": name, "namespace": namespace}
 
-	ServiceAppsProvisioned.Delete(labels)
+	for _, gauge := range serviceGauges {
+		gauge.DeletePartialMatch(labels)
+	}
 }
@@ -17,16 +17,21 @@ limitations under the License.
 package metrics
 
 import (
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var _ = Describe("Service Metrics", func() {
 	BeforeEach(func() {
 		// Clean up metrics before each test
-		ServiceAppsProvisioned.Reset()
+		for _, gauge := range serviceGauges {
+			gauge.Reset()
+		}
 	})
 
 	It("UpdateServiceMetrics sets all gauges correctly", func() {
@@ -64,4 +69,40 @@ var _ = Describe("Service Metrics", func() {
 		}))
 		Expect(val2).To(Equal(float64(2)))
 	})
+
+	It("UpdateServiceCapacityMetrics exports the capacity status", func() {
+		status := &v1beta2.BrokerServiceStatus{
+			Capacity: []v1beta2.BrokerServicePeerCapacityStatus{{
+				Peer:      0,
+				Apps:      1,
+				Limits:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				Allocated: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+			}},
+			Ports: &v1beta2.BrokerServicePortsStatus{Total: 10, Used: 1, Free: 9},
+			Allocations: []v1beta2.BrokerServiceAppAllocationStatus{{
+				Name:      "orders",
+				Namespace: "apps",
+				Port:      61616,
+				Allocated: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi"), "addresses": resource.MustParse("2")},
+			}},
+		}
+		UpdateServiceCapacityMetrics("capacity", "test-ns", status)
+
+		Expect(testutil.ToFloat64(ServiceCapacityLimit.WithLabelValues("capacity", "test-ns", "0", "memory"))).To(Equal(float64(2 * 1024 * 1024 * 1024)))
+		Expect(testutil.ToFloat64(ServiceCapacityAllocated.WithLabelValues("capacity", "test-ns", "0", "memory"))).To(Equal(float64(512 * 1024 * 1024)))
+		Expect(testutil.ToFloat64(ServicePortsUsed.WithLabelValues("capacity", "test-ns"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(ServicePortsFree.WithLabelValues("capacity", "test-ns"))).To(Equal(float64(9)))
+		Expect(testutil.ToFloat64(ServiceAppPort.WithLabelValues("capacity", "test-ns", "orders", "apps", "0"))).To(Equal(float64(61616)))
+		Expect(testutil.ToFloat64(ServiceAppAllocated.WithLabelValues("capacity", "test-ns", "orders", "apps", "0", "addresses"))).To(Equal(float64(2)))
+
+		// the app left the service
+		status.Allocations = nil
+		UpdateServiceCapacityMetrics("capacity", "test-ns", status)
+		Expect(testutil.CollectAndCount(ServiceAppAllocated)).To(Equal(0))
+		Expect(testutil.CollectAndCount(ServiceCapacityLimit)).To(Equal(1))
+
+		DeleteServiceMetrics("capacity", "test-ns")
+		Expect(testutil.CollectAndCount(ServiceCapacityLimit)).To(Equal(0))
+		Expect(testutil.CollectAndCount(ServicePortsFree)).To(Equal(0))
+	})
 })
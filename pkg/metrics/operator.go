@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+
+	// AppBinds counts the BrokerApps bound to a service, labeled by why the app was bound
+	AppBinds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "brokerapp_binds_total",
+			Help: "Number of BrokerApps bound to a service",
+		},
+		[]string{"reason"},
+	)
+
+	// AppUnbinds counts the BrokerApps that lost their binding without another service to bind to
+	AppUnbinds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "brokerapp_unbinds_total",
+			Help: "Number of BrokerApps unbound from a service without another service with capacity",
+		},
+		[]string{"reason"},
+	)
+
+	// AppRebinds counts the BrokerApps moved from one binding to another
+	AppRebinds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "brokerapp_rebinds_total",
+			Help: "Number of BrokerApps rebound to another service or peer",
+		},
+		[]string{"reason"},
+	)
+
+	// AppPlacementRejections counts the services that could not take a BrokerApp, labeled by rejection category,
+	// once per placement decision
+	AppPlacementRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "brokerapp_placement_rejections_total",
+			Help: "Number of services rejected by BrokerApp placement decisions",
+		},
+		[]string{"category"},
+	)
+
+	// AppTimeToReady observes the time from the creation of a BrokerApp to its Ready condition turning true
+	AppTimeToReady = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "brokerapp_time_to_ready_seconds",
+			Help:    "Time from the creation of a BrokerApp to its Ready condition turning true",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		},
+	)
+
+	// ReconcileDuration observes the duration of each reconcile, labeled by controller
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "operator_reconcile_duration_seconds",
+			Help:    "Duration of a reconcile",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"controller"},
+	)
+
+	// ReconcileErrors counts the reconciles that returned an error, labeled by controller
+	ReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "operator_reconcile_errors_total",
+			Help: "Number of reconciles that returned an error",
+		},
+		[]string{"controller"},
+	)
+
+	// JolokiaDuration observes the latency of each jolokia request, labeled by attribute or operation
+	JolokiaDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jolokia_request_duration_seconds",
+			Help:    "Latency of a jolokia request to a broker",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		},
+		[]string{"operation"},
+	)
+
+	// JolokiaErrors counts the jolokia requests that failed, labeled by attribute or operation
+	JolokiaErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jolokia_request_errors_total",
+			Help: "Number of jolokia requests to a broker that failed",
+		},
+		[]string{"operation"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		AppBinds,
+		AppUnbinds,
+		AppRebinds,
+		AppPlacementRejections,
+		AppTimeToReady,
+		ReconcileDuration,
+		ReconcileErrors,
+		JolokiaDuration,
+		JolokiaErrors,
+	)
+}
+
+// ObserveReconcile records the duration of a reconcile that started at start and counts its error,
+// to be deferred with the named error result of the reconcile
+func ObserveReconcile(controller string, start time.Time, err *error) {
+	ReconcileDuration.WithLabelValues(controller).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		ReconcileErrors.WithLabelValues(controller).Inc()
+	}
+}
+
+// ObserveJolokia records the latency of a jolokia request that started at start and counts its error,
+// to be deferred with the named error result of the request
+func ObserveJolokia(operation string, start time.Time, err *error) {
+	JolokiaDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		JolokiaErrors.WithLabelValues(operation).Inc()
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Operator Metrics", func() {
+	BeforeEach(func() {
+		ReconcileDuration.Reset()
+		ReconcileErrors.Reset()
+		JolokiaDuration.Reset()
+		JolokiaErrors.Reset()
+	})
+
+	It("ObserveReconcile records the duration and counts errors", func() {
+		var err error
+		ObserveReconcile("brokerapp", time.Now(), &err)
+		Expect(testutil.CollectAndCount(ReconcileDuration)).To(Equal(1))
+		Expect(testutil.ToFloat64(ReconcileErrors.WithLabelValues("brokerapp"))).To(Equal(float64(0)))
+
+		err = errors.New("failed")
+		ObserveReconcile("brokerapp", time.Now(), &err)
+		Expect(testutil.ToFloat64(ReconcileErrors.WithLabelValues("brokerapp"))).To(Equal(float64(1)))
+	})
+
+	It("ObserveJolokia labels by operation", func() {
+		var err error
+		ObserveJolokia("read:MessageCount", time.Now(), &err)
+		err = errors.New("unreachable")
+		ObserveJolokia("exec:createQueue", time.Now(), &err)
+
+		Expect(testutil.CollectAndCount(JolokiaDuration)).To(Equal(2))
+		Expect(testutil.ToFloat64(JolokiaErrors.WithLabelValues("read:MessageCount"))).To(Equal(float64(0)))
+		Expect(testutil.ToFloat64(JolokiaErrors.WithLabelValues("exec:createQueue"))).To(Equal(float64(1)))
+	})
+})
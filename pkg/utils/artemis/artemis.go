@@ -1,9 +1,11 @@
 package artemis
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -221,3 +223,68 @@ func (artemis *Artemis) GetTotalMessageCount() (string, error) {
 	}
 	return resp.Value, nil
 }
+
+// GetQueueMessageCount returns the number of messages on a queue, QUEUE_NOT_EXISTS when the queue is not deployed
+func (artemis *Artemis) GetQueueMessageCount(addressName string, queueName string, routingType string) (int64, error) {
+	return artemis.getQueueAttribute(addressName, queueName, routingType, "MessageCount")
+}
+
+// QueueStatistics are the runtime statistics of a queue
+type QueueStatistics struct {
+	MessageCount    int64
+	ConsumerCount   int64
+	DeliveringCount int64
+	PersistentSize  int64
+}
+
+// GetQueueStatistics returns the runtime statistics of a queue read in a single request,
+// QUEUE_NOT_EXISTS when the queue is not deployed
+func (artemis *Artemis) GetQueueStatistics(addressName string, queueName string, routingType string) (*QueueStatistics, error) {
+	url := "org.apache.activemq.artemis:broker=\"" + artemis.name + "\",component=addresses,address=\"" + addressName +
+		"\",subcomponent=queues,routing-type=\"" + strings.ToLower(routingType) + "\",queue=\"" + queueName + "\"/MessageCount,ConsumerCount,DeliveringCount,PersistentSize"
+	resp, err := artemis.jolokia.Read(url)
+	if resp != nil && strings.Contains(resp.ErrorType, "InstanceNotFoundException") {
+		return nil, errors.New(QUEUE_NOT_EXISTS)
+	}
+	if err != nil || resp == nil {
+		if err == nil {
+			err = errors.New(UNKNOWN_ERROR)
+		}
+		return nil, err
+	}
+	if resp.Status != 200 {
+		return nil, fmt.Errorf("unable to retrieve queue statistics %v", resp.Error)
+	}
+	// json numbers are decoded as float, large counts are formatted with an exponent
+	values := map[string]float64{}
+	if err := json.Unmarshal([]byte(resp.Value), &values); err != nil {
+		return nil, err
+	}
+	return &QueueStatistics{
+		MessageCount:    int64(values["MessageCount"]),
+		ConsumerCount:   int64(values["ConsumerCount"]),
+		DeliveringCount: int64(values["DeliveringCount"]),
+		PersistentSize:  int64(values["PersistentSize"]),
+	}, nil
+}
+
+func (artemis *Artemis) getQueueAttribute(addressName string, queueName string, routingType string, attribute string) (int64, error) {
+	url := "org.apache.activemq.artemis:broker=\"" + artemis.name + "\",component=addresses,address=\"" + addressName +
+		"\",subcomponent=queues,routing-type=\"" + strings.ToLower(routingType) + "\",queue=\"" + queueName + "\"/" + attribute
+	resp, err := artemis.jolokia.Read(url)
+	if resp != nil && strings.Contains(resp.ErrorType, "InstanceNotFoundException") {
+		return 0, errors.New(QUEUE_NOT_EXISTS)
+	}
+	if err != nil || resp == nil {
+		if err == nil {
+			err = errors.New(UNKNOWN_ERROR)
+		}
+		return 0, err
+	}
+	if resp.Status != 200 {
+		return 0, fmt.Errorf("unable to retrieve %s %v", attribute, resp.Error)
+	}
+	// json numbers are decoded as float, large counts are formatted with an exponent
+	value, err := strconv.ParseFloat(resp.Value, 64)
+	return int64(value), err
+}
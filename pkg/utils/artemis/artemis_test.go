@@ -117,3 +117,83 @@ func createMockArtemis(j jolokia.IJolokia) Artemis {
 		jolokia:     j,
 	}
 }
+
+func TestGetQueueMessageCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	j := jolokia.NewMockIJolokia(ctrl)
+
+	artemis := createMockArtemis(j)
+
+	j.
+		EXPECT().
+		Read(gomock.Eq("org.apache.activemq.artemis:broker=\"someBroker\",component=addresses,address=\"events\",subcomponent=queues,routing-type=\"multicast\",queue=\"audit\"/MessageCount")).
+		Return(&jolokia.ResponseData{Status: 200, Value: "1.5e+06"}, nil)
+	count, err := artemis.GetQueueMessageCount("events", "audit", "MULTICAST")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1500000), count)
+}
+
+func TestGetQueueMessageCountNotDeployed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	j := jolokia.NewMockIJolokia(ctrl)
+
+	artemis := createMockArtemis(j)
+
+	j.
+		EXPECT().
+		Read(gomock.Any()).
+		Return(&jolokia.ResponseData{
+			Status:    404,
+			ErrorType: "javax.management.InstanceNotFoundException",
+			Error:     "javax.management.InstanceNotFoundException : org.apache.activemq.artemis:broker=\"someBroker\"",
+		}, fmt.Errorf("javax.management.InstanceNotFoundException"))
+	count, err := artemis.GetQueueMessageCount("orders", "orders", "ANYCAST")
+
+	assert.EqualError(t, err, QUEUE_NOT_EXISTS)
+	assert.Zero(t, count)
+}
+
+func TestGetQueueMessageCountNoResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	j := jolokia.NewMockIJolokia(ctrl)
+
+	artemis := createMockArtemis(j)
+
+	// a request that could not be sent is not a count
+	j.
+		EXPECT().
+		Read(gomock.Any()).
+		Return(nil, nil)
+	count, err := artemis.GetQueueMessageCount("orders", "orders", "ANYCAST")
+
+	assert.EqualError(t, err, UNKNOWN_ERROR)
+	assert.Zero(t, count)
+}
+
+func TestGetQueueStatistics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	j := jolokia.NewMockIJolokia(ctrl)
+
+	artemis := createMockArtemis(j)
+
+	// the attributes are read in a single request
+	mbean := "org.apache.activemq.artemis:broker=\"someBroker\",component=addresses,address=\"orders\",subcomponent=queues,routing-type=\"anycast\",queue=\"orders\"/"
+	j.
+		EXPECT().
+		Read(gomock.Eq(mbean+"MessageCount,ConsumerCount,DeliveringCount,PersistentSize")).
+		Return(&jolokia.ResponseData{Status: 200, Value: `{"ConsumerCount":2,"DeliveringCount":3,"MessageCount":12,"PersistentSize":4096}`}, nil).
+		Times(1)
+	statistics, err := artemis.GetQueueStatistics("orders", "orders", "ANYCAST")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &QueueStatistics{MessageCount: 12, ConsumerCount: 2, DeliveringCount: 3, PersistentSize: 4096}, statistics)
+}
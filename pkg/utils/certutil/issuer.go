@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certutil
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Issuer signs client certificates with a CA key pair
+type Issuer struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// NewIssuerFromSecret loads the CA key pair from the tls.crt and tls.key items of a secret
+func NewIssuerFromSecret(secret *corev1.Secret) (*Issuer, error) {
+	return newIssuer(secret.Data["tls.crt"], secret.Data["tls.key"], "issuer secret "+secret.Name)
+}
+
+// NewIssuer loads the CA key pair from a PEM encoded cert and key
+func NewIssuer(certPem []byte, keyPem []byte) (*Issuer, error) {
+	return newIssuer(certPem, keyPem, "CA key pair")
+}
+
+func newIssuer(certPem []byte, keyPem []byte, source string) (*Issuer, error) {
+	keyPair, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key pair in %s, %w", source, err)
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cert in %s, %w", source, err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("cert in %s is not a CA", source)
+	}
+	key, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type in %s", source)
+	}
+	return &Issuer{cert: cert, key: key}, nil
+}
+
+// NewCA returns a PEM encoded self signed CA cert and key for the common name, valid from now for the duration
+func NewCA(commonName string, now time.Time, duration time.Duration) (certPem []byte, keyPem []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(duration),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	return encode(template, template, key, key)
+}
+
+// Cert returns the CA cert of the issuer
+func (issuer *Issuer) Cert() *x509.Certificate {
+	return issuer.cert
+}
+
+// IssueClientCert returns a PEM encoded client cert and key for the subject, valid from now for the duration
+func (issuer *Issuer) IssueClientCert(subject pkix.Name, now time.Time, duration time.Duration) (certPem []byte, keyPem []byte, err error) {
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return issuer.issue(&x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      subject,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(duration),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+}
+
+// IssueServingCert returns a PEM encoded serving cert and key for the DNS names, valid from now for the duration
+func (issuer *Issuer) IssueServingCert(dnsNames []string, now time.Time, duration time.Duration) (certPem []byte, keyPem []byte, err error) {
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return issuer.issue(&x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(duration),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+}
+
+// IssuedClientCert returns the PEM encoded client cert when it pairs with the key,
+// has the subject and was signed by the issuer, nil otherwise
+func (issuer *Issuer) IssuedClientCert(certPem []byte, keyPem []byte, subject pkix.Name) *x509.Certificate {
+	cert := issuer.issued(certPem, keyPem)
+	if cert == nil {
+		return nil
+	}
+	// the values of a multi-valued RDN are in the order of their encoding
+	if encoded, err := asn1.Marshal(subject.ToRDNSequence()); err != nil || !bytes.Equal(cert.RawSubject, encoded) {
+		return nil
+	}
+	return cert
+}
+
+// IssuedServingCert returns the PEM encoded serving cert when it pairs with the key,
+// is valid for each DNS name and was signed by the issuer, nil otherwise
+func (issuer *Issuer) IssuedServingCert(certPem []byte, keyPem []byte, dnsNames []string) *x509.Certificate {
+	cert := issuer.issued(certPem, keyPem)
+	if cert == nil {
+		return nil
+	}
+	for _, dnsName := range dnsNames {
+		if cert.VerifyHostname(dnsName) != nil {
+			return nil
+		}
+	}
+	return cert
+}
+
+func (issuer *Issuer) issue(template *x509.Certificate) (certPem []byte, keyPem []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encode(template, issuer.cert, key, issuer.key)
+}
+
+// issued returns the cert when it pairs with the key and was signed by the issuer, nil otherwise
+func (issuer *Issuer) issued(certPem []byte, keyPem []byte) *x509.Certificate {
+	keyPair, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil
+	}
+	if cert.CheckSignatureFrom(issuer.cert) != nil {
+		return nil
+	}
+	return cert
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// encode signs the template for the key with the parent and returns the PEM encoded cert and key
+func encode(template *x509.Certificate, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey crypto.Signer) (certPem []byte, keyPem []byte, err error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+	return certPem, keyPem, nil
+}
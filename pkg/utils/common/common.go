@@ -69,6 +69,7 @@ const (
 	// New naming convention (preferred)
 	DefaultOperatorCertSecretName   = "arkmq-org-broker-manager-cert"
 	DefaultOperatorCASecretName     = "arkmq-org-broker-manager-ca"
+	DefaultOperatorIssuerSecretName = "arkmq-org-broker-manager-issuer"
 	DefaultOperandCertSecretName    = "broker-cert"     // or can be prefixed with `cr.Name-`
 	DefaultPrometheusCertSecretName = "prometheus-cert" // or can be prefixed with `cr.Name-`
 	AppCertSecretSuffix             = "-app-cert"
@@ -108,7 +109,7 @@ var ClusterDomain *string
 
 var isOpenshift *bool
 
-var operatorCertSecretName, operatorCASecretName, prometheusCertSecretName *string
+var operatorCertSecretName, operatorCASecretName, operatorIssuerSecretName, prometheusCertSecretName *string
 
 // we may want to cache and require operator restart on rotation
 //var operatorCert *tls.Certificate
@@ -825,6 +826,27 @@ func UnsetOperatorCASecretName() {
 	operatorCASecretName = nil
 }
 
+// GetOperatorIssuerSecretName returns the name of the CA key pair secret used to issue app client certs
+func GetOperatorIssuerSecretName() string {
+	if operatorIssuerSecretName == nil {
+		if name, found := os.LookupEnv("ARKMQ_ORG_BROKER_MANAGER_ISSUER_SECRET_NAME"); found {
+			operatorIssuerSecretName = &name
+		} else {
+			defaultName := DefaultOperatorIssuerSecretName
+			operatorIssuerSecretName = &defaultName
+		}
+	}
+	return *operatorIssuerSecretName
+}
+
+func SetOperatorIssuerSecretName(name string) {
+	operatorIssuerSecretName = &name
+}
+
+func UnsetOperatorIssuerSecretName() {
+	operatorIssuerSecretName = nil
+}
+
 func GetPrometheusCertSecretName(cr *v1beta2.Broker, client rtclient.Client) string {
 	// Determine the base secret name (from env or default)
 	if prometheusCertSecretName == nil {
@@ -980,6 +1002,10 @@ func GetOperatorCASecret(client rtclient.Client) (*corev1.Secret, error) {
 	return GetOperatorSecretWithFallback(client, GetOperatorCASecretName(), legacyOperatorCASecretNameIfApplicable())
 }
 
+func GetOperatorIssuerSecret(client rtclient.Client) (*corev1.Secret, error) {
+	return GetOperatorSecret(client, GetOperatorIssuerSecretName())
+}
+
 func GetOperatorClientCertSecret(client rtclient.Client) (*corev1.Secret, error) {
 	return GetOperatorSecretWithFallback(client, GetOperatorCertSecretName(), legacyOperatorCertSecretNameIfApplicable())
 }
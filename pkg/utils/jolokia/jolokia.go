@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/metrics"
 	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
 	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -133,15 +135,13 @@ func (j *Jolokia) GetClientWithTimeout(timeout time.Duration) *http.Client {
 	return &httpClient
 }
 
-func (j *Jolokia) Read(_path string) (*ResponseData, error) {
+func (j *Jolokia) Read(_path string) (jdata *ResponseData, respError error) {
+	defer metrics.ObserveJolokia(readOperation(_path), time.Now(), &respError)
 
 	url := j.protocol + "://" + j.user + ":" + j.password + "@" + j.jolokiaURL + "/read/" + _path
 
 	jolokiaClient := j.getClient()
 
-	var respError error = nil
-	var jdata *ResponseData = nil
-
 	for {
 		req, err := http.NewRequest(http.MethodGet, url, nil)
 		if err != nil {
@@ -189,12 +189,11 @@ func (j *Jolokia) Exec(_path string, _postJsonString string) (*ResponseData, err
 	return j.ExecWithClient(j.getClient(), _path, _postJsonString)
 }
 
-func (j *Jolokia) ExecWithClient(jolokiaClient *http.Client, _path string, _postJsonString string) (*ResponseData, error) {
+func (j *Jolokia) ExecWithClient(jolokiaClient *http.Client, _path string, _postJsonString string) (jdata *ResponseData, execErr error) {
+	defer metrics.ObserveJolokia(execOperation(_postJsonString), time.Now(), &execErr)
 
 	url := j.protocol + "://" + j.user + ":" + j.password + "@" + j.jolokiaURL + "/exec/" + _path
 
-	var jdata *ResponseData
-	var execErr error = nil
 	for {
 		req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer([]byte(_postJsonString)))
 		if err != nil {
@@ -232,6 +231,23 @@ func (j *Jolokia) ExecWithClient(jolokiaClient *http.Client, _path string, _post
 	return jdata, execErr
 }
 
+// readOperation returns the attribute read by the path
+func readOperation(_path string) string {
+	return "read:" + _path[strings.LastIndex(_path, "/")+1:]
+}
+
+// execOperation returns the name of the operation executed by the request, without its signature
+func execOperation(_postJsonString string) string {
+	request := struct {
+		Operation string `json:"operation"`
+	}{}
+	if err := json.Unmarshal([]byte(_postJsonString), &request); err != nil || request.Operation == "" {
+		return "exec"
+	}
+	operation, _, _ := strings.Cut(request.Operation, "(")
+	return "exec:" + operation
+}
+
 func CheckResponse(resp *http.Response, jdata *ResponseData) error {
 
 	if isResponseSuccessful(resp.StatusCode) {
@@ -280,7 +296,16 @@ func decodeResponseData(resp *http.Response) (*ResponseData, map[string]interfac
 		}
 	}
 	if v, ok := rawData["value"]; ok {
-		if v != nil {
+		switch v.(type) {
+		case nil:
+		case map[string]interface{}, []interface{}:
+			// the values of a read of several attributes are keyed by attribute
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, rawData, err
+			}
+			result.Value = string(encoded)
+		default:
 			result.Value = fmt.Sprintf("%v", v)
 		}
 	}
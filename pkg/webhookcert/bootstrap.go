@@ -0,0 +1,263 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhookcert bootstraps the serving cert of the admission webhooks of the operator,
+// signed by a CA of its own, without depending on cert-manager
+package webhookcert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/certutil"
+	"github.com/arkmq-org/arkmq-org-broker-operator/v2/pkg/utils/common"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CADuration and CertDuration are the validity of the CA and of the serving cert
+	CADuration   = 5 * 365 * 24 * time.Hour
+	CertDuration = 365 * 24 * time.Hour
+
+	// RenewBefore is how long before expiry a cert is replaced
+	RenewBefore = 30 * 24 * time.Hour
+
+	// RefreshInterval is how often the cert is checked once the operator runs
+	RefreshInterval = 12 * time.Hour
+
+	CACertKey = "ca.crt"
+	CAKeyKey  = "ca.key"
+	CertKey   = "tls.crt"
+	KeyKey    = "tls.key"
+)
+
+// Bootstrap keeps the serving cert of the webhook server in a secret of the operator namespace,
+// in the cert dir of the webhook server and in the CA bundle of the webhook configurations
+type Bootstrap struct {
+	Client client.Client
+	Log    logr.Logger
+
+	Namespace   string
+	SecretName  string
+	ServiceName string
+	CertDir     string
+
+	ValidatingWebhookConfigurationName string
+	MutatingWebhookConfigurationName   string
+}
+
+// DNSNames returns the names the webhook service is reached by
+func (bootstrap *Bootstrap) DNSNames() []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", bootstrap.ServiceName, bootstrap.Namespace),
+		fmt.Sprintf("%s.%s.svc.%s", bootstrap.ServiceName, bootstrap.Namespace, common.GetClusterDomain()),
+	}
+}
+
+// Ensure issues the CA and the serving cert when they are missing, invalid or due for renewal,
+// writes the serving cert in the cert dir and injects the CA in the webhook configurations
+func (bootstrap *Bootstrap) Ensure(ctx context.Context) error {
+	secret, err := bootstrap.ensureSecret(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := bootstrap.writeCertDir(secret); err != nil {
+		return err
+	}
+	return bootstrap.injectCABundle(ctx, secret.Data[CACertKey])
+}
+
+// Start checks the cert every refresh interval, the webhook server reloads the cert dir on change
+func (bootstrap *Bootstrap) Start(ctx context.Context) error {
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := bootstrap.Ensure(ctx); err != nil {
+				bootstrap.Log.Error(err, "failed to refresh the webhook serving cert")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection is false, every replica serves the webhooks
+func (bootstrap *Bootstrap) NeedLeaderElection() bool {
+	return false
+}
+
+func (bootstrap *Bootstrap) ensureSecret(ctx context.Context, now time.Time) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: bootstrap.Namespace, Name: bootstrap.SecretName}
+	err := bootstrap.Client.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: bootstrap.Namespace, Name: bootstrap.SecretName},
+			Type:       corev1.SecretTypeOpaque,
+		}
+		if secret.Data, err = bootstrap.issue(nil, now); err != nil {
+			return nil, err
+		}
+		bootstrap.Log.V(1).Info("Creating the webhook serving cert", "secret", key)
+		if err = bootstrap.Client.Create(ctx, secret); apierrors.IsAlreadyExists(err) {
+			// created by another replica
+			err = bootstrap.Client.Get(ctx, key, secret)
+		}
+		return secret, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bootstrap.issue(secret.Data, now)
+	if err != nil {
+		return nil, err
+	}
+	if !equalData(data, secret.Data) {
+		bootstrap.Log.V(1).Info("Renewing the webhook serving cert", "secret", key)
+		secret.Data = data
+		if err = bootstrap.Client.Update(ctx, secret); err != nil {
+			return nil, err
+		}
+	}
+	return secret, nil
+}
+
+// issue returns the data of the secret with a CA and a serving cert valid beyond the renewal time,
+// the data is retained when it is
+func (bootstrap *Bootstrap) issue(data map[string][]byte, now time.Time) (map[string][]byte, error) {
+	renewal := now.Add(RenewBefore)
+
+	issuer, err := certutil.NewIssuer(data[CACertKey], data[CAKeyKey])
+	if err != nil || !renewal.Before(issuer.Cert().NotAfter) {
+		caCert, caKey, err := certutil.NewCA(bootstrap.ServiceName+"-ca", now, CADuration)
+		if err != nil {
+			return nil, err
+		}
+		if issuer, err = certutil.NewIssuer(caCert, caKey); err != nil {
+			return nil, err
+		}
+		data = map[string][]byte{CACertKey: caCert, CAKeyKey: caKey}
+	}
+
+	dnsNames := bootstrap.DNSNames()
+	if cert := issuer.IssuedServingCert(data[CertKey], data[KeyKey], dnsNames); cert != nil && renewal.Before(cert.NotAfter) {
+		return data, nil
+	}
+	certPem, keyPem, err := issuer.IssueServingCert(dnsNames, now, CertDuration)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		CACertKey: data[CACertKey],
+		CAKeyKey:  data[CAKeyKey],
+		CertKey:   certPem,
+		KeyKey:    keyPem,
+	}, nil
+}
+
+func (bootstrap *Bootstrap) writeCertDir(secret *corev1.Secret) error {
+	if err := os.MkdirAll(bootstrap.CertDir, 0700); err != nil {
+		return err
+	}
+	for _, name := range []string{CertKey, KeyKey} {
+		path := filepath.Join(bootstrap.CertDir, name)
+		if deployed, err := os.ReadFile(path); err == nil && bytes.Equal(deployed, secret.Data[name]) {
+			continue
+		}
+		if err := os.WriteFile(path, secret.Data[name], 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// injectCABundle sets the CA bundle of each webhook of the webhook configurations, a configuration
+// that is not installed or that the operator is not allowed to update is left to the installer
+func (bootstrap *Bootstrap) injectCABundle(ctx context.Context, caBundle []byte) error {
+	if name := bootstrap.ValidatingWebhookConfigurationName; name != "" {
+		configuration := &admissionv1.ValidatingWebhookConfiguration{}
+		err := bootstrap.updateConfiguration(ctx, name, configuration, func() bool {
+			updated := false
+			for index := range configuration.Webhooks {
+				updated = setCABundle(&configuration.Webhooks[index].ClientConfig, caBundle) || updated
+			}
+			return updated
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if name := bootstrap.MutatingWebhookConfigurationName; name != "" {
+		configuration := &admissionv1.MutatingWebhookConfiguration{}
+		err := bootstrap.updateConfiguration(ctx, name, configuration, func() bool {
+			updated := false
+			for index := range configuration.Webhooks {
+				updated = setCABundle(&configuration.Webhooks[index].ClientConfig, caBundle) || updated
+			}
+			return updated
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bootstrap *Bootstrap) updateConfiguration(ctx context.Context, name string, configuration client.Object, inject func() bool) error {
+	err := bootstrap.Client.Get(ctx, types.NamespacedName{Name: name}, configuration)
+	if err == nil && inject() {
+		bootstrap.Log.V(1).Info("Injecting the webhook CA bundle", "configuration", name)
+		err = bootstrap.Client.Update(ctx, configuration)
+	}
+	if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		bootstrap.Log.Info("Webhook CA bundle not injected", "configuration", name, "reason", err.Error())
+		return nil
+	}
+	return err
+}
+
+func setCABundle(clientConfig *admissionv1.WebhookClientConfig, caBundle []byte) bool {
+	if bytes.Equal(clientConfig.CABundle, caBundle) {
+		return false
+	}
+	clientConfig.CABundle = caBundle
+	return true
+}
+
+func equalData(data map[string][]byte, other map[string][]byte) bool {
+	if len(data) != len(other) {
+		return false
+	}
+	for key, value := range data {
+		if !bytes.Equal(value, other[key]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookcert
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newBootstrap(t *testing.T, objects ...runtime.Object) *Bootstrap {
+	return &Bootstrap{
+		Client:                             fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRuntimeObjects(objects...).Build(),
+		Log:                                logr.New(log.NullLogSink{}),
+		Namespace:                          "operator",
+		SecretName:                         "webhook-server-cert",
+		ServiceName:                        "webhook-service",
+		CertDir:                            t.TempDir(),
+		ValidatingWebhookConfigurationName: "validating-webhook-configuration",
+		MutatingWebhookConfigurationName:   "mutating-webhook-configuration",
+	}
+}
+
+func TestEnsure_CreatesSecretAndInjectsCABundle(t *testing.T) {
+	validating := &admissionv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+		Webhooks:   []admissionv1.ValidatingWebhook{{Name: "vbrokerapp.broker.arkmq.org"}, {Name: "vbroker.broker.arkmq.org"}},
+	}
+	// the mutating configuration is not installed
+	bootstrap := newBootstrap(t, validating)
+
+	assert.NoError(t, bootstrap.Ensure(context.TODO()))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, bootstrap.Client.Get(context.TODO(), types.NamespacedName{Namespace: "operator", Name: "webhook-server-cert"}, secret))
+	for _, key := range []string{CACertKey, CAKeyKey, CertKey, KeyKey} {
+		assert.NotEmpty(t, secret.Data[key], key)
+	}
+
+	_, err := tls.LoadX509KeyPair(filepath.Join(bootstrap.CertDir, CertKey), filepath.Join(bootstrap.CertDir, KeyKey))
+	assert.NoError(t, err)
+
+	assert.NoError(t, bootstrap.Client.Get(context.TODO(), types.NamespacedName{Name: validating.Name}, validating))
+	for _, webhook := range validating.Webhooks {
+		assert.Equal(t, secret.Data[CACertKey], webhook.ClientConfig.CABundle, webhook.Name)
+	}
+}
+
+func TestEnsure_RetainsValidCert(t *testing.T) {
+	bootstrap := newBootstrap(t)
+	assert.NoError(t, bootstrap.Ensure(context.TODO()))
+
+	issued := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: "operator", Name: "webhook-server-cert"}
+	assert.NoError(t, bootstrap.Client.Get(context.TODO(), key, issued))
+
+	// another replica starts with an empty cert dir
+	bootstrap.CertDir = t.TempDir()
+	assert.NoError(t, bootstrap.Ensure(context.TODO()))
+
+	retained := &corev1.Secret{}
+	assert.NoError(t, bootstrap.Client.Get(context.TODO(), key, retained))
+	assert.Equal(t, issued.ResourceVersion, retained.ResourceVersion)
+	assert.Equal(t, issued.Data, retained.Data)
+
+	deployed, err := os.ReadFile(filepath.Join(bootstrap.CertDir, CertKey))
+	assert.NoError(t, err)
+	assert.Equal(t, issued.Data[CertKey], deployed)
+}
+
+func TestIssue_RenewsExpiringCert(t *testing.T) {
+	bootstrap := newBootstrap(t)
+	now := time.Now()
+
+	data, err := bootstrap.issue(nil, now)
+	assert.NoError(t, err)
+
+	// within the renewal time of the serving cert only
+	renewed, err := bootstrap.issue(data, now.Add(CertDuration-RenewBefore+time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, data[CACertKey], renewed[CACertKey])
+	assert.NotEqual(t, data[CertKey], renewed[CertKey])
+
+	// within the renewal time of the CA
+	renewed, err = bootstrap.issue(data, now.Add(CADuration-RenewBefore+time.Hour))
+	assert.NoError(t, err)
+	assert.NotEqual(t, data[CACertKey], renewed[CACertKey])
+	assert.NotEqual(t, data[CertKey], renewed[CertKey])
+
+	// the service name changed
+	bootstrap.ServiceName = "other-service"
+	renewed, err = bootstrap.issue(data, now)
+	assert.NoError(t, err)
+	assert.Equal(t, data[CACertKey], renewed[CACertKey])
+	assert.NotEqual(t, data[CertKey], renewed[CertKey])
+
+	// invalid data is replaced
+	renewed, err = bootstrap.issue(map[string][]byte{CACertKey: []byte("not a cert")}, now)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, renewed[CACertKey])
+	assert.NotEmpty(t, renewed[CertKey])
+}